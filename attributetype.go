@@ -12,22 +12,22 @@ const (
 
 // AttributeTypeSchema represent schema of attribute type
 type AttributeTypeSchema struct {
-	NumericOID         string
-	Name               []string
-	Description        string
-	Obsolete           bool
-	SuperType          string
-	Equality           string
-	Ordering           string
-	SubString          string
-	Syntax             string
-	SyntaxOID          string
-	SyntaxLength       int32
-	SingleValue        bool
-	Collective         bool
-	NoUserModification bool
-	Usage              string
-	Extensions         map[string][]string
+	NumericOID         string              `json:"oid"`
+	Name               []string            `json:"name,omitempty"`
+	Description        string              `json:"desc,omitempty"`
+	Obsolete           bool                `json:"obsolete,omitempty"`
+	SuperType          string              `json:"sup,omitempty"`
+	Equality           string              `json:"equality,omitempty"`
+	Ordering           string              `json:"ordering,omitempty"`
+	SubString          string              `json:"substr,omitempty"`
+	Syntax             string              `json:"syntax,omitempty"`
+	SyntaxOID          string              `json:"-"`
+	SyntaxLength       int32               `json:"-"`
+	SingleValue        bool                `json:"single_value,omitempty"`
+	Collective         bool                `json:"collective,omitempty"`
+	NoUserModification bool                `json:"no_user_modification,omitempty"`
+	Usage              string              `json:"usage,omitempty"`
+	Extensions         map[string][]string `json:"extensions,omitempty"`
 }
 
 // NewAttributeTypeSchemaViaGenericSchema creates attribute type schema instance from GenericSchema
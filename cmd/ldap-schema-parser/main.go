@@ -7,9 +7,22 @@ import (
 	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
 )
 
+func parseStdin() {
+	scanner := ldapschemaparser.NewSchemaScanner(os.Stdin)
+	for scanner.Scan() {
+		log.Printf("Schema (line %d): %v", scanner.Line(), scanner.Text())
+		log.Printf("- %v", scanner.Schema())
+	}
+	if err := scanner.Err(); nil != err {
+		log.Printf("- ERR: %v", err)
+	}
+}
+
 func main() {
 	if len(os.Args) < 2 {
-		log.Printf("Argument: [LDAP_SCHEMA_TEXT] ...")
+		log.Printf("Argument: [LDAP_SCHEMA_TEXT] ... (reading schema texts from stdin when no argument given)")
+		parseStdin()
+		return
 	}
 	for _, arg := range os.Args[1:] {
 		log.Printf("Schema: %v", arg)
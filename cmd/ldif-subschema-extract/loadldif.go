@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/go-ldap/ldif"
 	ldap "gopkg.in/ldap.v2"
@@ -10,60 +12,51 @@ import (
 	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
 )
 
-func addEntryAttributeToStore(store *ldapschemaparser.LDAPSchemaStore, attr *ldap.EntryAttribute, verbose bool) (err error) {
-	switch attr.Name {
-	case "ldapSyntaxes":
-		for _, schemaText := range attr.Values {
-			if verbose {
-				log.Printf("ldapSyntaxes: %v", schemaText)
-			}
-			if nil != store {
-				if err = store.AddLDAPSyntaxSchemaText(schemaText); nil != err {
-					log.Printf("ERROR: cannot add LDAP syntax schema to store: %v - %v", schemaText, err)
-					return err
-				}
-			}
-		}
-	case "matchingRules":
-		for _, schemaText := range attr.Values {
-			if verbose {
-				log.Printf("matchingRules: %v", schemaText)
-			}
-			if nil != store {
-				if err = store.AddMatchingRuleSchemaText(schemaText); nil != err {
-					log.Printf("ERROR: cannot add matching rule schema to store: %v - %v", schemaText, err)
-					return err
-				}
-			}
+// schemaKindOfAttribute maps name of subschema attribute into kind of schema
+// held in its values.
+var schemaKindOfAttribute = map[string]ldapschemaparser.SchemaKind{
+	"ldapSyntaxes":      ldapschemaparser.SchemaKindLDAPSyntax,
+	"matchingRules":     ldapschemaparser.SchemaKindMatchingRule,
+	"olcAttributeTypes": ldapschemaparser.SchemaKindAttributeType,
+	"attributeTypes":    ldapschemaparser.SchemaKindAttributeType,
+	"olcObjectClasses":  ldapschemaparser.SchemaKindObjectClass,
+	"objectClasses":     ldapschemaparser.SchemaKindObjectClass,
+}
+
+// addSchemaValue adds schema definitions in attribute value into store.
+// Leading text such as the `{N}` ordering prefix of cn=config is dropped.
+func addSchemaValue(store *ldapschemaparser.LDAPSchemaStore, attrName string, kind ldapschemaparser.SchemaKind, value string, verbose bool) (err error) {
+	scanner := ldapschemaparser.NewSchemaScanner(strings.NewReader(value))
+	for scanner.Scan() {
+		if verbose {
+			log.Printf("%s: %v", attrName, scanner.Text())
 		}
-	case "matchingRuleUse":
-	case "olcAttributeTypes":
-		fallthrough
-	case "attributeTypes":
-		for _, schemaText := range attr.Values {
-			if verbose {
-				log.Printf("attributeTypes: %v", schemaText)
-			}
-			if nil != store {
-				if err = store.AddAttributeTypeSchemaText(schemaText); nil != err {
-					log.Printf("ERROR: cannot add attribute type schema to store: %v - %v", schemaText, err)
-					return err
-				}
+		if nil != store {
+			if err = store.AddGenericSchema(kind, scanner.Schema()); nil != err {
+				log.Printf("ERROR: cannot add %s schema to store: %v - %v", kind, scanner.Text(), err)
+				return err
 			}
 		}
-	case "olcObjectClasses":
-		fallthrough
-	case "objectClasses":
-		for _, schemaText := range attr.Values {
-			if verbose {
-				log.Printf("objectClasses: %v", schemaText)
-			}
-			if nil != store {
-				if err = store.AddObjectClassSchemaText(schemaText); nil != err {
-					log.Printf("ERROR: cannot add object class schema to store: %v - %v", schemaText, err)
-					return err
-				}
-			}
+	}
+	if err = scanner.Err(); nil != err {
+		log.Printf("ERROR: cannot scan schema texts of attribute %v: %v", attrName, err)
+		return err
+	}
+	if trailing := scanner.LeadingText(); "" != trailing {
+		log.Printf("ERROR: unexpected text after schema definition of attribute %v: %v", attrName, trailing)
+		return errors.New("unexpected text after schema definition: " + trailing)
+	}
+	return nil
+}
+
+func addEntryAttributeToStore(store *ldapschemaparser.LDAPSchemaStore, attr *ldap.EntryAttribute, verbose bool) (err error) {
+	kind, ok := schemaKindOfAttribute[attr.Name]
+	if !ok {
+		return nil
+	}
+	for _, value := range attr.Values {
+		if err = addSchemaValue(store, attr.Name, kind, value, verbose); nil != err {
+			return err
 		}
 	}
 	return nil
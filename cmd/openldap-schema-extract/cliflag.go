@@ -0,0 +1,23 @@
+package main
+
+import (
+	"errors"
+	"flag"
+)
+
+func parseCommandParam() (schemaPaths []string, outputPath string, verbose bool, err error) {
+	flag.StringVar(&outputPath, "out", "", "path to write into")
+	flag.BoolVar(&verbose, "verbose", false, "enable verbose mode")
+	flag.Parse()
+	schemaPaths = flag.Args()
+	if 0 == len(schemaPaths) {
+		err = errors.New("require input OpenLDAP schema files")
+		return
+	}
+	if "" == outputPath {
+		err = errors.New("require output file")
+		return
+	}
+	err = nil
+	return
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
+)
+
+func main() {
+	schemaPaths, outputPath, verbose, err := parseCommandParam()
+	if nil != err {
+		log.Fatalf("failed on parsing command line parameters: %v", err)
+		return
+	}
+	store := ldapschemaparser.NewLDAPSchemaStore()
+	if err = store.ReadFromFile(outputPath); nil != err {
+		if !os.IsNotExist(err) {
+			log.Fatalf("ERROR: cannot load LDAP schema store from [%v]: %v", outputPath, err)
+			return
+		}
+	}
+	loader := ldapschemaparser.NewOpenLDAPSchemaLoader(store)
+	for _, schemaPath := range schemaPaths {
+		log.Printf("INFO: input OpenLDAP schema %v", schemaPath)
+		if err = loader.ReadFromFile(schemaPath); nil != err {
+			log.Fatalf("failed on loading OpenLDAP schema from %v: %v", schemaPath, err)
+			return
+		}
+	}
+	if verbose {
+		for _, macro := range loader.OIDMacros() {
+			log.Printf("objectIdentifier: %v %v", macro.Name, macro.OID)
+		}
+	}
+	log.Printf("INFO: output to: %v", outputPath)
+	if err = store.WriteToFile(outputPath); nil != err {
+		log.Fatalf("ERROR: cannot write content of LDAP schema store into [%v]: %v", outputPath, err)
+	}
+}
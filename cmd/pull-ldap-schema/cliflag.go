@@ -3,11 +3,31 @@ package main
 import (
 	"errors"
 	"flag"
+	"strings"
 )
 
-func parseCommandParam() (elementStorePath, rootStorePath, outputPath string, verbose bool, err error) {
+// nameListFlag collects names from repeated or comma separated flag values.
+type nameListFlag []string
+
+func (names *nameListFlag) String() string {
+	return strings.Join(*names, ",")
+}
+
+func (names *nameListFlag) Set(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); "" != name {
+			*names = append(*names, name)
+		}
+	}
+	return nil
+}
+
+func parseCommandParam() (elementStorePath, rootStorePath string, classNames, attrNames []string, outputPath string, verbose bool, err error) {
+	var classNameList, attrNameList nameListFlag
 	flag.StringVar(&elementStorePath, "element", "", "path to store for getting schema elements")
 	flag.StringVar(&rootStorePath, "root", "", "path to store for getting root elements")
+	flag.Var(&classNameList, "class", "name of object class to pull as root element (repeatable, comma separated)")
+	flag.Var(&attrNameList, "attr", "name of attribute type to pull as root element (repeatable, comma separated)")
 	flag.StringVar(&outputPath, "out", "", "path to write into")
 	flag.BoolVar(&verbose, "verbose", false, "enable verbose mode")
 	flag.Parse()
@@ -15,14 +35,16 @@ func parseCommandParam() (elementStorePath, rootStorePath, outputPath string, ve
 		err = errors.New("require schema element store file (`-element` option)")
 		return
 	}
-	if "" == rootStorePath {
-		err = errors.New("require root element store file  (`-root` option)")
+	if ("" == rootStorePath) && (0 == len(classNameList)) && (0 == len(attrNameList)) {
+		err = errors.New("require root element store file (`-root` option) or root element names (`-class` or `-attr` option)")
 		return
 	}
 	if "" == outputPath {
 		err = errors.New("require output file")
 		return
 	}
+	classNames = classNameList
+	attrNames = attrNameList
 	err = nil
 	return
 }
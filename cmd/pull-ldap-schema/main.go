@@ -7,7 +7,7 @@ import (
 )
 
 func main() {
-	elementStorePath, rootStorePath, outputPath, verbose, err := parseCommandParam()
+	elementStorePath, rootStorePath, classNames, attrNames, outputPath, verbose, err := parseCommandParam()
 	if nil != err {
 		log.Fatalf("failed on parsing command line parameters: %v", err)
 		return
@@ -18,12 +18,34 @@ func main() {
 		return
 	}
 	rootStore := ldapschemaparser.NewLDAPSchemaStore()
-	if err = rootStore.ReadFromFile(rootStorePath); nil != err {
-		log.Fatalf("ERROR: cannot load element LDAP schema store from [%v]: %v", rootStorePath, err)
-		return
+	if "" != rootStorePath {
+		if err = rootStore.ReadFromFile(rootStorePath); nil != err {
+			log.Fatalf("ERROR: cannot load element LDAP schema store from [%v]: %v", rootStorePath, err)
+			return
+		}
+	}
+	// Root elements are given by numeric OID so an attribute type sharing
+	// name with an object class is not taken as the object class.
+	rootOIDs := make([]string, 0, len(classNames)+len(attrNames))
+	for _, className := range classNames {
+		objectClassSchema, err := elementStore.ObjectClass(className)
+		if nil != err {
+			log.Fatalf("ERROR: cannot find root object class in element store: %v", err)
+			return
+		}
+		rootOIDs = append(rootOIDs, objectClassSchema.NumericOID)
+	}
+	for _, attrName := range attrNames {
+		attributeTypeSchema, err := elementStore.AttributeType(attrName)
+		if nil != err {
+			log.Fatalf("ERROR: cannot find root attribute type in element store: %v", err)
+			return
+		}
+		rootOIDs = append(rootOIDs, attributeTypeSchema.NumericOID)
 	}
-	if err = rootStore.PullDependentSchema(elementStore, verbose); nil != err {
-		log.Fatalf("ERROR: failed on pulling dependent schema: %v", err)
+	rootStore.Verbose = verbose
+	if err = rootStore.ExtractClosure(elementStore, rootOIDs...); nil != err {
+		log.Fatalf("ERROR: failed on extracting closure of root elements: %v", err)
 		return
 	}
 	if err = rootStore.WriteToJSONFile(outputPath); nil != err {
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"errors"
+	"flag"
+)
+
+func parseCommandParam() (storePathA, storePathB string, outputJSON bool, err error) {
+	flag.BoolVar(&outputJSON, "json", false, "output difference in JSON form")
+	flag.Parse()
+	if flag.NArg() != 2 {
+		err = errors.New("require two schema store files to compare")
+		return
+	}
+	storePathA = flag.Arg(0)
+	storePathB = flag.Arg(1)
+	err = nil
+	return
+}
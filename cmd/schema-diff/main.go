@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
+)
+
+func loadStore(storePath string) (store *ldapschemaparser.LDAPSchemaStore, err error) {
+	store = ldapschemaparser.NewLDAPSchemaStore()
+	if err = store.ReadFromFile(storePath); nil != err {
+		return nil, err
+	}
+	return store, nil
+}
+
+func writeElementChanges(w io.Writer, mark string, elementChanges []*ldapschemaparser.ElementChange) {
+	for _, elementChange := range elementChanges {
+		if "" != elementChange.Name {
+			fmt.Fprintf(w, "%s %s %s (%s)\n", mark, elementChange.Kind, elementChange.Identifier, elementChange.Name)
+		} else {
+			fmt.Fprintf(w, "%s %s %s\n", mark, elementChange.Kind, elementChange.Identifier)
+		}
+		for _, fieldChange := range elementChange.Changes {
+			fmt.Fprintf(w, "    %v\n", fieldChange)
+		}
+	}
+}
+
+func main() {
+	storePathA, storePathB, outputJSON, err := parseCommandParam()
+	if nil != err {
+		log.Fatalf("failed on parsing command line parameters: %v", err)
+		return
+	}
+	storeA, err := loadStore(storePathA)
+	if nil != err {
+		log.Fatalf("ERROR: cannot load LDAP schema store from [%v]: %v", storePathA, err)
+		return
+	}
+	storeB, err := loadStore(storePathB)
+	if nil != err {
+		log.Fatalf("ERROR: cannot load LDAP schema store from [%v]: %v", storePathB, err)
+		return
+	}
+	diff, err := ldapschemaparser.DiffStores(storeA, storeB)
+	if nil != err {
+		log.Fatalf("ERROR: failed on comparing LDAP schema stores: %v", err)
+		return
+	}
+	if outputJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(diff); nil != err {
+			log.Fatalf("ERROR: cannot write difference in JSON form: %v", err)
+		}
+		return
+	}
+	writeElementChanges(os.Stdout, "-", diff.Removed)
+	writeElementChanges(os.Stdout, "+", diff.Added)
+	writeElementChanges(os.Stdout, "~", diff.Modified)
+}
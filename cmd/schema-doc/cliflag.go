@@ -0,0 +1,33 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
+)
+
+func parseCommandParam() (storePath, outputPath string, outputFormat ldapschemaparser.SchemaDocFormat, err error) {
+	var formatText string
+	flag.StringVar(&outputPath, "out", "", "path to folder of output documents")
+	flag.StringVar(&formatText, "format", "markdown", "output format: markdown or html")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		err = errors.New("require one schema store file")
+		return
+	}
+	if "" == outputPath {
+		err = errors.New("require path to output folder")
+		return
+	}
+	outputFormat = ldapschemaparser.SchemaDocFormat(formatText)
+	switch outputFormat {
+	case ldapschemaparser.SchemaDocMarkdown, ldapschemaparser.SchemaDocHTML:
+	default:
+		err = errors.New("unknown output format: " + formatText)
+		return
+	}
+	storePath = flag.Arg(0)
+	err = nil
+	return
+}
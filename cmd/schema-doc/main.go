@@ -0,0 +1,23 @@
+package main
+
+import (
+	"log"
+
+	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
+)
+
+func main() {
+	storePath, outputPath, outputFormat, err := parseCommandParam()
+	if nil != err {
+		log.Fatalf("failed on parsing command line parameters: %v", err)
+		return
+	}
+	store := ldapschemaparser.NewLDAPSchemaStore()
+	if err = store.ReadFromFile(storePath); nil != err {
+		log.Fatalf("ERROR: cannot load LDAP schema store from [%v]: %v", storePath, err)
+		return
+	}
+	if err = store.WriteSchemaDoc(outputPath, outputFormat); nil != err {
+		log.Fatalf("ERROR: cannot write schema documents into [%v]: %v", outputPath, err)
+	}
+}
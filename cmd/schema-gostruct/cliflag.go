@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+)
+
+// nameListFlag collects names from repeated or comma separated flag values.
+type nameListFlag []string
+
+func (names *nameListFlag) String() string {
+	return strings.Join(*names, ",")
+}
+
+func (names *nameListFlag) Set(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); "" != name {
+			*names = append(*names, name)
+		}
+	}
+	return nil
+}
+
+func parseCommandParam() (storePath, outputPath, packageName string, classNames []string, err error) {
+	var classNameList nameListFlag
+	flag.StringVar(&outputPath, "out", "", "path to output Go source (default: standard output)")
+	flag.StringVar(&packageName, "package", os.Getenv("GOPACKAGE"), "package name of generated code (default: $GOPACKAGE)")
+	flag.Var(&classNameList, "class", "name of object class to generate struct for (repeatable, comma separated)")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		err = errors.New("require one schema store file")
+		return
+	}
+	if "" == packageName {
+		err = errors.New("require package name (`-package` option)")
+		return
+	}
+	if 0 == len(classNameList) {
+		err = errors.New("require object class names (`-class` option)")
+		return
+	}
+	storePath = flag.Arg(0)
+	classNames = classNameList
+	err = nil
+	return
+}
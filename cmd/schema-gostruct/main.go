@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+
+	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
+)
+
+func main() {
+	storePath, outputPath, packageName, classNames, err := parseCommandParam()
+	if nil != err {
+		log.Fatalf("failed on parsing command line parameters: %v", err)
+		return
+	}
+	store := ldapschemaparser.NewLDAPSchemaStore()
+	if err = store.ReadFromFile(storePath); nil != err {
+		log.Fatalf("ERROR: cannot load LDAP schema store from [%v]: %v", storePath, err)
+		return
+	}
+	var buf bytes.Buffer
+	if err = store.GenerateGoStructs(&buf, &ldapschemaparser.GoStructOption{
+		PackageName:   packageName,
+		ObjectClasses: classNames,
+	}); nil != err {
+		log.Fatalf("ERROR: cannot generate Go structs: %v", err)
+		return
+	}
+	if "" == outputPath {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err = ioutil.WriteFile(outputPath, buf.Bytes(), 0644); nil != err {
+		log.Fatalf("ERROR: cannot write Go source into [%v]: %v", outputPath, err)
+	}
+}
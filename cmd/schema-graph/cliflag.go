@@ -0,0 +1,28 @@
+package main
+
+import (
+	"errors"
+	"flag"
+)
+
+func parseCommandParam() (storePath, outputPath, outputFormat, focus string, depth int, dropTop bool, err error) {
+	flag.StringVar(&outputPath, "out", "", "path to output graph (default: standard output)")
+	flag.StringVar(&outputFormat, "format", "dot", "output format: dot, mermaid or json")
+	flag.StringVar(&focus, "focus", "", "name or OID of schema element to keep neighbourhood of")
+	flag.IntVar(&depth, "depth", 1, "depth of neighbourhood to keep, negative for unlimited")
+	flag.BoolVar(&dropTop, "drop-top", false, "drop edges to object class top")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		err = errors.New("require one schema store file")
+		return
+	}
+	switch outputFormat {
+	case "dot", "mermaid", "json":
+	default:
+		err = errors.New("unknown output format: " + outputFormat)
+		return
+	}
+	storePath = flag.Arg(0)
+	err = nil
+	return
+}
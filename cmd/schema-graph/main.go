@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io"
+	"log"
+	"os"
+
+	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
+)
+
+func writeGraph(w io.Writer, graph *ldapschemaparser.SchemaGraph, outputFormat string) error {
+	switch outputFormat {
+	case "mermaid":
+		return graph.WriteMermaid(w)
+	case "json":
+		return graph.WriteJSON(w)
+	}
+	return graph.WriteDOT(w)
+}
+
+func main() {
+	storePath, outputPath, outputFormat, focus, depth, dropTop, err := parseCommandParam()
+	if nil != err {
+		log.Fatalf("failed on parsing command line parameters: %v", err)
+		return
+	}
+	store := ldapschemaparser.NewLDAPSchemaStore()
+	if err = store.ReadFromFile(storePath); nil != err {
+		log.Fatalf("ERROR: cannot load LDAP schema store from [%v]: %v", storePath, err)
+		return
+	}
+	graph, err := store.BuildSchemaGraph()
+	if nil != err {
+		log.Fatalf("ERROR: failed on building schema graph: %v", err)
+		return
+	}
+	if dropTop {
+		graph = graph.DropEdgesTo("top")
+	}
+	if "" != focus {
+		graph = graph.Neighbourhood(focus, depth)
+		if 0 == len(graph.Nodes) {
+			log.Fatalf("ERROR: cannot find schema element: %s", focus)
+			return
+		}
+	}
+	w := io.Writer(os.Stdout)
+	if "" != outputPath {
+		fp, err := os.Create(outputPath)
+		if nil != err {
+			log.Fatalf("ERROR: cannot open output file [%v]: %v", outputPath, err)
+			return
+		}
+		defer fp.Close()
+		w = fp
+	}
+	if err = writeGraph(w, graph, outputFormat); nil != err {
+		log.Fatalf("ERROR: cannot write schema graph: %v", err)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"strings"
+)
+
+// nameListFlag collects names from repeated or comma separated flag values.
+type nameListFlag []string
+
+func (names *nameListFlag) String() string {
+	return strings.Join(*names, ",")
+}
+
+func (names *nameListFlag) Set(value string) error {
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); "" != name {
+			*names = append(*names, name)
+		}
+	}
+	return nil
+}
+
+func parseCommandParam() (storePath, outputPath string, classNames []string, jsonStore bool, err error) {
+	var classNameList nameListFlag
+	flag.StringVar(&outputPath, "out", "", "path to output JSON Schema (default: standard output)")
+	flag.Var(&classNameList, "class", "name of object class to export (repeatable, comma separated; default: all object classes)")
+	flag.BoolVar(&jsonStore, "json-store", false, "read schema store file in JSON form (output of pull-ldap-schema)")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		err = errors.New("require one schema store file")
+		return
+	}
+	storePath = flag.Arg(0)
+	classNames = classNameList
+	err = nil
+	return
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log"
+	"os"
+
+	ldapschemaparser "github.com/yinyin/go-ldap-schema-parser"
+)
+
+func main() {
+	storePath, outputPath, classNames, jsonStore, err := parseCommandParam()
+	if nil != err {
+		log.Fatalf("failed on parsing command line parameters: %v", err)
+		return
+	}
+	store := ldapschemaparser.NewLDAPSchemaStore()
+	if jsonStore {
+		err = store.ReadFromJSONFile(storePath)
+	} else {
+		err = store.ReadFromFile(storePath)
+	}
+	if nil != err {
+		log.Fatalf("ERROR: cannot load LDAP schema store from [%v]: %v", storePath, err)
+		return
+	}
+	var buf bytes.Buffer
+	if err = store.WriteJSONSchema(&buf, classNames...); nil != err {
+		log.Fatalf("ERROR: cannot generate JSON Schema: %v", err)
+		return
+	}
+	if "" == outputPath {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err = ioutil.WriteFile(outputPath, buf.Bytes(), 0644); nil != err {
+		log.Fatalf("ERROR: cannot write JSON Schema into [%v]: %v", outputPath, err)
+	}
+}
@@ -0,0 +1,203 @@
+package ldapschemaparser
+
+import (
+	"log"
+	"strings"
+)
+
+// dependencyOrder sorts given keys so that dependencies come before the keys
+// depend on them. Dependencies not in given keys are ignored and the order of
+// given keys is kept otherwise. Sorting stops at the first cyclic dependency
+// found and the keys of the cycle are returned.
+func dependencyOrder(keys []string, dependencies func(key string) []string) (result []string, cycle []string) {
+	const (
+		visitNone int = iota
+		visitInProgress
+		visitDone
+	)
+	included := make(map[string]bool)
+	for _, k := range keys {
+		included[k] = true
+	}
+	visitState := make(map[string]int)
+	var path []string
+	var visit func(k string) bool
+	visit = func(k string) bool {
+		switch visitState[k] {
+		case visitInProgress:
+			startIdx := len(path) - 1
+			for (startIdx >= 0) && (path[startIdx] != k) {
+				startIdx--
+			}
+			cycle = append(append([]string{}, path[startIdx:]...), k)
+			return false
+		case visitDone:
+			return true
+		}
+		visitState[k] = visitInProgress
+		path = append(path, k)
+		for _, dep := range dependencies(k) {
+			if included[dep] && !visit(dep) {
+				return false
+			}
+		}
+		path = path[:len(path)-1]
+		visitState[k] = visitDone
+		result = append(result, k)
+		return true
+	}
+	for _, k := range keys {
+		if !visit(k) {
+			return nil, cycle
+		}
+	}
+	return result, nil
+}
+
+func (store *LDAPSchemaStore) attributeTypeDependencies(attributeTypeSchema *AttributeTypeSchema) []string {
+	if "" == attributeTypeSchema.SuperType {
+		return nil
+	}
+	if supGenericSchema := store.findAttributeTypeGenericSchema(attributeTypeSchema.SuperType); nil != supGenericSchema {
+		return []string{supGenericSchema.NumericOID}
+	}
+	return nil
+}
+
+func (store *LDAPSchemaStore) objectClassDependencies(objectClassSchema *ObjectClassSchema) (result []string) {
+	for _, superClassName := range objectClassSchema.SuperClasses {
+		if supGenericSchema := store.findObjectClassGenericSchema(superClassName); nil != supGenericSchema {
+			result = append(result, supGenericSchema.NumericOID)
+		}
+	}
+	return
+}
+
+// makeDependencyOrderedAttributeTypeSchemas returns attribute types with super types placed before sub-types.
+func (store *LDAPSchemaStore) makeDependencyOrderedAttributeTypeSchemas() (result []*AttributeTypeSchema, err error) {
+	attributeTypeSchemas, err := store.makeOIDOrderedAttributeTypeSchemas()
+	if nil != err {
+		return
+	}
+	schemaByOID := make(map[string]*AttributeTypeSchema)
+	oids := make([]string, 0, len(attributeTypeSchemas))
+	for _, attributeTypeSchema := range attributeTypeSchemas {
+		schemaByOID[attributeTypeSchema.NumericOID] = attributeTypeSchema
+		oids = append(oids, attributeTypeSchema.NumericOID)
+	}
+	orderedOIDs, cycle := dependencyOrder(oids, func(oid string) []string {
+		return store.attributeTypeDependencies(schemaByOID[oid])
+	})
+	if nil != cycle {
+		return nil, &ErrCyclicSuperior{
+			Kind:  SchemaKindAttributeType,
+			Chain: cycle,
+		}
+	}
+	for _, oid := range orderedOIDs {
+		result = append(result, schemaByOID[oid])
+	}
+	return
+}
+
+func (store *LDAPSchemaStore) makeOIDOrderedObjectClassSchemas() (result []*ObjectClassSchema, err error) {
+	for _, oid := range sortedMapKey(store.objectClassSchemaIndex) {
+		genericSchema := store.objectClassSchemaIndex[oid]
+		objectClassSchema, err := NewObjectClassSchemaViaGenericSchema(genericSchema)
+		if nil != err {
+			log.Printf("ERROR: cannot create object class schema object from generic schema [%v]: %v", oid, err)
+			return nil, err
+		}
+		result = append(result, objectClassSchema)
+	}
+	return
+}
+
+// makeDependencyOrderedObjectClassSchemas returns object classes with super classes placed before sub-classes.
+func (store *LDAPSchemaStore) makeDependencyOrderedObjectClassSchemas() (result []*ObjectClassSchema, err error) {
+	objectClassSchemas, err := store.makeOIDOrderedObjectClassSchemas()
+	if nil != err {
+		return
+	}
+	schemaByOID := make(map[string]*ObjectClassSchema)
+	oids := make([]string, 0, len(objectClassSchemas))
+	for _, objectClassSchema := range objectClassSchemas {
+		schemaByOID[objectClassSchema.NumericOID] = objectClassSchema
+		oids = append(oids, objectClassSchema.NumericOID)
+	}
+	orderedOIDs, cycle := dependencyOrder(oids, func(oid string) []string {
+		return store.objectClassDependencies(schemaByOID[oid])
+	})
+	if nil != cycle {
+		return nil, &ErrCyclicSuperior{
+			Kind:  SchemaKindObjectClass,
+			Chain: cycle,
+		}
+	}
+	for _, oid := range orderedOIDs {
+		result = append(result, schemaByOID[oid])
+	}
+	return
+}
+
+// findDependencyCycles returns cycles in dependency graph of given keys.
+// Each cycle is reported once, started from its smallest key.
+func findDependencyCycles(keys []string, dependencies func(key string) []string) (result [][]string) {
+	const (
+		visitNone int = iota
+		visitInProgress
+		visitDone
+	)
+	included := make(map[string]bool)
+	for _, k := range keys {
+		included[k] = true
+	}
+	visitState := make(map[string]int)
+	reported := make(map[string]bool)
+	var path []string
+	var visit func(k string)
+	visit = func(k string) {
+		switch visitState[k] {
+		case visitInProgress:
+			startIdx := len(path) - 1
+			for (startIdx >= 0) && (path[startIdx] != k) {
+				startIdx--
+			}
+			cycle := rotateToSmallest(path[startIdx:])
+			cycleKey := strings.Join(cycle, " ")
+			if !reported[cycleKey] {
+				reported[cycleKey] = true
+				result = append(result, cycle)
+			}
+			return
+		case visitDone:
+			return
+		}
+		visitState[k] = visitInProgress
+		path = append(path, k)
+		for _, dep := range dependencies(k) {
+			if included[dep] {
+				visit(dep)
+			}
+		}
+		path = path[:len(path)-1]
+		visitState[k] = visitDone
+	}
+	for _, k := range keys {
+		visit(k)
+	}
+	return
+}
+
+func rotateToSmallest(cycle []string) []string {
+	minIdx := 0
+	for idx, k := range cycle {
+		if k < cycle[minIdx] {
+			minIdx = idx
+		}
+	}
+	result := make([]string, 0, len(cycle))
+	result = append(result, cycle[minIdx:]...)
+	result = append(result, cycle[:minIdx]...)
+	return result
+}
@@ -2,15 +2,15 @@ package ldapschemaparser
 
 // DITContentRuleSchema represent schema of DIT content rule
 type DITContentRuleSchema struct {
-	NumericOID  string
-	Name        []string
-	Description string
-	Obsolete    bool
-	Aux         []string
-	Must        []string
-	May         []string
-	Not         []string
-	Extensions  map[string][]string
+	NumericOID  string              `json:"oid"`
+	Name        []string            `json:"name,omitempty"`
+	Description string              `json:"desc,omitempty"`
+	Obsolete    bool                `json:"obsolete,omitempty"`
+	Aux         []string            `json:"aux,omitempty"`
+	Must        []string            `json:"must,omitempty"`
+	May         []string            `json:"may,omitempty"`
+	Not         []string            `json:"not,omitempty"`
+	Extensions  map[string][]string `json:"extensions,omitempty"`
 }
 
 // NewDITContentRuleSchemaViaGenericSchema creates DIT content rule schema instance from GenericSchema
@@ -0,0 +1,289 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DITStructureLevel reports name form and DIT structure rule governing one
+// RDN of DN. Rule fields are empty for RDN not governed by any rule.
+type DITStructureLevel struct {
+	RDN         string `json:"rdn"`
+	RuleID      string `json:"rule_id,omitempty"`
+	RuleName    string `json:"rule_name,omitempty"`
+	NameForm    string `json:"name_form,omitempty"`
+	ObjectClass string `json:"object_class,omitempty"`
+}
+
+func (level *DITStructureLevel) String() string {
+	if ("" == level.RuleID) && ("" == level.NameForm) {
+		return level.RDN + ": not governed"
+	}
+	if "" == level.RuleID {
+		return fmt.Sprintf("%s: name form %s of %s", level.RDN, level.NameForm, level.ObjectClass)
+	}
+	ruleText := level.RuleID
+	if "" != level.RuleName {
+		ruleText += " (" + level.RuleName + ")"
+	}
+	return fmt.Sprintf("%s: rule %s, name form %s of %s", level.RDN, ruleText, level.NameForm, level.ObjectClass)
+}
+
+// DITStructureCheck is the result of checking DN against name forms and
+// DIT structure rules. Levels are in the order of RDNs of DN, the first
+// one is the level of the entry.
+type DITStructureCheck struct {
+	Levels     []*DITStructureLevel `json:"levels"`
+	Violations []*EntryViolation    `json:"violations,omitempty"`
+}
+
+type structureRule struct {
+	schema          *DITStructureRuleSchema
+	nameForm        *NameFormSchema
+	objectClassName string
+	objectClassOID  string
+}
+
+func (rule *structureRule) level(rdn RelativeDistinguishedName) *DITStructureLevel {
+	level := &DITStructureLevel{
+		RDN:         rdn.String(),
+		NameForm:    rule.nameForm.NumericOID,
+		ObjectClass: rule.objectClassName,
+	}
+	if len(rule.nameForm.Name) > 0 {
+		level.NameForm = rule.nameForm.Name[0]
+	}
+	if nil != rule.schema {
+		level.RuleID = rule.schema.RuleID
+		if len(rule.schema.Name) > 0 {
+			level.RuleName = rule.schema.Name[0]
+		}
+	}
+	return level
+}
+
+type ditStructureChecker struct {
+	store      *LDAPSchemaStore
+	dnText     string
+	dn         DistinguishedName
+	rules      []*structureRule
+	ruleIndex  map[string]*structureRule
+	violations []*EntryViolation
+}
+
+func (checker *ditStructureChecker) report(violationType EntryViolationType, attrName, objectClassName, message string) {
+	checker.violations = append(checker.violations, &EntryViolation{
+		Type:        violationType,
+		DN:          checker.dnText,
+		Attribute:   attrName,
+		ObjectClass: objectClassName,
+		Message:     message,
+	})
+}
+
+func (checker *ditStructureChecker) makeStructureRule(ditStructureRuleSchema *DITStructureRuleSchema, nameFormSchema *NameFormSchema) *structureRule {
+	rule := &structureRule{
+		schema:          ditStructureRuleSchema,
+		nameForm:        nameFormSchema,
+		objectClassName: nameFormSchema.ObjectClass,
+	}
+	if genericSchema := checker.store.findObjectClassGenericSchema(nameFormSchema.ObjectClass); nil != genericSchema {
+		rule.objectClassOID = genericSchema.NumericOID
+	}
+	return rule
+}
+
+// loadRules collects DIT structure rules with known name form. Name forms
+// are loaded as rules without DIT structure rule when store has no DIT
+// structure rule.
+func (checker *ditStructureChecker) loadRules() (err error) {
+	checker.ruleIndex = make(map[string]*structureRule)
+	for _, ruleID := range sortedRuleIDMapKey(checker.store.ditStructureRuleSchemaIndex) {
+		ditStructureRuleSchema, err := NewDITStructureRuleSchemaViaGenericSchema(checker.store.ditStructureRuleSchemaIndex[ruleID])
+		if nil != err {
+			return err
+		}
+		genericSchema := checker.store.findNameFormGenericSchema(ditStructureRuleSchema.NameForm)
+		if nil == genericSchema {
+			continue
+		}
+		nameFormSchema, err := NewNameFormSchemaViaGenericSchema(genericSchema)
+		if nil != err {
+			return err
+		}
+		rule := checker.makeStructureRule(ditStructureRuleSchema, nameFormSchema)
+		checker.rules = append(checker.rules, rule)
+		checker.ruleIndex[ruleID] = rule
+	}
+	if len(checker.store.ditStructureRuleSchemaIndex) > 0 {
+		return nil
+	}
+	for _, oid := range sortedMapKey(checker.store.nameFormSchemaIndex) {
+		nameFormSchema, err := NewNameFormSchemaViaGenericSchema(checker.store.nameFormSchemaIndex[oid])
+		if nil != err {
+			return err
+		}
+		checker.rules = append(checker.rules, checker.makeStructureRule(nil, nameFormSchema))
+	}
+	return nil
+}
+
+func (checker *ditStructureChecker) attributeKey(name string) string {
+	if genericSchema := checker.store.findAttributeTypeGenericSchema(name); nil != genericSchema {
+		return attributeClosureKey(genericSchema.NumericOID, name)
+	}
+	return attributeClosureKey("", name)
+}
+
+// matchNameForm checks attribute types of RDN against MUST and MAY of name
+// form. Missing MUST attribute types and RDN attribute types not in MUST
+// or MAY are returned.
+func (checker *ditStructureChecker) matchNameForm(rdn RelativeDistinguishedName, nameFormSchema *NameFormSchema) (missing, notAllowed []string) {
+	rdnKeys := make(map[string]bool)
+	for _, ava := range rdn {
+		rdnKeys[checker.attributeKey(ava.Type)] = true
+	}
+	allowed := make(map[string]bool)
+	for _, name := range nameFormSchema.Must {
+		key := checker.attributeKey(name)
+		allowed[key] = true
+		if !rdnKeys[key] {
+			missing = append(missing, name)
+		}
+	}
+	for _, name := range nameFormSchema.May {
+		allowed[checker.attributeKey(name)] = true
+	}
+	for _, ava := range rdn {
+		if !allowed[checker.attributeKey(ava.Type)] {
+			notAllowed = append(notAllowed, ava.Type)
+		}
+	}
+	return
+}
+
+func (checker *ditStructureChecker) rdnMatched(rdn RelativeDistinguishedName, nameFormSchema *NameFormSchema) bool {
+	missing, notAllowed := checker.matchNameForm(rdn, nameFormSchema)
+	return (0 == len(missing)) && (0 == len(notAllowed))
+}
+
+// resolveChain finds rules governing superior entries of entry at given
+// level when entry is governed by rule. Superior entries are matched by
+// name form of superior rules only as object classes of superior entries
+// are not known.
+func (checker *ditStructureChecker) resolveChain(levelIndex int, rule *structureRule) (chain []*structureRule) {
+	if (nil == rule.schema) || (0 == len(rule.schema.SuperRules)) {
+		return []*structureRule{rule}
+	}
+	if levelIndex+1 >= len(checker.dn) {
+		return nil
+	}
+	for _, superRuleID := range rule.schema.SuperRules {
+		superRule := checker.ruleIndex[superRuleID]
+		if (nil == superRule) || !checker.rdnMatched(checker.dn[levelIndex+1], superRule.nameForm) {
+			continue
+		}
+		if superChain := checker.resolveChain(levelIndex+1, superRule); nil != superChain {
+			return append([]*structureRule{rule}, superChain...)
+		}
+	}
+	return nil
+}
+
+func (checker *ditStructureChecker) check(structuralObjectClass string) (levels []*DITStructureLevel, err error) {
+	levels = make([]*DITStructureLevel, len(checker.dn))
+	for idx, rdn := range checker.dn {
+		levels[idx] = &DITStructureLevel{
+			RDN: rdn.String(),
+		}
+	}
+	if 0 == len(checker.dn) {
+		return
+	}
+	objectClassGenericSchema := checker.store.findObjectClassGenericSchema(structuralObjectClass)
+	if nil == objectClassGenericSchema {
+		checker.report(EntryUnknownObjectClass, "", structuralObjectClass, "unknown object class "+structuralObjectClass)
+		return
+	}
+	if err = checker.loadRules(); nil != err {
+		return nil, err
+	}
+	var candidates []*structureRule
+	for _, rule := range checker.rules {
+		if rule.objectClassOID == objectClassGenericSchema.NumericOID {
+			candidates = append(candidates, rule)
+		}
+	}
+	if 0 == len(candidates) {
+		if len(checker.store.ditStructureRuleSchemaIndex) > 0 {
+			checker.report(EntryNoStructureRule, "", structuralObjectClass,
+				"no DIT structure rule governs object class "+structuralObjectClass)
+		}
+		return
+	}
+	var matched []*structureRule
+	for _, rule := range candidates {
+		if checker.rdnMatched(checker.dn[0], rule.nameForm) {
+			matched = append(matched, rule)
+		}
+	}
+	if 0 == len(matched) {
+		nameFormSchema := candidates[0].nameForm
+		missing, notAllowed := checker.matchNameForm(checker.dn[0], nameFormSchema)
+		for _, name := range missing {
+			checker.report(EntryRDNMissingAttribute, name, structuralObjectClass,
+				fmt.Sprintf("attribute type %s required by name form %s is missing in RDN", name, nameFormSchema.NumericOID))
+		}
+		for _, name := range notAllowed {
+			checker.report(EntryRDNAttributeNotAllowed, name, structuralObjectClass,
+				fmt.Sprintf("attribute type %s is not allowed in RDN by name form %s", name, nameFormSchema.NumericOID))
+		}
+		return
+	}
+	for _, rule := range matched {
+		if chain := checker.resolveChain(0, rule); nil != chain {
+			for idx, chainRule := range chain {
+				levels[idx] = chainRule.level(checker.dn[idx])
+			}
+			return
+		}
+	}
+	rule := matched[0]
+	levels[0] = rule.level(checker.dn[0])
+	superRules := strings.Join(rule.schema.SuperRules, " ")
+	if 1 == len(checker.dn) {
+		checker.report(EntryStructureRuleViolation, "", structuralObjectClass,
+			fmt.Sprintf("entry requires superior entry governed by rule %s of DIT structure rule %s", superRules, rule.schema.RuleID))
+	} else {
+		checker.report(EntryStructureRuleViolation, "", structuralObjectClass,
+			fmt.Sprintf("superior entry %s is not governed by rule %s of DIT structure rule %s", checker.dn[1:].String(), superRules, rule.schema.RuleID))
+	}
+	return
+}
+
+// CheckDITStructure checks DN of entry with given structural object class
+// against name forms and DIT structure rules in store. RDN of entry is
+// checked against MUST and MAY of name form. Placement of entry is checked
+// against SUP chain of DIT structure rules where superior entries are
+// matched by name forms of superior rules. When store has no DIT structure
+// rule only name forms are checked. Errors are returned for DN not in
+// RFC-4514 form or schema element cannot be converted into typed schema.
+func (store *LDAPSchemaStore) CheckDITStructure(dn, structuralObjectClass string) (result *DITStructureCheck, err error) {
+	parsedDN, err := ParseDN(dn)
+	if nil != err {
+		return
+	}
+	checker := &ditStructureChecker{
+		store:  store,
+		dnText: dn,
+		dn:     parsedDN,
+	}
+	levels, err := checker.check(structuralObjectClass)
+	if nil != err {
+		return
+	}
+	return &DITStructureCheck{
+		Levels:     levels,
+		Violations: checker.violations,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func makeDITStructureSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := makeObjectClassClosureSampleStore(t)
+	for _, schemaText := range []string{
+		"( 2.5.4.10 NAME ( 'o' 'organizationName' ) SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 2.5.4.11 NAME ( 'ou' 'organizationalUnitName' ) SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 0.9.2342.19200300.100.1.1 NAME ( 'uid' 'userid' ) SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 2.5.6.4 NAME 'organization' SUP top STRUCTURAL MUST o )",
+		"( 2.5.6.5 NAME 'organizationalUnit' SUP top STRUCTURAL MUST ou )",
+	} {
+		if err := store.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.3.1 NAME 'sampleOrgNameForm' OC organization MUST o )",
+		"( 1.3.6.1.4.1.99999.3.2 NAME 'sampleUnitNameForm' OC organizationalUnit MUST ou )",
+		"( 1.3.6.1.4.1.99999.3.3 NAME 'samplePersonNameForm' OC person MUST cn MAY uid )",
+	} {
+		if err := store.AddNameFormSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding name form: %v", err)
+		}
+	}
+	return store
+}
+
+func addDITStructureSampleRules(t *testing.T, store *LDAPSchemaStore) {
+	for _, schemaText := range []string{
+		"( 1 NAME 'sampleOrgRule' FORM sampleOrgNameForm )",
+		"( 2 NAME 'sampleUnitRule' FORM sampleUnitNameForm SUP ( 1 2 ) )",
+		"( 3 NAME 'samplePersonRule' FORM samplePersonNameForm SUP 2 )",
+	} {
+		if err := store.AddDITStructureRuleSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding DIT structure rule: %v", err)
+		}
+	}
+}
+
+func checkDITStructureResult(t *testing.T, result *DITStructureCheck, expectLevels, expectViolations []string) {
+	if len(result.Levels) != len(expectLevels) {
+		t.Fatalf("expecting %d levels but have %d: %v", len(expectLevels), len(result.Levels), result.Levels)
+	}
+	for idx, expect := range expectLevels {
+		if v := result.Levels[idx].String(); v != expect {
+			t.Errorf("expecting level %d: %v but have %v", idx, expect, v)
+		}
+	}
+	checkEntryViolations(t, result.Violations, expectViolations)
+}
+
+func TestLDAPSchemaStoreCheckDITStructure_1(t *testing.T) {
+	store := makeDITStructureSampleStore(t)
+	addDITStructureSampleRules(t, store)
+	result, err := store.CheckDITStructure("cn=Alice+uid=alice,ou=Staff,ou=Sales,o=Example,c=TW", "person")
+	if nil != err {
+		t.Fatalf("failed on checking DIT structure: %v", err)
+	}
+	checkDITStructureResult(t, result, []string{
+		"cn=Alice+uid=alice: rule 3 (samplePersonRule), name form samplePersonNameForm of person",
+		"ou=Staff: rule 2 (sampleUnitRule), name form sampleUnitNameForm of organizationalUnit",
+		"ou=Sales: rule 2 (sampleUnitRule), name form sampleUnitNameForm of organizationalUnit",
+		"o=Example: rule 1 (sampleOrgRule), name form sampleOrgNameForm of organization",
+		"c=TW: not governed",
+	}, nil)
+	if result, err = store.CheckDITStructure("commonName=Alice,organizationalUnitName=Staff,o=Example", "2.5.6.6"); nil != err {
+		t.Fatalf("failed on checking DIT structure: %v", err)
+	}
+	checkDITStructureResult(t, result, []string{
+		"commonName=Alice: rule 3 (samplePersonRule), name form samplePersonNameForm of person",
+		"organizationalUnitName=Staff: rule 2 (sampleUnitRule), name form sampleUnitNameForm of organizationalUnit",
+		"o=Example: rule 1 (sampleOrgRule), name form sampleOrgNameForm of organization",
+	}, nil)
+}
+
+func TestLDAPSchemaStoreCheckDITStructure_2(t *testing.T) {
+	store := makeDITStructureSampleStore(t)
+	addDITStructureSampleRules(t, store)
+	testCases := []struct {
+		dn          string
+		objectClass string
+		expects     []string
+	}{
+		{"uid=alice,ou=Staff,o=Example", "person", []string{
+			"uid=alice,ou=Staff,o=Example: attribute type cn required by name form 1.3.6.1.4.1.99999.3.3 is missing in RDN [rdn-missing-attribute]",
+		}},
+		{"cn=Alice+sn=Smith,ou=Staff,o=Example", "person", []string{
+			"cn=Alice+sn=Smith,ou=Staff,o=Example: attribute type sn is not allowed in RDN by name form 1.3.6.1.4.1.99999.3.3 [rdn-attribute-not-allowed]",
+		}},
+		{"cn=Alice,o=Example", "person", []string{
+			"cn=Alice,o=Example: superior entry o=Example is not governed by rule 2 of DIT structure rule 3 [structure-rule-violation]",
+		}},
+		{"cn=Alice", "person", []string{
+			"cn=Alice: entry requires superior entry governed by rule 2 of DIT structure rule 3 [structure-rule-violation]",
+		}},
+		{"cn=Alice,o=Example", "sampleMailbox", []string{
+			"cn=Alice,o=Example: no DIT structure rule governs object class sampleMailbox [no-structure-rule]",
+		}},
+		{"cn=Alice,o=Example", "sampleMissing", []string{
+			"cn=Alice,o=Example: unknown object class sampleMissing [unknown-object-class]",
+		}},
+	}
+	for _, testCase := range testCases {
+		result, err := store.CheckDITStructure(testCase.dn, testCase.objectClass)
+		if nil != err {
+			t.Fatalf("failed on checking DIT structure of %s: %v", testCase.dn, err)
+		}
+		checkEntryViolations(t, result.Violations, testCase.expects)
+	}
+	if _, err := store.CheckDITStructure("cn=Alice,,o=Example", "person"); nil == err {
+		t.Error("expecting error for invalid DN")
+	}
+}
+
+func TestLDAPSchemaStoreCheckDITStructure_3(t *testing.T) {
+	store := makeDITStructureSampleStore(t)
+	result, err := store.CheckDITStructure("cn=Alice,o=Example", "person")
+	if nil != err {
+		t.Fatalf("failed on checking DIT structure: %v", err)
+	}
+	checkDITStructureResult(t, result, []string{
+		"cn=Alice: name form samplePersonNameForm of person",
+		"o=Example: not governed",
+	}, nil)
+	if result, err = store.CheckDITStructure("ou=Staff,o=Example", "organization"); nil != err {
+		t.Fatalf("failed on checking DIT structure: %v", err)
+	}
+	checkEntryViolations(t, result.Violations, []string{
+		"ou=Staff,o=Example: attribute type o required by name form 1.3.6.1.4.1.99999.3.1 is missing in RDN [rdn-missing-attribute]",
+		"ou=Staff,o=Example: attribute type ou is not allowed in RDN by name form 1.3.6.1.4.1.99999.3.1 [rdn-attribute-not-allowed]",
+	})
+}
+
+func TestLDAPSchemaStoreValidateEntryDITStructure_1(t *testing.T) {
+	store := makeDITStructureSampleStore(t)
+	addDITStructureSampleRules(t, store)
+	attrs := map[string][]string{
+		"objectClass": {"top", "person"},
+		"cn":          {"Alice"},
+		"sn":          {"Smith"},
+	}
+	violations, err := store.ValidateEntry("cn=Alice,o=Example", attrs)
+	if nil != err {
+		t.Fatalf("failed on validating entry: %v", err)
+	}
+	checkEntryViolations(t, violations, nil)
+	if violations, err = store.ValidateEntry("cn=Alice,,o=Example", attrs); nil != err {
+		t.Fatalf("failed on validating entry: %v", err)
+	}
+	checkEntryViolations(t, violations, nil)
+}
@@ -2,13 +2,13 @@ package ldapschemaparser
 
 // DITStructureRuleSchema represent schema of DIT structure rule
 type DITStructureRuleSchema struct {
-	RuleID      string
-	Name        []string
-	Description string
-	Obsolete    bool
-	NameForm    string
-	SuperRules  []string
-	Extensions  map[string][]string
+	RuleID      string              `json:"rule_id"`
+	Name        []string            `json:"name,omitempty"`
+	Description string              `json:"desc,omitempty"`
+	Obsolete    bool                `json:"obsolete,omitempty"`
+	NameForm    string              `json:"form,omitempty"`
+	SuperRules  []string            `json:"sup,omitempty"`
+	Extensions  map[string][]string `json:"extensions,omitempty"`
 }
 
 // NewDITStructureRuleSchemaViaGenericSchema creates DIT structure rule schema instance from GenericSchema
@@ -42,7 +42,7 @@ func (s *DITStructureRuleSchema) String() string {
 	b.AppendQString("DESC", s.Description)
 	b.AppendFlag("OBSOLETE", s.Obsolete)
 	b.AppendBareString("FORM", s.NameForm)
-	b.AppendOIDSlice("SUP", s.SuperRules)
+	b.AppendRuleIDSlice("SUP", s.SuperRules)
 	b.AppendExtensions(s.Extensions)
 	return b.String()
 }
@@ -0,0 +1,39 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+const sampleDITStructureRule1 = "( 2 NAME 'sampleUnitRule' FORM sampleUnitNameForm SUP ( 1 2 ) )"
+const sampleDITStructureRule2 = "( 3 NAME 'samplePersonRule' FORM samplePersonNameForm SUP 2 )"
+
+func TestDITStructureRuleSchema_1(t *testing.T) {
+	s, err := ParseDITStructureRuleSchema(sampleDITStructureRule1)
+	if nil != err {
+		t.Fatalf("failed on parsing DIT Structure Rule sample 1: %v", err)
+	}
+	v := s.String()
+	if v != sampleDITStructureRule1 {
+		t.Errorf("expecting %v but have %v", sampleDITStructureRule1, v)
+	}
+	if (len(s.SuperRules) != 2) || (s.SuperRules[0] != "1") || (s.SuperRules[1] != "2") {
+		t.Errorf("expecting SUP ( 1 2 ) but have %v", s.SuperRules)
+	}
+	if s, err = ParseDITStructureRuleSchema(v); nil != err {
+		t.Fatalf("failed on parsing written DIT Structure Rule sample 1: %v", err)
+	}
+	if v = s.String(); v != sampleDITStructureRule1 {
+		t.Errorf("expecting %v after reparse but have %v", sampleDITStructureRule1, v)
+	}
+}
+
+func TestDITStructureRuleSchema_2(t *testing.T) {
+	s, err := ParseDITStructureRuleSchema(sampleDITStructureRule2)
+	if nil != err {
+		t.Fatalf("failed on parsing DIT Structure Rule sample 2: %v", err)
+	}
+	v := s.String()
+	if v != sampleDITStructureRule2 {
+		t.Errorf("expecting %v but have %v", sampleDITStructureRule2, v)
+	}
+}
@@ -0,0 +1,211 @@
+package ldapschemaparser
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// AttributeTypeAndValue is an attributeTypeAndValue of RFC-4514.
+// Value is unescaped. For hex string form value holds the decoded BER bytes.
+type AttributeTypeAndValue struct {
+	Type       string
+	Value      string
+	HexEncoded bool
+}
+
+// RelativeDistinguishedName is a RDN of RFC-4514. Multi-valued RDN has
+// more than one attribute type and value.
+type RelativeDistinguishedName []*AttributeTypeAndValue
+
+// DistinguishedName is a parsed DN. RDNs are in the order of string
+// representation, the leftmost one is the RDN of the entry.
+type DistinguishedName []RelativeDistinguishedName
+
+func hexByteValue(ch byte) byte {
+	switch {
+	case (ch >= '0') && (ch <= '9'):
+		return ch - '0'
+	case (ch >= 'a') && (ch <= 'f'):
+		return ch - 'a' + 10
+	}
+	return ch - 'A' + 10
+}
+
+// escapeDistinguishedNameValue escapes value as RFC-4514 2.4.
+func escapeDistinguishedNameValue(value string) string {
+	var b strings.Builder
+	for idx := 0; idx < len(value); idx++ {
+		ch := value[idx]
+		switch {
+		case strings.IndexByte("\\\"+,;<>", ch) >= 0:
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		case ch == 0:
+			b.WriteString("\\00")
+		case ((ch == ' ') && ((idx == 0) || (idx == len(value)-1))) || ((ch == '#') && (idx == 0)):
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+func (ava *AttributeTypeAndValue) String() string {
+	if ava.HexEncoded {
+		return ava.Type + "=#" + hex.EncodeToString([]byte(ava.Value))
+	}
+	return ava.Type + "=" + escapeDistinguishedNameValue(ava.Value)
+}
+
+func (rdn RelativeDistinguishedName) String() string {
+	texts := make([]string, 0, len(rdn))
+	for _, ava := range rdn {
+		texts = append(texts, ava.String())
+	}
+	return strings.Join(texts, "+")
+}
+
+func (dn DistinguishedName) String() string {
+	texts := make([]string, 0, len(dn))
+	for _, rdn := range dn {
+		texts = append(texts, rdn.String())
+	}
+	return strings.Join(texts, ",")
+}
+
+// Parent returns DN of the immediate superior entry. Nil is returned for
+// DN of one RDN or less.
+func (dn DistinguishedName) Parent() DistinguishedName {
+	if len(dn) < 2 {
+		return nil
+	}
+	return dn[1:]
+}
+
+type dnParser struct {
+	text   string
+	offset int
+}
+
+func (parser *dnParser) fail(message string) *ParseError {
+	token := ""
+	if parser.offset < len(parser.text) {
+		token = parser.text[parser.offset : parser.offset+1]
+	}
+	return &ParseError{
+		Message: message,
+		Offset:  parser.offset,
+		Line:    1,
+		Column:  parser.offset + 1,
+		Token:   token,
+		Snippet: parser.text,
+	}
+}
+
+// parseValue parses attributeValue of RFC-4514 2.4.
+func (parser *dnParser) parseValue(ava *AttributeTypeAndValue) (err error) {
+	text := parser.text
+	if (parser.offset < len(text)) && (text[parser.offset] == '#') {
+		parser.offset++
+		start := parser.offset
+		for (parser.offset+1 < len(text)) && isHexByte(text[parser.offset]) && isHexByte(text[parser.offset+1]) {
+			parser.offset += 2
+		}
+		if start == parser.offset {
+			return parser.fail("empty hex string")
+		}
+		decoded, err := hex.DecodeString(text[start:parser.offset])
+		if nil != err {
+			return parser.fail(err.Error())
+		}
+		ava.Value = string(decoded)
+		ava.HexEncoded = true
+		return nil
+	}
+	var b strings.Builder
+	start := parser.offset
+	lastEscaped := false
+	for parser.offset < len(text) {
+		ch := text[parser.offset]
+		if (ch == ',') || (ch == '+') {
+			break
+		}
+		lastEscaped = false
+		switch ch {
+		case '\\':
+			if parser.offset+1 >= len(text) {
+				return parser.fail("incomplete escape")
+			}
+			if strings.IndexByte("\\\"+,;<> #=", text[parser.offset+1]) >= 0 {
+				b.WriteByte(text[parser.offset+1])
+				parser.offset += 2
+			} else if (parser.offset+2 < len(text)) && isHexByte(text[parser.offset+1]) && isHexByte(text[parser.offset+2]) {
+				b.WriteByte(hexByteValue(text[parser.offset+1])<<4 | hexByteValue(text[parser.offset+2]))
+				parser.offset += 3
+			} else {
+				return parser.fail("invalid escape")
+			}
+			lastEscaped = true
+			continue
+		case '"', ';', '<', '>', 0:
+			return parser.fail("unescaped special character")
+		case ' ':
+			if parser.offset == start {
+				return parser.fail("unescaped leading space")
+			}
+		}
+		b.WriteByte(ch)
+		parser.offset++
+	}
+	if (parser.offset > start) && !lastEscaped && (text[parser.offset-1] == ' ') {
+		parser.offset--
+		return parser.fail("unescaped trailing space")
+	}
+	ava.Value = b.String()
+	return nil
+}
+
+// ParseDN parses string representation of distinguished name in RFC-4514.
+// Empty string is parsed into DN of zero RDN. Errors are reported with
+// *ParseError.
+func ParseDN(text string) (dn DistinguishedName, err error) {
+	if "" == text {
+		return nil, nil
+	}
+	parser := &dnParser{
+		text: text,
+	}
+	var rdn RelativeDistinguishedName
+	for {
+		idx := strings.IndexByte(text[parser.offset:], '=')
+		if idx < 0 {
+			return nil, parser.fail("missing '='")
+		}
+		ava := &AttributeTypeAndValue{
+			Type: text[parser.offset : parser.offset+idx],
+		}
+		if !isNumericOIDValue(ava.Type) && !isKeyStringValue(ava.Type) {
+			return nil, parser.fail("invalid attribute type")
+		}
+		parser.offset += idx + 1
+		if err = parser.parseValue(ava); nil != err {
+			return nil, err
+		}
+		rdn = append(rdn, ava)
+		if parser.offset == len(text) {
+			dn = append(dn, rdn)
+			return dn, nil
+		}
+		switch text[parser.offset] {
+		case ',':
+			dn = append(dn, rdn)
+			rdn = nil
+		case '+':
+		default:
+			return nil, parser.fail("unexpected character")
+		}
+		parser.offset++
+	}
+}
@@ -0,0 +1,70 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func TestParseDN_1(t *testing.T) {
+	testCases := []struct {
+		text   string
+		expect string
+		rdns   int
+	}{
+		{"UID=jsmith,DC=example,DC=net", "UID=jsmith,DC=example,DC=net", 3},
+		{"OU=Sales+CN=J.  Smith,DC=example,DC=net", "OU=Sales+CN=J.  Smith,DC=example,DC=net", 3},
+		{"CN=James \\\"Jim\\\" Smith\\, III,DC=example,DC=net", "CN=James \\\"Jim\\\" Smith\\, III,DC=example,DC=net", 3},
+		{"CN=Before\\0dAfter,DC=example,DC=net", "CN=Before\rAfter,DC=example,DC=net", 3},
+		{"1.3.6.1.4.1.1466.0=#04024869", "1.3.6.1.4.1.1466.0=#04024869", 1},
+		{"CN=Lu\\C4\\8Di\\C4\\87", "CN=Lučić", 1},
+		{"CN=\\ lead\\#,O=\\#hash", "CN=\\ lead#,O=\\#hash", 2},
+		{"", "", 0},
+	}
+	for _, testCase := range testCases {
+		dn, err := ParseDN(testCase.text)
+		if nil != err {
+			t.Errorf("failed on parsing %q: %v", testCase.text, err)
+			continue
+		}
+		if len(dn) != testCase.rdns {
+			t.Errorf("expecting %d RDNs for %q but have %d", testCase.rdns, testCase.text, len(dn))
+		}
+		if v := dn.String(); v != testCase.expect {
+			t.Errorf("expecting %v but have %v", testCase.expect, v)
+		}
+	}
+	dn, _ := ParseDN("OU=Sales+CN=J. Smith,DC=example,DC=net")
+	if (len(dn[0]) != 2) || (dn[0][1].Type != "CN") || (dn[0][1].Value != "J. Smith") {
+		t.Errorf("unexpected multi-valued RDN: %v", dn[0])
+	}
+	if v := dn.Parent().String(); v != "DC=example,DC=net" {
+		t.Errorf("unexpected parent DN: %v", v)
+	}
+}
+
+func TestParseDN_2(t *testing.T) {
+	testCases := []struct {
+		text   string
+		offset int
+	}{
+		{"CN", 0},
+		{"CN=a,", 5},
+		{"-CN=x", 0},
+		{"CN=a;b", 4},
+		{"CN= a", 3},
+		{"CN=a ", 4},
+		{"CN=a\\x", 4},
+		{"CN=#0", 4},
+	}
+	for _, testCase := range testCases {
+		_, err := ParseDN(testCase.text)
+		if nil == err {
+			t.Errorf("expecting error for %q", testCase.text)
+			continue
+		}
+		if parseErr, ok := err.(*ParseError); !ok {
+			t.Errorf("expecting parse error for %q: %v", testCase.text, err)
+		} else if parseErr.Offset != testCase.offset {
+			t.Errorf("expecting error at offset %d for %q but have %d: %v", testCase.offset, testCase.text, parseErr.Offset, err)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package ldapschemaparser
+
+// EffectiveAttributeTypeField is a resolved value of inheritable field of
+// attribute type. Source is the numeric OID of attribute type which
+// declares the value. Declared is false when the value is inherited from
+// a supertype. Source is empty when no attribute type in the SUP chain
+// declares the field.
+type EffectiveAttributeTypeField struct {
+	Value    string `json:"value,omitempty"`
+	Declared bool   `json:"declared"`
+	Source   string `json:"source,omitempty"`
+}
+
+// Inherited check if the value comes from a supertype.
+func (field *EffectiveAttributeTypeField) Inherited() bool {
+	return ("" != field.Source) && !field.Declared
+}
+
+// EffectiveAttributeType is the resolved view of an attribute type with
+// EQUALITY, ORDERING, SUBSTR and SYNTAX inherited through the SUP chain.
+type EffectiveAttributeType struct {
+	// Schema is the attribute type as written literally.
+	Schema *AttributeTypeSchema `json:"schema"`
+
+	// SuperTypes are numeric OIDs of supertypes from the direct supertype up.
+	SuperTypes []string `json:"super_types,omitempty"`
+
+	Equality  EffectiveAttributeTypeField `json:"equality"`
+	Ordering  EffectiveAttributeTypeField `json:"ordering"`
+	SubString EffectiveAttributeTypeField `json:"substr"`
+	Syntax    EffectiveAttributeTypeField `json:"syntax"`
+}
+
+// SyntaxOID return numeric OID part of effective syntax.
+func (effective *EffectiveAttributeType) SyntaxOID() string {
+	syntaxOID, _ := parseOIDLength(effective.Syntax.Value)
+	return syntaxOID
+}
+
+// SyntaxLength return suggested minimum upper bound of effective syntax.
+func (effective *EffectiveAttributeType) SyntaxLength() int32 {
+	_, syntaxLength := parseOIDLength(effective.Syntax.Value)
+	return syntaxLength
+}
+
+// Resolved return a copy of literal attribute type schema with inherited
+// values filled into EQUALITY, ORDERING, SUBSTR and SYNTAX.
+func (effective *EffectiveAttributeType) Resolved() *AttributeTypeSchema {
+	aux := *effective.Schema
+	aux.Equality = effective.Equality.Value
+	aux.Ordering = effective.Ordering.Value
+	aux.SubString = effective.SubString.Value
+	aux.Syntax = effective.Syntax.Value
+	aux.SyntaxOID, aux.SyntaxLength = parseOIDLength(effective.Syntax.Value)
+	return &aux
+}
+
+func resolveEffectiveAttributeTypeField(field *EffectiveAttributeTypeField, declared bool, value, source string) {
+	if ("" != field.Source) || ("" == value) {
+		return
+	}
+	field.Value = value
+	field.Declared = declared
+	field.Source = source
+}
+
+// EffectiveAttributeType resolves attribute type of given name or numeric
+// OID through its SUP chain. Supertypes which cannot be found stop the
+// resolution silently, use Validate to find such references.
+func (store *LDAPSchemaStore) EffectiveAttributeType(identifier string) (effective *EffectiveAttributeType, err error) {
+	genericSchema := store.findAttributeTypeGenericSchema(identifier)
+	if nil == genericSchema {
+		return nil, &ErrSchemaNotFound{
+			Kind:       SchemaKindAttributeType,
+			Identifier: identifier,
+		}
+	}
+	attributeTypeSchema, err := NewAttributeTypeSchemaViaGenericSchema(genericSchema)
+	if nil != err {
+		return
+	}
+	return store.resolveEffectiveAttributeType(attributeTypeSchema)
+}
+
+func (store *LDAPSchemaStore) resolveEffectiveAttributeType(attributeTypeSchema *AttributeTypeSchema) (effective *EffectiveAttributeType, err error) {
+	effective = &EffectiveAttributeType{
+		Schema: attributeTypeSchema,
+	}
+	visited := map[string]bool{
+		attributeTypeSchema.NumericOID: true,
+	}
+	chain := []string{attributeTypeSchema.NumericOID}
+	current := attributeTypeSchema
+	for {
+		declared := current == attributeTypeSchema
+		resolveEffectiveAttributeTypeField(&effective.Equality, declared, current.Equality, current.NumericOID)
+		resolveEffectiveAttributeTypeField(&effective.Ordering, declared, current.Ordering, current.NumericOID)
+		resolveEffectiveAttributeTypeField(&effective.SubString, declared, current.SubString, current.NumericOID)
+		resolveEffectiveAttributeTypeField(&effective.Syntax, declared, current.Syntax, current.NumericOID)
+		if "" == current.SuperType {
+			return effective, nil
+		}
+		supGenericSchema := store.findAttributeTypeGenericSchema(current.SuperType)
+		if nil == supGenericSchema {
+			return effective, nil
+		}
+		chain = append(chain, supGenericSchema.NumericOID)
+		if visited[supGenericSchema.NumericOID] {
+			return nil, &ErrCyclicSuperior{
+				Kind:  SchemaKindAttributeType,
+				Chain: chain,
+			}
+		}
+		visited[supGenericSchema.NumericOID] = true
+		if current, err = NewAttributeTypeSchemaViaGenericSchema(supGenericSchema); nil != err {
+			return nil, err
+		}
+		effective.SuperTypes = append(effective.SuperTypes, current.NumericOID)
+	}
+}
@@ -0,0 +1,103 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func makeEffectiveAttributeTypeSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 2.5.4.41 NAME 'name' EQUALITY caseIgnoreMatch SUBSTR caseIgnoreSubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32768} )",
+		"( 2.5.4.3 NAME ( 'cn' 'commonName' ) SUP name )",
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleCN' SUP cn ORDERING caseIgnoreOrderingMatch )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	return store
+}
+
+func TestEffectiveAttributeType_1(t *testing.T) {
+	store := makeEffectiveAttributeTypeSampleStore(t)
+	effective, err := store.EffectiveAttributeType("sampleCN")
+	if nil != err {
+		t.Fatalf("failed on resolve effective attribute type: %v", err)
+	}
+	if len(effective.SuperTypes) != 2 || effective.SuperTypes[0] != "2.5.4.3" || effective.SuperTypes[1] != "2.5.4.41" {
+		t.Errorf("unexpected super types: %v", effective.SuperTypes)
+	}
+	if v := effective.Equality; v.Value != "caseIgnoreMatch" || v.Declared || v.Source != "2.5.4.41" || !v.Inherited() {
+		t.Errorf("unexpected equality: %#v", v)
+	}
+	if v := effective.Ordering; v.Value != "caseIgnoreOrderingMatch" || !v.Declared || v.Source != "1.3.6.1.4.1.99999.1.1" || v.Inherited() {
+		t.Errorf("unexpected ordering: %#v", v)
+	}
+	if v := effective.SyntaxOID(); v != "1.3.6.1.4.1.1466.115.121.1.15" {
+		t.Errorf("unexpected syntax OID: %v", v)
+	}
+	if v := effective.SyntaxLength(); v != 32768 {
+		t.Errorf("unexpected syntax length: %v", v)
+	}
+	expect := "( 1.3.6.1.4.1.99999.1.1 NAME 'sampleCN' SUP cn EQUALITY caseIgnoreMatch ORDERING caseIgnoreOrderingMatch SUBSTR caseIgnoreSubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32768} )"
+	if v := effective.Resolved().String(); v != expect {
+		t.Errorf("expecting resolved %v but have %v", expect, v)
+	}
+	if v := effective.Schema.Equality; v != "" {
+		t.Errorf("literal schema should not be modified: %v", v)
+	}
+}
+
+func TestEffectiveAttributeType_2(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	store.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.1 NAME 'sampleLoopA' SUP sampleLoopB )")
+	store.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.2 NAME 'sampleLoopB' SUP sampleLoopA )")
+	if _, err := store.EffectiveAttributeType("sampleLoopA"); nil == err {
+		t.Error("expecting error for cyclic SUP chain")
+	} else if _, ok := err.(*ErrCyclicSuperior); !ok {
+		t.Errorf("expecting *ErrCyclicSuperior but have %T: %v", err, err)
+	}
+	if _, err := store.EffectiveAttributeType("sampleMissing"); nil == err {
+		t.Error("expecting error for missing attribute type")
+	} else if _, ok := err.(*ErrSchemaNotFound); !ok {
+		t.Errorf("expecting *ErrSchemaNotFound but have %T: %v", err, err)
+	}
+}
+
+func TestRebuildMatchingRuleUses_1(t *testing.T) {
+	store := makeEffectiveAttributeTypeSampleStore(t)
+	store.AddMatchingRuleSchemaText("( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )")
+	if err := store.rebuildMatchingRuleUses(false); nil != err {
+		t.Fatalf("failed on rebuild matching rule uses: %v", err)
+	}
+	matchingRuleUseSchema, err := NewMatchingRuleUseSchemaViaGenericSchema(store.matchingRuleUseSchemaIndex["2.5.13.2"])
+	if nil != err {
+		t.Fatalf("failed on fetch matching rule use: %v", err)
+	}
+	expect := "( 2.5.13.2 NAME 'caseIgnoreMatch' APPLIES ( sampleCN $ cn $ name ) )"
+	if v := matchingRuleUseSchema.String(); v != expect {
+		t.Errorf("expecting %v but have %v", expect, v)
+	}
+}
+
+func TestRebuildMatchingRuleUses_2(t *testing.T) {
+	store := makeEffectiveAttributeTypeSampleStore(t)
+	store.AddMatchingRuleSchemaText("( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )")
+	if err := store.rebuildMatchingRuleUses(false); nil != err {
+		t.Fatalf("failed on rebuild matching rule uses: %v", err)
+	}
+	if err := store.AddAttributeTypeSchemaText("( 2.5.4.13 NAME 'description' EQUALITY caseIgnoreMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )"); nil != err {
+		t.Fatalf("failed on adding attribute type: %v", err)
+	}
+	if err := store.rebuildMatchingRuleUses(false); nil != err {
+		t.Fatalf("failed on rebuild matching rule uses: %v", err)
+	}
+	matchingRuleUseSchema, err := store.MatchingRuleUse("caseIgnoreMatch")
+	if nil != err {
+		t.Fatalf("failed on fetch matching rule use by name: %v", err)
+	}
+	expect := "( 2.5.13.2 NAME 'caseIgnoreMatch' APPLIES ( sampleCN $ description $ cn $ name ) )"
+	if v := matchingRuleUseSchema.String(); v != expect {
+		t.Errorf("expecting %v but have %v", expect, v)
+	}
+}
@@ -0,0 +1,311 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// EntryViolationType indicates the type of entry violation
+type EntryViolationType string
+
+// Types of entry violations.
+const (
+	EntryMissingObjectClass          EntryViolationType = "missing-object-class"
+	EntryUnknownObjectClass          EntryViolationType = "unknown-object-class"
+	EntryNoStructuralObjectClass     EntryViolationType = "no-structural-object-class"
+	EntryMultipleStructuralChains    EntryViolationType = "multiple-structural-object-classes"
+	EntryAuxiliaryClassNotAllowed    EntryViolationType = "auxiliary-class-not-allowed"
+	EntryUnknownAttribute            EntryViolationType = "unknown-attribute"
+	EntryMissingRequiredAttribute    EntryViolationType = "missing-required-attribute"
+	EntryAttributeNotAllowed         EntryViolationType = "attribute-not-allowed"
+	EntryAttributePrecluded          EntryViolationType = "attribute-precluded"
+	EntrySingleValueViolation        EntryViolationType = "single-value-violation"
+	EntryNoUserModificationViolation EntryViolationType = "no-user-modification-violation"
+	EntryNoStructureRule             EntryViolationType = "no-structure-rule"
+	EntryRDNMissingAttribute         EntryViolationType = "rdn-missing-attribute"
+	EntryRDNAttributeNotAllowed      EntryViolationType = "rdn-attribute-not-allowed"
+	EntryStructureRuleViolation      EntryViolationType = "structure-rule-violation"
+)
+
+const entryObjectClassAttributeName = "objectclass"
+
+// EntryViolation represents a schema violation of entry.
+type EntryViolation struct {
+	Type        EntryViolationType `json:"type"`
+	DN          string             `json:"dn,omitempty"`
+	Attribute   string             `json:"attribute,omitempty"`
+	ObjectClass string             `json:"object_class,omitempty"`
+	Message     string             `json:"message"`
+}
+
+func (violation *EntryViolation) String() string {
+	return fmt.Sprintf("%s: %s [%s]", violation.DN, violation.Message, violation.Type)
+}
+
+// entryAttribute is attribute of entry with options stripped and values of
+// the same attribute type merged.
+type entryAttribute struct {
+	name                string
+	attributeTypeSchema *AttributeTypeSchema
+	valueCount          int
+}
+
+type entryValidator struct {
+	store      *LDAPSchemaStore
+	dn         string
+	violations []*EntryViolation
+}
+
+func (v *entryValidator) report(violationType EntryViolationType, attrName, objectClassName, message string) {
+	v.violations = append(v.violations, &EntryViolation{
+		Type:        violationType,
+		DN:          v.dn,
+		Attribute:   attrName,
+		ObjectClass: objectClassName,
+		Message:     message,
+	})
+}
+
+func stripAttributeOptions(attrDescription string) string {
+	if idx := strings.Index(attrDescription, ";"); idx >= 0 {
+		return attrDescription[:idx]
+	}
+	return attrDescription
+}
+
+func entryObjectClassValues(attrs map[string][]string) (result []string) {
+	for attrDescription, values := range attrs {
+		if strings.ToLower(stripAttributeOptions(attrDescription)) == entryObjectClassAttributeName {
+			result = append(result, values...)
+		}
+	}
+	sort.Strings(result)
+	return
+}
+
+// collectEntryAttributes groups values of attrs by attribute type.
+// Keys are from attributeClosureKey.
+func (v *entryValidator) collectEntryAttributes(attrs map[string][]string) (keys []string, result map[string]*entryAttribute, err error) {
+	attrDescriptions := make([]string, 0, len(attrs))
+	for attrDescription := range attrs {
+		attrDescriptions = append(attrDescriptions, attrDescription)
+	}
+	sort.Strings(attrDescriptions)
+	result = make(map[string]*entryAttribute)
+	for _, attrDescription := range attrDescriptions {
+		name := stripAttributeOptions(attrDescription)
+		var attributeTypeSchema *AttributeTypeSchema
+		numericOID := ""
+		if genericSchema := v.store.findAttributeTypeGenericSchema(name); nil != genericSchema {
+			if attributeTypeSchema, err = NewAttributeTypeSchemaViaGenericSchema(genericSchema); nil != err {
+				return nil, nil, err
+			}
+			numericOID = attributeTypeSchema.NumericOID
+		}
+		key := attributeClosureKey(numericOID, name)
+		attr := result[key]
+		if nil == attr {
+			attr = &entryAttribute{
+				name:                name,
+				attributeTypeSchema: attributeTypeSchema,
+			}
+			result[key] = attr
+			keys = append(keys, key)
+		}
+		attr.valueCount += len(attrs[attrDescription])
+	}
+	return keys, result, nil
+}
+
+// findStructuralObjectClass check object classes of entry and returns the
+// most specific structural object class and auxiliary object classes.
+func (v *entryValidator) findStructuralObjectClass(objectClassNames []string) (structural *ObjectClassSchema, auxiliaries []*ObjectClassSchema, knownNames []string, err error) {
+	var structurals []*ObjectClassSchema
+	for _, objectClassName := range objectClassNames {
+		genericSchema := v.store.findObjectClassGenericSchema(objectClassName)
+		if nil == genericSchema {
+			v.report(EntryUnknownObjectClass, "", objectClassName, "unknown object class "+objectClassName)
+			continue
+		}
+		objectClassSchema, err := NewObjectClassSchemaViaGenericSchema(genericSchema)
+		if nil != err {
+			return nil, nil, nil, err
+		}
+		knownNames = append(knownNames, objectClassName)
+		switch objectClassSchema.ClassKind {
+		case ClassKindStructural:
+			structurals = append(structurals, objectClassSchema)
+		case ClassKindAuxiliary:
+			auxiliaries = append(auxiliaries, objectClassSchema)
+		}
+	}
+	if 0 == len(structurals) {
+		v.report(EntryNoStructuralObjectClass, "", "", "entry has no structural object class")
+		return nil, auxiliaries, knownNames, nil
+	}
+	// The most specific structural class is the one which is not a
+	// superclass of any other structural class of entry.
+	var candidates []*ObjectClassSchema
+	for _, s := range structurals {
+		isSuperClass := false
+		for _, other := range structurals {
+			if other == s {
+				continue
+			}
+			closure, err := v.store.ObjectClassClosure(other.NumericOID)
+			if nil != err {
+				if _, ok := err.(*ErrSchemaNotFound); !ok {
+					return nil, nil, nil, err
+				}
+				continue
+			}
+			if closure.HasObjectClass(s.NumericOID) {
+				isSuperClass = true
+				break
+			}
+		}
+		if !isSuperClass {
+			candidates = append(candidates, s)
+		}
+	}
+	if 1 != len(candidates) {
+		names := make([]string, 0, len(candidates))
+		for _, s := range candidates {
+			names = append(names, s.ShortIdentifier())
+		}
+		v.report(EntryMultipleStructuralChains, "", "", "entry has more than one structural object class chain: "+strings.Join(names, ", "))
+		return nil, auxiliaries, knownNames, nil
+	}
+	return candidates[0], auxiliaries, knownNames, nil
+}
+
+func (v *entryValidator) attributeKeys(names []string) (result map[string]string) {
+	result = make(map[string]string)
+	for _, name := range names {
+		numericOID := ""
+		if genericSchema := v.store.findAttributeTypeGenericSchema(name); nil != genericSchema {
+			numericOID = genericSchema.NumericOID
+		}
+		result[attributeClosureKey(numericOID, name)] = name
+	}
+	return
+}
+
+func (v *entryValidator) validate(attrs map[string][]string) (err error) {
+	objectClassNames := entryObjectClassValues(attrs)
+	if 0 == len(objectClassNames) {
+		v.report(EntryMissingObjectClass, "objectClass", "", "entry has no object class")
+	}
+	structural, auxiliaries, knownNames, err := v.findStructuralObjectClass(objectClassNames)
+	if nil != err {
+		return
+	}
+	closure, err := v.store.ObjectClassClosure(knownNames...)
+	if nil != err {
+		return
+	}
+	var ditContentRuleSchema *DITContentRuleSchema
+	if nil != structural {
+		if genericSchema := v.store.ditContentRuleSchemaIndex[structural.NumericOID]; nil != genericSchema {
+			if ditContentRuleSchema, err = NewDITContentRuleSchemaViaGenericSchema(genericSchema); nil != err {
+				return
+			}
+		}
+	}
+	ruleMust := make(map[string]string)
+	ruleMay := make(map[string]string)
+	ruleNot := make(map[string]string)
+	if nil != ditContentRuleSchema {
+		allowedAuxiliaries := make(map[string]bool)
+		for _, auxName := range ditContentRuleSchema.Aux {
+			if genericSchema := v.store.findObjectClassGenericSchema(auxName); nil != genericSchema {
+				allowedAuxiliaries[genericSchema.NumericOID] = true
+			}
+		}
+		for _, auxiliary := range auxiliaries {
+			if !allowedAuxiliaries[auxiliary.NumericOID] {
+				v.report(EntryAuxiliaryClassNotAllowed, "", auxiliary.ShortIdentifier(),
+					fmt.Sprintf("auxiliary object class %s is not allowed by DIT content rule of %s", auxiliary.ShortIdentifier(), structural.ShortIdentifier()))
+			}
+		}
+		ruleMust = v.attributeKeys(ditContentRuleSchema.Must)
+		ruleMay = v.attributeKeys(ditContentRuleSchema.May)
+		ruleNot = v.attributeKeys(ditContentRuleSchema.Not)
+	}
+	keys, entryAttrs, err := v.collectEntryAttributes(attrs)
+	if nil != err {
+		return
+	}
+	for _, key := range keys {
+		attr := entryAttrs[key]
+		if nil == attr.attributeTypeSchema {
+			v.report(EntryUnknownAttribute, attr.name, "", "unknown attribute type "+attr.name)
+			continue
+		}
+		if attr.attributeTypeSchema.NoUserModification {
+			v.report(EntryNoUserModificationViolation, attr.name, "", "attribute type "+attr.name+" is not user modifiable")
+		}
+		if attr.attributeTypeSchema.SingleValue && (attr.valueCount > 1) {
+			v.report(EntrySingleValueViolation, attr.name, "",
+				fmt.Sprintf("attribute type %s is single-valued but have %d values", attr.name, attr.valueCount))
+		}
+		if "" != ruleNot[key] {
+			v.report(EntryAttributePrecluded, attr.name, "", "attribute type "+attr.name+" is precluded by DIT content rule")
+			continue
+		}
+		if attr.attributeTypeSchema.Usage != AttributeUsageUserApplications {
+			continue
+		}
+		if closureAttr, _ := closure.lookupAttribute(key); nil != closureAttr {
+			continue
+		}
+		if ("" != ruleMust[key]) || ("" != ruleMay[key]) {
+			continue
+		}
+		v.report(EntryAttributeNotAllowed, attr.name, "", "attribute type "+attr.name+" is not allowed by object classes of entry")
+	}
+	for _, closureAttr := range closure.Must {
+		key := attributeClosureKey(closureAttr.NumericOID, closureAttr.Name)
+		if (nil != entryAttrs[key]) || ("" != ruleNot[key]) {
+			continue
+		}
+		v.report(EntryMissingRequiredAttribute, closureAttr.Name, closureAttr.ContributedBy[0],
+			fmt.Sprintf("attribute type %s required by object class %s is missing", closureAttr.Name, closureAttr.ContributedBy[0]))
+	}
+	for _, key := range sortedRuleAttributeKeys(ruleMust) {
+		if (nil != entryAttrs[key]) || (nil != closure.mustIndex[key]) {
+			continue
+		}
+		v.report(EntryMissingRequiredAttribute, ruleMust[key], "",
+			fmt.Sprintf("attribute type %s required by DIT content rule is missing", ruleMust[key]))
+	}
+	return nil
+}
+
+func sortedRuleAttributeKeys(m map[string]string) (result []string) {
+	result = make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return
+}
+
+// ValidateEntry checks entry of given DN and attributes against schema in
+// store. Options of attribute descriptions (eg. `cn;lang-en`) are ignored.
+// Auxiliary object classes are checked against DIT content rule only when
+// a rule exists for the structural object class of entry. Operational
+// attribute types are not checked against MUST and MAY. DN is not checked,
+// use CheckDITStructure for name forms and DIT structure rules.
+// Errors are returned when schema element cannot be converted into typed schema.
+func (store *LDAPSchemaStore) ValidateEntry(dn string, attrs map[string][]string) (violations []*EntryViolation, err error) {
+	v := &entryValidator{
+		store: store,
+		dn:    dn,
+	}
+	if err = v.validate(attrs); nil != err {
+		return nil, err
+	}
+	return v.violations, nil
+}
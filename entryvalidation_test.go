@@ -0,0 +1,100 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func makeEntryValidationSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := makeObjectClassClosureSampleStore(t)
+	for _, schemaText := range []string{
+		"( 2.5.18.1 NAME 'createTimestamp' SYNTAX 1.3.6.1.4.1.1466.115.121.1.24 SINGLE-VALUE NO-USER-MODIFICATION USAGE directoryOperation )",
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleCode' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 SINGLE-VALUE )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.2.2 NAME 'sampleEmployee' SUP person STRUCTURAL MAY sampleCode )",
+		"( 1.3.6.1.4.1.99999.2.3 NAME 'sampleDevice' SUP top STRUCTURAL MUST cn )",
+		"( 1.3.6.1.4.1.99999.2.4 NAME 'sampleExtra' SUP top AUXILIARY MAY description )",
+	} {
+		if err := store.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	return store
+}
+
+func checkEntryViolations(t *testing.T, violations []*EntryViolation, expects []string) {
+	if len(violations) != len(expects) {
+		t.Fatalf("expecting %d violations but have %d: %v", len(expects), len(violations), violations)
+	}
+	for idx, expect := range expects {
+		if v := violations[idx].String(); v != expect {
+			t.Errorf("expecting violation %d: %v but have %v", idx, expect, v)
+		}
+	}
+}
+
+func TestLDAPSchemaStoreValidateEntry_1(t *testing.T) {
+	store := makeEntryValidationSampleStore(t)
+	violations, err := store.ValidateEntry("cn=alice,dc=example,dc=net", map[string][]string{
+		"objectClass":    {"top", "person", "sampleEmployee", "sampleMailbox"},
+		"cn":             {"alice"},
+		"commonName;x-a": {"Alice"},
+		"sn":             {"Smith"},
+		"mail":           {"alice@example.net"},
+		"sampleCode":     {"A1"},
+	})
+	if nil != err {
+		t.Fatalf("failed on validate entry: %v", err)
+	}
+	checkEntryViolations(t, violations, nil)
+}
+
+func TestLDAPSchemaStoreValidateEntry_2(t *testing.T) {
+	store := makeEntryValidationSampleStore(t)
+	violations, err := store.ValidateEntry("cn=bob,dc=example,dc=net", map[string][]string{
+		"objectClass":     {"top", "sampleEmployee", "sampleDevice", "sampleUnknown"},
+		"cn":              {"bob"},
+		"sampleCode":      {"B1", "B2"},
+		"createTimestamp": {"20200101000000Z"},
+		"description":     {"device"},
+		"sampleFoo":       {"foo"},
+	})
+	if nil != err {
+		t.Fatalf("failed on validate entry: %v", err)
+	}
+	checkEntryViolations(t, violations, []string{
+		"cn=bob,dc=example,dc=net: unknown object class sampleUnknown [unknown-object-class]",
+		"cn=bob,dc=example,dc=net: entry has more than one structural object class chain: sampleDevice, sampleEmployee [multiple-structural-object-classes]",
+		"cn=bob,dc=example,dc=net: attribute type createTimestamp is not user modifiable [no-user-modification-violation]",
+		"cn=bob,dc=example,dc=net: attribute type sampleCode is single-valued but have 2 values [single-value-violation]",
+		"cn=bob,dc=example,dc=net: unknown attribute type sampleFoo [unknown-attribute]",
+		"cn=bob,dc=example,dc=net: attribute type sn required by object class 2.5.6.6 is missing [missing-required-attribute]",
+	})
+}
+
+func TestLDAPSchemaStoreValidateEntry_3(t *testing.T) {
+	store := makeEntryValidationSampleStore(t)
+	if err := store.AddDITContentRuleSchemaText("( 2.5.6.6 NAME 'personContentRule' AUX sampleExtra MUST description NOT sn )"); nil != err {
+		t.Fatalf("failed on adding DIT content rule: %v", err)
+	}
+	violations, err := store.ValidateEntry("cn=carol,dc=example,dc=net", map[string][]string{
+		"objectClass": {"top", "person", "sampleMailbox"},
+		"cn":          {"carol"},
+		"sn":          {"Jones"},
+		"mail":        {"carol@example.net"},
+		"sampleCode":  {"C1"},
+	})
+	if nil != err {
+		t.Fatalf("failed on validate entry: %v", err)
+	}
+	checkEntryViolations(t, violations, []string{
+		"cn=carol,dc=example,dc=net: auxiliary object class sampleMailbox is not allowed by DIT content rule of person [auxiliary-class-not-allowed]",
+		"cn=carol,dc=example,dc=net: attribute type sampleCode is not allowed by object classes of entry [attribute-not-allowed]",
+		"cn=carol,dc=example,dc=net: attribute type sn is precluded by DIT content rule [attribute-precluded]",
+		"cn=carol,dc=example,dc=net: attribute type description required by DIT content rule is missing [missing-required-attribute]",
+	})
+}
@@ -3,6 +3,7 @@ package ldapschemaparser
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // ErrMissingNumericOID indicates numeric OID is required but not given.
@@ -19,3 +20,54 @@ type ErrMissingField struct {
 func (missingField *ErrMissingField) Error() string {
 	return fmt.Sprintf("%s is required field", missingField.FieldName)
 }
+
+// ParseError represents a parsing failure with position of the offending token.
+type ParseError struct {
+	Message  string
+	Offset   int
+	Line     int
+	Column   int
+	Token    string
+	Expected []string
+	Snippet  string
+
+	FileName string
+	FileLine int
+}
+
+func (parseError *ParseError) Error() string {
+	var b strings.Builder
+	if "" != parseError.FileName {
+		fmt.Fprintf(&b, "%s:%d: ", parseError.FileName, parseError.FileLine)
+	} else if parseError.FileLine > 0 {
+		fmt.Fprintf(&b, "line %d: ", parseError.FileLine)
+	}
+	fmt.Fprintf(&b, "%s at offset %d (line %d, column %d)", parseError.Message, parseError.Offset, parseError.Line, parseError.Column)
+	if "" != parseError.Token {
+		fmt.Fprintf(&b, " near %q", parseError.Token)
+	}
+	if "" != parseError.Snippet {
+		fmt.Fprintf(&b, ": %s", parseError.Snippet)
+	}
+	return b.String()
+}
+
+// ErrSchemaNotFound indicates the referenced schema element does not exist in store.
+type ErrSchemaNotFound struct {
+	Kind       SchemaKind
+	Identifier string
+}
+
+func (notFound *ErrSchemaNotFound) Error() string {
+	return fmt.Sprintf("%s not found: %s", notFound.Kind, notFound.Identifier)
+}
+
+// ErrCyclicSuperior indicates the superior chain of schema element loops back.
+type ErrCyclicSuperior struct {
+	Kind  SchemaKind
+	Chain []string
+}
+
+func (cyclicSuperior *ErrCyclicSuperior) Error() string {
+	return fmt.Sprintf("cyclic superior chain of %s: %s", cyclicSuperior.Kind, strings.Join(cyclicSuperior.Chain, " -> "))
+}
@@ -0,0 +1,343 @@
+package ldapschemaparser
+
+import (
+	"strings"
+)
+
+// FilterType indicates the type of search filter node
+type FilterType string
+
+// Types of search filter nodes. (RFC-4515)
+const (
+	FilterAnd             FilterType = "and"
+	FilterOr              FilterType = "or"
+	FilterNot             FilterType = "not"
+	FilterEqualityMatch   FilterType = "equalityMatch"
+	FilterSubstrings      FilterType = "substrings"
+	FilterGreaterOrEqual  FilterType = "greaterOrEqual"
+	FilterLessOrEqual     FilterType = "lessOrEqual"
+	FilterPresent         FilterType = "present"
+	FilterApproxMatch     FilterType = "approxMatch"
+	FilterExtensibleMatch FilterType = "extensibleMatch"
+)
+
+// Filter is a node of parsed search filter.
+//
+// Children holds sub-filters of and, or and not filters. Attribute is the
+// attribute description including options. Value is the unescaped
+// assertion value. Substrings is set for substrings filter. MatchingRule
+// and DNAttributes are set for extensible match filter.
+type Filter struct {
+	Type         FilterType
+	Children     []*Filter
+	Attribute    string
+	Value        string
+	Substrings   *SubstringAssertion
+	MatchingRule string
+	DNAttributes bool
+}
+
+// escapeFilterValue escapes assertion value as RFC-4515 3.
+func escapeFilterValue(value string) string {
+	var b strings.Builder
+	for idx := 0; idx < len(value); idx++ {
+		ch := value[idx]
+		switch ch {
+		case '*', '(', ')', '\\', 0:
+			b.WriteByte('\\')
+			b.WriteByte("0123456789abcdef"[ch>>4])
+			b.WriteByte("0123456789abcdef"[ch&0x0F])
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return b.String()
+}
+
+func (filter *Filter) writeTo(b *strings.Builder) {
+	b.WriteByte('(')
+	switch filter.Type {
+	case FilterAnd, FilterOr, FilterNot:
+		switch filter.Type {
+		case FilterAnd:
+			b.WriteByte('&')
+		case FilterOr:
+			b.WriteByte('|')
+		default:
+			b.WriteByte('!')
+		}
+		for _, child := range filter.Children {
+			child.writeTo(b)
+		}
+	case FilterEqualityMatch:
+		b.WriteString(filter.Attribute + "=" + escapeFilterValue(filter.Value))
+	case FilterGreaterOrEqual:
+		b.WriteString(filter.Attribute + ">=" + escapeFilterValue(filter.Value))
+	case FilterLessOrEqual:
+		b.WriteString(filter.Attribute + "<=" + escapeFilterValue(filter.Value))
+	case FilterApproxMatch:
+		b.WriteString(filter.Attribute + "~=" + escapeFilterValue(filter.Value))
+	case FilterPresent:
+		b.WriteString(filter.Attribute + "=*")
+	case FilterSubstrings:
+		b.WriteString(filter.Attribute + "=" + escapeFilterValue(filter.Substrings.Initial) + "*")
+		for _, anyValue := range filter.Substrings.Any {
+			b.WriteString(escapeFilterValue(anyValue) + "*")
+		}
+		b.WriteString(escapeFilterValue(filter.Substrings.Final))
+	case FilterExtensibleMatch:
+		b.WriteString(filter.Attribute)
+		if filter.DNAttributes {
+			b.WriteString(":dn")
+		}
+		if "" != filter.MatchingRule {
+			b.WriteString(":" + filter.MatchingRule)
+		}
+		b.WriteString(":=" + escapeFilterValue(filter.Value))
+	}
+	b.WriteByte(')')
+}
+
+func (filter *Filter) String() string {
+	var b strings.Builder
+	filter.writeTo(&b)
+	return b.String()
+}
+
+type filterParser struct {
+	text   string
+	offset int
+}
+
+func (parser *filterParser) fail(message string) *ParseError {
+	token := ""
+	if parser.offset < len(parser.text) {
+		token = parser.text[parser.offset : parser.offset+1]
+	}
+	return &ParseError{
+		Message: message,
+		Offset:  parser.offset,
+		Line:    1,
+		Column:  parser.offset + 1,
+		Token:   token,
+		Snippet: parser.text,
+	}
+}
+
+func (parser *filterParser) expect(ch byte) (err error) {
+	if (parser.offset >= len(parser.text)) || (parser.text[parser.offset] != ch) {
+		return parser.fail("expecting '" + string(ch) + "'")
+	}
+	parser.offset++
+	return nil
+}
+
+func (parser *filterParser) parseFilter() (filter *Filter, err error) {
+	if err = parser.expect('('); nil != err {
+		return
+	}
+	if parser.offset >= len(parser.text) {
+		return nil, parser.fail("unexpected end of filter")
+	}
+	switch parser.text[parser.offset] {
+	case '&', '|':
+		filter = &Filter{Type: FilterAnd}
+		if parser.text[parser.offset] == '|' {
+			filter.Type = FilterOr
+		}
+		parser.offset++
+		for (parser.offset < len(parser.text)) && (parser.text[parser.offset] == '(') {
+			child, err := parser.parseFilter()
+			if nil != err {
+				return nil, err
+			}
+			filter.Children = append(filter.Children, child)
+		}
+	case '!':
+		parser.offset++
+		child, err := parser.parseFilter()
+		if nil != err {
+			return nil, err
+		}
+		filter = &Filter{
+			Type:     FilterNot,
+			Children: []*Filter{child},
+		}
+	default:
+		if filter, err = parser.parseItem(); nil != err {
+			return
+		}
+	}
+	if err = parser.expect(')'); nil != err {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func isFilterAttributeDescriptionByte(ch byte) bool {
+	return isAlphaByte(ch) || isDigitByte(ch) || (ch == '-') || (ch == '.') || (ch == ';')
+}
+
+func (parser *filterParser) parseAttributeDescription() string {
+	start := parser.offset
+	for (parser.offset < len(parser.text)) && isFilterAttributeDescriptionByte(parser.text[parser.offset]) {
+		parser.offset++
+	}
+	return parser.text[start:parser.offset]
+}
+
+// parseAssertionValue reads value till ')' or unescaped '*'.
+func (parser *filterParser) parseAssertionValue() (value string, err error) {
+	var b strings.Builder
+	for parser.offset < len(parser.text) {
+		ch := parser.text[parser.offset]
+		switch ch {
+		case ')', '*':
+			return b.String(), nil
+		case '(', 0:
+			return "", parser.fail("unescaped character in assertion value")
+		case '\\':
+			if (parser.offset+2 >= len(parser.text)) || !isHexByte(parser.text[parser.offset+1]) || !isHexByte(parser.text[parser.offset+2]) {
+				return "", parser.fail("invalid escape in assertion value")
+			}
+			b.WriteByte(hexByteValue(parser.text[parser.offset+1])<<4 | hexByteValue(parser.text[parser.offset+2]))
+			parser.offset += 3
+			continue
+		}
+		b.WriteByte(ch)
+		parser.offset++
+	}
+	return b.String(), nil
+}
+
+func (parser *filterParser) parseSimpleValue() (value string, err error) {
+	if value, err = parser.parseAssertionValue(); nil != err {
+		return
+	}
+	if (parser.offset < len(parser.text)) && (parser.text[parser.offset] == '*') {
+		return "", parser.fail("unescaped '*' in assertion value")
+	}
+	return value, nil
+}
+
+func (parser *filterParser) parseExtensible(attr string) (filter *Filter, err error) {
+	filter = &Filter{
+		Type:      FilterExtensibleMatch,
+		Attribute: attr,
+	}
+	if rest := parser.text[parser.offset:]; (len(rest) >= 4) && strings.EqualFold(rest[:4], ":dn:") {
+		filter.DNAttributes = true
+		parser.offset += 3
+	}
+	if strings.HasPrefix(parser.text[parser.offset:], ":=") {
+		if "" == attr {
+			return nil, parser.fail("expecting matching rule for extensible match without attribute")
+		}
+	} else {
+		parser.offset++
+		start := parser.offset
+		for (parser.offset < len(parser.text)) && (isAlphaByte(parser.text[parser.offset]) || isDigitByte(parser.text[parser.offset]) || (parser.text[parser.offset] == '-') || (parser.text[parser.offset] == '.')) {
+			parser.offset++
+		}
+		filter.MatchingRule = parser.text[start:parser.offset]
+		if "" == filter.MatchingRule {
+			return nil, parser.fail("expecting matching rule")
+		}
+	}
+	if err = parser.expect(':'); nil != err {
+		return nil, err
+	}
+	if err = parser.expect('='); nil != err {
+		return nil, err
+	}
+	if filter.Value, err = parser.parseSimpleValue(); nil != err {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func (parser *filterParser) parseItem() (filter *Filter, err error) {
+	attr := parser.parseAttributeDescription()
+	if parser.offset >= len(parser.text) {
+		return nil, parser.fail("unexpected end of filter")
+	}
+	if parser.text[parser.offset] == ':' {
+		return parser.parseExtensible(attr)
+	}
+	if "" == attr {
+		return nil, parser.fail("expecting attribute description")
+	}
+	filter = &Filter{
+		Attribute: attr,
+	}
+	switch parser.text[parser.offset] {
+	case '>', '<', '~':
+		switch parser.text[parser.offset] {
+		case '>':
+			filter.Type = FilterGreaterOrEqual
+		case '<':
+			filter.Type = FilterLessOrEqual
+		default:
+			filter.Type = FilterApproxMatch
+		}
+		parser.offset++
+		if err = parser.expect('='); nil != err {
+			return nil, err
+		}
+		if filter.Value, err = parser.parseSimpleValue(); nil != err {
+			return nil, err
+		}
+		return filter, nil
+	case '=':
+		parser.offset++
+	default:
+		return nil, parser.fail("expecting filter type")
+	}
+	var components []string
+	for {
+		component, err := parser.parseAssertionValue()
+		if nil != err {
+			return nil, err
+		}
+		components = append(components, component)
+		if (parser.offset >= len(parser.text)) || (parser.text[parser.offset] != '*') {
+			break
+		}
+		parser.offset++
+	}
+	switch {
+	case len(components) == 1:
+		filter.Type = FilterEqualityMatch
+		filter.Value = components[0]
+	case (len(components) == 2) && ("" == components[0]) && ("" == components[1]):
+		filter.Type = FilterPresent
+	default:
+		filter.Type = FilterSubstrings
+		filter.Substrings = &SubstringAssertion{
+			Initial: components[0],
+			Final:   components[len(components)-1],
+		}
+		for _, component := range components[1 : len(components)-1] {
+			if "" == component {
+				return nil, parser.fail("empty substring component")
+			}
+			filter.Substrings.Any = append(filter.Substrings.Any, component)
+		}
+	}
+	return filter, nil
+}
+
+// ParseFilter parses string representation of search filter in RFC-4515.
+// Errors are reported with *ParseError.
+func ParseFilter(text string) (filter *Filter, err error) {
+	parser := &filterParser{
+		text: text,
+	}
+	if filter, err = parser.parseFilter(); nil != err {
+		return nil, err
+	}
+	if parser.offset != len(text) {
+		return nil, parser.fail("unexpected text after filter")
+	}
+	return filter, nil
+}
@@ -0,0 +1,91 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func TestParseFilter_1(t *testing.T) {
+	testCases := []struct {
+		text   string
+		expect string
+	}{
+		{"(cn=Babs Jensen)", "(cn=Babs Jensen)"},
+		{"(!(cn=Tim Howes))", "(!(cn=Tim Howes))"},
+		{"(&(objectClass=Person)(|(sn=Jensen)(cn=Babs J*)))", "(&(objectClass=Person)(|(sn=Jensen)(cn=Babs J*)))"},
+		{"(o=univ*of*mich*)", "(o=univ*of*mich*)"},
+		{"(seeAlso=)", "(seeAlso=)"},
+		{"(cn:caseExactMatch:=Fred Flintstone)", "(cn:caseExactMatch:=Fred Flintstone)"},
+		{"(cn:=Betty Rubble)", "(cn:=Betty Rubble)"},
+		{"(sn:dn:2.4.6.8.10:=Barney Rubble)", "(sn:dn:2.4.6.8.10:=Barney Rubble)"},
+		{"(o:dn:=Ace Industry)", "(o:dn:=Ace Industry)"},
+		{"(:1.2.3:=Wilma Flintstone)", "(:1.2.3:=Wilma Flintstone)"},
+		{"(:DN:2.4.6.8.10:=Dino)", "(:dn:2.4.6.8.10:=Dino)"},
+		{"(o=Parens R Us \\28for all your parenthetical needs\\29)", "(o=Parens R Us \\28for all your parenthetical needs\\29)"},
+		{"(cn=*\\2A*)", "(cn=*\\2a*)"},
+		{"(filename=C:\\5cMyFile)", "(filename=C:\\5cMyFile)"},
+		{"(sn=Lu\\c4\\8di\\c4\\87)", "(sn=Lučić)"},
+		{"(cn;lang-en>=a)", "(cn;lang-en>=a)"},
+		{"(age<=30)", "(age<=30)"},
+		{"(cn~=jensen)", "(cn~=jensen)"},
+		{"(mail=*)", "(mail=*)"},
+		{"(&)", "(&)"},
+	}
+	for _, testCase := range testCases {
+		filter, err := ParseFilter(testCase.text)
+		if nil != err {
+			t.Errorf("failed on parsing %q: %v", testCase.text, err)
+			continue
+		}
+		if v := filter.String(); v != testCase.expect {
+			t.Errorf("expecting %v but have %v", testCase.expect, v)
+		}
+	}
+}
+
+func TestParseFilter_2(t *testing.T) {
+	filter, err := ParseFilter("(cn=a*b*c)")
+	if nil != err {
+		t.Fatalf("failed on parsing filter: %v", err)
+	}
+	if filter.Type != FilterSubstrings || filter.Substrings.Initial != "a" || len(filter.Substrings.Any) != 1 || filter.Substrings.Any[0] != "b" || filter.Substrings.Final != "c" {
+		t.Errorf("unexpected substrings filter: %#v", filter.Substrings)
+	}
+	if filter, err = ParseFilter("(sn:dn:caseIgnoreMatch:=x)"); nil != err {
+		t.Fatalf("failed on parsing filter: %v", err)
+	}
+	if filter.Type != FilterExtensibleMatch || filter.Attribute != "sn" || !filter.DNAttributes || filter.MatchingRule != "caseIgnoreMatch" || filter.Value != "x" {
+		t.Errorf("unexpected extensible filter: %#v", filter)
+	}
+}
+
+func TestParseFilter_3(t *testing.T) {
+	testCases := []struct {
+		text   string
+		column int
+	}{
+		{"cn=a", 1},
+		{"(cn=a", 6},
+		{"(cn=a)(sn=b)", 7},
+		{"(cn=a(b)", 6},
+		{"(cn=\\4)", 5},
+		{"(cn>=a*)", 7},
+		{"(cn=a**b)", 9},
+		{"(=a)", 2},
+		{"(cn!a)", 4},
+		{"(:=a)", 2},
+		{"(cn::=a)", 5},
+	}
+	for _, testCase := range testCases {
+		_, err := ParseFilter(testCase.text)
+		if nil == err {
+			t.Errorf("expecting error for %q", testCase.text)
+			continue
+		}
+		parseErr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("expecting parse error for %q: %v", testCase.text, err)
+		} else if parseErr.Column != testCase.column {
+			t.Errorf("expecting error at column %d for %q but have %d: %v", testCase.column, testCase.text, parseErr.Column, err)
+		}
+	}
+}
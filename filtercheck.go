@@ -0,0 +1,484 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FilterProblemType indicates the type of problem found in search filter
+type FilterProblemType string
+
+// Types of problems found in search filter.
+const (
+	FilterUnknownAttribute      FilterProblemType = "unknown-attribute"
+	FilterMissingEqualityRule   FilterProblemType = "missing-equality-rule"
+	FilterMissingOrderingRule   FilterProblemType = "missing-ordering-rule"
+	FilterMissingSubstringsRule FilterProblemType = "missing-substrings-rule"
+	FilterUnknownMatchingRule   FilterProblemType = "unknown-matching-rule"
+	FilterMissingMatchingRule   FilterProblemType = "missing-matching-rule"
+	FilterInvalidValue          FilterProblemType = "invalid-value"
+)
+
+// FilterProblem represents a problem of search filter against schema.
+// Filter is the string form of the offending filter item.
+type FilterProblem struct {
+	Type         FilterProblemType `json:"type"`
+	Filter       string            `json:"filter"`
+	Attribute    string            `json:"attribute,omitempty"`
+	MatchingRule string            `json:"matching_rule,omitempty"`
+	Message      string            `json:"message"`
+}
+
+func (problem *FilterProblem) String() string {
+	return fmt.Sprintf("%s: %s [%s]", problem.Filter, problem.Message, problem.Type)
+}
+
+type filterChecker struct {
+	store    *LDAPSchemaStore
+	problems []*FilterProblem
+}
+
+func (checker *filterChecker) report(problemType FilterProblemType, filter *Filter, message string) {
+	checker.problems = append(checker.problems, &FilterProblem{
+		Type:         problemType,
+		Filter:       filter.String(),
+		Attribute:    filter.Attribute,
+		MatchingRule: filter.MatchingRule,
+		Message:      message,
+	})
+}
+
+// attributeType resolves attribute type of filter. Nil is returned when
+// attribute type is unknown and the problem is reported.
+func (checker *filterChecker) attributeType(filter *Filter) (effective *EffectiveAttributeType, err error) {
+	attributeTypeName := stripAttributeOptions(filter.Attribute)
+	effective, err = checker.store.EffectiveAttributeType(attributeTypeName)
+	if nil != err {
+		if _, ok := err.(*ErrSchemaNotFound); ok {
+			checker.report(FilterUnknownAttribute, filter, "unknown attribute type "+attributeTypeName)
+			return nil, nil
+		}
+		return nil, err
+	}
+	return effective, nil
+}
+
+func (checker *filterChecker) checkValue(filter *Filter) {
+	if err := checker.store.ValidateValue(stripAttributeOptions(filter.Attribute), filter.Value); nil != err {
+		checker.report(FilterInvalidValue, filter, err.Error())
+	}
+}
+
+func (checker *filterChecker) checkExtensible(filter *Filter) (err error) {
+	if ("" == filter.Attribute) && ("" == filter.MatchingRule) {
+		checker.report(FilterMissingMatchingRule, filter, "extensible match requires attribute type or matching rule")
+		return nil
+	}
+	var effective *EffectiveAttributeType
+	if "" != filter.Attribute {
+		if effective, err = checker.attributeType(filter); (nil != err) || (nil == effective) {
+			return
+		}
+	}
+	if "" == filter.MatchingRule {
+		if "" == effective.Equality.Value {
+			checker.report(FilterMissingEqualityRule, filter, "attribute type "+filter.Attribute+" has no EQUALITY matching rule")
+			return nil
+		}
+		checker.checkValue(filter)
+		return nil
+	}
+	genericSchema := checker.store.findMatchingRuleGenericSchema(filter.MatchingRule)
+	if nil == genericSchema {
+		if nil == checker.store.currentMatchingRuleEngine().Lookup(filter.MatchingRule) {
+			checker.report(FilterUnknownMatchingRule, filter, "unknown matching rule "+filter.MatchingRule)
+		}
+		return nil
+	}
+	matchingRuleSchema, err := NewMatchingRuleSchemaViaGenericSchema(genericSchema)
+	if nil != err {
+		return
+	}
+	syntaxOID, _ := parseOIDLength(matchingRuleSchema.Syntax)
+	if err := checker.store.syntaxValidatorRegistry().Validate(syntaxOID, filter.Value); nil != err {
+		checker.report(FilterInvalidValue, filter, err.Error())
+	}
+	return nil
+}
+
+func (checker *filterChecker) check(filter *Filter) (err error) {
+	switch filter.Type {
+	case FilterAnd, FilterOr, FilterNot:
+		for _, child := range filter.Children {
+			if err = checker.check(child); nil != err {
+				return
+			}
+		}
+		return nil
+	case FilterExtensibleMatch:
+		return checker.checkExtensible(filter)
+	}
+	effective, err := checker.attributeType(filter)
+	if (nil != err) || (nil == effective) {
+		return
+	}
+	switch filter.Type {
+	case FilterEqualityMatch, FilterApproxMatch:
+		if "" == effective.Equality.Value {
+			checker.report(FilterMissingEqualityRule, filter, "attribute type "+filter.Attribute+" has no EQUALITY matching rule")
+			return nil
+		}
+		checker.checkValue(filter)
+	case FilterGreaterOrEqual, FilterLessOrEqual:
+		if "" == effective.Ordering.Value {
+			checker.report(FilterMissingOrderingRule, filter, "attribute type "+filter.Attribute+" has no ORDERING matching rule")
+			return nil
+		}
+		checker.checkValue(filter)
+	case FilterSubstrings:
+		if "" == effective.SubString.Value {
+			checker.report(FilterMissingSubstringsRule, filter, "attribute type "+filter.Attribute+" has no SUBSTR matching rule")
+		}
+	}
+	return nil
+}
+
+// CheckFilter checks attribute types, matching rules and assertion values
+// of search filter against schema in store. Errors are returned when
+// schema element cannot be converted into typed schema.
+func (store *LDAPSchemaStore) CheckFilter(filter *Filter) (problems []*FilterProblem, err error) {
+	checker := &filterChecker{
+		store: store,
+	}
+	if err = checker.check(filter); nil != err {
+		return nil, err
+	}
+	return checker.problems, nil
+}
+
+// FilterResult is the three-valued result of filter evaluation. (RFC-4511 4.5.1.7)
+type FilterResult int
+
+// FilterFalse, FilterTrue and FilterUndefined are results of filter evaluation.
+const (
+	FilterFalse FilterResult = iota
+	FilterTrue
+	FilterUndefined
+)
+
+func (result FilterResult) String() string {
+	switch result {
+	case FilterTrue:
+		return "TRUE"
+	case FilterFalse:
+		return "FALSE"
+	}
+	return "Undefined"
+}
+
+func attributeOptions(attrDescription string) (result []string) {
+	parts := strings.Split(strings.ToLower(attrDescription), ";")
+	return parts[1:]
+}
+
+func hasAttributeOptions(options, required []string) bool {
+	for _, r := range required {
+		found := false
+		for _, option := range options {
+			if option == r {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+type filterEvaluator struct {
+	store *LDAPSchemaStore
+	attrs map[string][]string
+
+	attrDescriptions []string
+
+	dn       string
+	dnParsed bool
+	dnValues []*AttributeTypeAndValue
+	dnErr    error
+}
+
+// distinguishedValues parses DN of entry on first use. DN is only needed
+// by extensible match filters with `:dn` so other filters still evaluate
+// when DN cannot be parsed.
+func (evaluator *filterEvaluator) distinguishedValues() ([]*AttributeTypeAndValue, error) {
+	if !evaluator.dnParsed {
+		evaluator.dnParsed = true
+		rdns, err := ParseDN(evaluator.dn)
+		if nil != err {
+			evaluator.dnErr = err
+		}
+		for _, rdn := range rdns {
+			evaluator.dnValues = append(evaluator.dnValues, rdn...)
+		}
+	}
+	return evaluator.dnValues, evaluator.dnErr
+}
+
+// valuesOf collects values of entry for attribute description of filter.
+// Values of subtypes are included and so are matched values of given
+// attribute value assertions of DN. Known is false when attribute type is
+// not in store.
+func (evaluator *filterEvaluator) valuesOf(filterAttr string, dnValues []*AttributeTypeAndValue) (values []string, known bool, err error) {
+	filterType := stripAttributeOptions(filterAttr)
+	filterGenericSchema := evaluator.store.findAttributeTypeGenericSchema(filterType)
+	requiredOptions := attributeOptions(filterAttr)
+	matchType := func(entryType string) (bool, error) {
+		if nil == filterGenericSchema {
+			return strings.EqualFold(entryType, filterType), nil
+		}
+		entryGenericSchema := evaluator.store.findAttributeTypeGenericSchema(entryType)
+		if nil == entryGenericSchema {
+			return false, nil
+		}
+		if entryGenericSchema == filterGenericSchema {
+			return true, nil
+		}
+		effective, err := evaluator.store.EffectiveAttributeType(entryType)
+		if nil != err {
+			return false, err
+		}
+		for _, oid := range effective.SuperTypes {
+			if oid == filterGenericSchema.NumericOID {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	for _, attrDescription := range evaluator.attrDescriptions {
+		matched, err := matchType(stripAttributeOptions(attrDescription))
+		if nil != err {
+			return nil, false, err
+		}
+		if matched && hasAttributeOptions(attributeOptions(attrDescription), requiredOptions) {
+			values = append(values, evaluator.attrs[attrDescription]...)
+		}
+	}
+	for _, ava := range dnValues {
+		matched, err := matchType(ava.Type)
+		if nil != err {
+			return nil, false, err
+		}
+		if matched {
+			values = append(values, ava.Value)
+		}
+	}
+	return values, nil != filterGenericSchema, nil
+}
+
+// matchValues applies match to each value. Values which cannot be
+// evaluated make the result Undefined unless another value matches.
+func matchValues(values []string, match func(value string) (bool, error)) FilterResult {
+	result := FilterFalse
+	for _, value := range values {
+		matched, err := match(value)
+		if nil != err {
+			result = FilterUndefined
+			continue
+		}
+		if matched {
+			return FilterTrue
+		}
+	}
+	return result
+}
+
+func (evaluator *filterEvaluator) evaluateItem(filter *Filter) (result FilterResult, err error) {
+	values, known, err := evaluator.valuesOf(filter.Attribute, nil)
+	if nil != err {
+		return
+	}
+	if filter.Type == FilterPresent {
+		if len(values) > 0 {
+			return FilterTrue, nil
+		}
+		return FilterFalse, nil
+	}
+	if !known {
+		return FilterUndefined, nil
+	}
+	rules, err := evaluator.store.AttributeMatchingRules(stripAttributeOptions(filter.Attribute))
+	if nil != err {
+		return
+	}
+	switch filter.Type {
+	case FilterEqualityMatch, FilterApproxMatch:
+		if nil == rules.Equality {
+			return FilterUndefined, nil
+		}
+		if _, err := rules.Equality.normalize(filter.Value); nil != err {
+			return FilterUndefined, nil
+		}
+		return matchValues(values, func(value string) (bool, error) {
+			return rules.Equality.Equal(value, filter.Value)
+		}), nil
+	case FilterGreaterOrEqual, FilterLessOrEqual:
+		if nil == rules.Ordering {
+			return FilterUndefined, nil
+		}
+		if _, err := rules.Ordering.normalize(filter.Value); nil != err {
+			return FilterUndefined, nil
+		}
+		return matchValues(values, func(value string) (bool, error) {
+			c, err := rules.Ordering.CompareValues(value, filter.Value)
+			if filter.Type == FilterGreaterOrEqual {
+				return c >= 0, err
+			}
+			return c <= 0, err
+		}), nil
+	case FilterSubstrings:
+		if nil == rules.Substrings {
+			return FilterUndefined, nil
+		}
+		return matchValues(values, func(value string) (bool, error) {
+			return rules.Substrings.MatchSubstrings(value, filter.Substrings)
+		}), nil
+	}
+	return FilterUndefined, nil
+}
+
+func applyExtensibleRule(rule *MatchingRuleEvaluator, value, assertionValue string) (bool, error) {
+	switch rule.Usage {
+	case MatchingRuleEquality:
+		return rule.Equal(value, assertionValue)
+	case MatchingRuleOrdering:
+		c, err := rule.CompareValues(value, assertionValue)
+		return c < 0, err
+	}
+	return false, &ErrMatchingRuleUsage{Rule: rule.Name, Usage: rule.Usage}
+}
+
+func (evaluator *filterEvaluator) evaluateExtensible(filter *Filter) (result FilterResult, err error) {
+	var rule *MatchingRuleEvaluator
+	if "" != filter.MatchingRule {
+		if rule = evaluator.store.lookupMatchingRuleEvaluator(filter.MatchingRule); nil == rule {
+			return FilterUndefined, nil
+		}
+	} else {
+		rules, err := evaluator.store.AttributeMatchingRules(stripAttributeOptions(filter.Attribute))
+		if nil != err {
+			if _, ok := err.(*ErrSchemaNotFound); ok {
+				return FilterUndefined, nil
+			}
+			return FilterUndefined, err
+		}
+		if rule = rules.Equality; nil == rule {
+			return FilterUndefined, nil
+		}
+	}
+	if rule.Usage == MatchingRuleSubstrings {
+		return FilterUndefined, nil
+	}
+	var dnValues []*AttributeTypeAndValue
+	if filter.DNAttributes {
+		if dnValues, err = evaluator.distinguishedValues(); nil != err {
+			return FilterUndefined, nil
+		}
+	}
+	if "" != filter.Attribute {
+		values, known, err := evaluator.valuesOf(filter.Attribute, dnValues)
+		if nil != err {
+			return FilterUndefined, err
+		}
+		if !known {
+			return FilterUndefined, nil
+		}
+		return matchValues(values, func(value string) (bool, error) {
+			return applyExtensibleRule(rule, value, filter.Value)
+		}), nil
+	}
+	// Without attribute, the rule applies to every value the rule can
+	// evaluate. Values not fitting the rule are skipped.
+	var values []string
+	for _, attrDescription := range evaluator.attrDescriptions {
+		values = append(values, evaluator.attrs[attrDescription]...)
+	}
+	for _, ava := range dnValues {
+		values = append(values, ava.Value)
+	}
+	for _, value := range values {
+		if matched, err := applyExtensibleRule(rule, value, filter.Value); (nil == err) && matched {
+			return FilterTrue, nil
+		}
+	}
+	return FilterFalse, nil
+}
+
+func (evaluator *filterEvaluator) evaluate(filter *Filter) (result FilterResult, err error) {
+	switch filter.Type {
+	case FilterAnd:
+		result = FilterTrue
+		for _, child := range filter.Children {
+			childResult, err := evaluator.evaluate(child)
+			if nil != err {
+				return FilterUndefined, err
+			}
+			if childResult == FilterFalse {
+				return FilterFalse, nil
+			}
+			if childResult == FilterUndefined {
+				result = FilterUndefined
+			}
+		}
+		return result, nil
+	case FilterOr:
+		result = FilterFalse
+		for _, child := range filter.Children {
+			childResult, err := evaluator.evaluate(child)
+			if nil != err {
+				return FilterUndefined, err
+			}
+			if childResult == FilterTrue {
+				return FilterTrue, nil
+			}
+			if childResult == FilterUndefined {
+				result = FilterUndefined
+			}
+		}
+		return result, nil
+	case FilterNot:
+		if result, err = evaluator.evaluate(filter.Children[0]); nil != err {
+			return
+		}
+		switch result {
+		case FilterTrue:
+			return FilterFalse, nil
+		case FilterFalse:
+			return FilterTrue, nil
+		}
+		return FilterUndefined, nil
+	case FilterExtensibleMatch:
+		return evaluator.evaluateExtensible(filter)
+	}
+	return evaluator.evaluateItem(filter)
+}
+
+// EvaluateFilter evaluates search filter against entry of given DN and
+// attributes with matching rules of attribute types in store. Values of
+// subtypes are included when evaluating filter of an attribute type.
+// DN is only used by extensible match filters with `:dn`, which evaluate
+// to Undefined when DN cannot be parsed.
+func (store *LDAPSchemaStore) EvaluateFilter(filter *Filter, dn string, attrs map[string][]string) (result FilterResult, err error) {
+	evaluator := &filterEvaluator{
+		store: store,
+		attrs: attrs,
+		dn:    dn,
+	}
+	for attrDescription := range attrs {
+		evaluator.attrDescriptions = append(evaluator.attrDescriptions, attrDescription)
+	}
+	sort.Strings(evaluator.attrDescriptions)
+	return evaluator.evaluate(filter)
+}
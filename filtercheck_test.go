@@ -0,0 +1,141 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func makeFilterSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := makeEffectiveAttributeTypeSampleStore(t)
+	for _, schemaText := range []string{
+		"( 2.5.4.4 NAME ( 'sn' 'surname' ) SUP name )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleAge' EQUALITY integerMatch ORDERING integerOrderingMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 SINGLE-VALUE )",
+		"( 1.3.6.1.4.1.99999.1.3 NAME 'sampleData' SYNTAX 1.3.6.1.4.1.1466.115.121.1.40 )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	if err := store.AddMatchingRuleSchemaText("( 2.5.13.14 NAME 'integerMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 )"); nil != err {
+		t.Fatalf("failed on adding matching rule: %v", err)
+	}
+	return store
+}
+
+func TestLDAPSchemaStoreCheckFilter_1(t *testing.T) {
+	store := makeFilterSampleStore(t)
+	filter, err := ParseFilter("(&(cn=alice)(sampleAge>=x1)(|(unknownAttr=1)(cn>=a))(!(sampleData=*abc*))(sampleAge=*3*)(cn:sampleMatch:=a)(:integerMatch:=abc)(sampleCN>=b)(sn;lang-en~=x))")
+	if nil != err {
+		t.Fatalf("failed on parsing filter: %v", err)
+	}
+	problems, err := store.CheckFilter(filter)
+	if nil != err {
+		t.Fatalf("failed on checking filter: %v", err)
+	}
+	expects := []string{
+		"(sampleAge>=x1): invalid value \"x1\" of attribute sampleAge (syntax 1.3.6.1.4.1.1466.115.121.1.27): non digit character [invalid-value]",
+		"(unknownAttr=1): unknown attribute type unknownAttr [unknown-attribute]",
+		"(cn>=a): attribute type cn has no ORDERING matching rule [missing-ordering-rule]",
+		"(sampleData=*abc*): attribute type sampleData has no SUBSTR matching rule [missing-substrings-rule]",
+		"(sampleAge=*3*): attribute type sampleAge has no SUBSTR matching rule [missing-substrings-rule]",
+		"(cn:sampleMatch:=a): unknown matching rule sampleMatch [unknown-matching-rule]",
+		"(:integerMatch:=abc): invalid value \"abc\" of syntax 1.3.6.1.4.1.1466.115.121.1.27: non digit character [invalid-value]",
+	}
+	if len(problems) != len(expects) {
+		t.Fatalf("expecting %d problems but have %d: %v", len(expects), len(problems), problems)
+	}
+	for idx, expect := range expects {
+		if v := problems[idx].String(); v != expect {
+			t.Errorf("expecting problem %d: %v but have %v", idx, expect, v)
+		}
+	}
+}
+
+func TestLDAPSchemaStoreCheckFilter_2(t *testing.T) {
+	store := makeFilterSampleStore(t)
+	filter := &Filter{
+		Type:  FilterExtensibleMatch,
+		Value: "a",
+	}
+	problems, err := store.CheckFilter(filter)
+	if nil != err {
+		t.Fatalf("failed on checking filter: %v", err)
+	}
+	if (len(problems) != 1) || (problems[0].Type != FilterMissingMatchingRule) {
+		t.Errorf("expecting missing matching rule problem: %v", problems)
+	}
+}
+
+func TestLDAPSchemaStoreEvaluateFilter_1(t *testing.T) {
+	store := makeFilterSampleStore(t)
+	dn := "cn=Alice Smith,dc=example,dc=net"
+	attrs := map[string][]string{
+		"objectClass":      {"person"},
+		"commonName":       {"Alice  Smith"},
+		"sampleCN;lang-en": {"Ally"},
+		"sn":               {"Smith"},
+		"sampleAge":        {"42"},
+		"x-unknown":        {"v"},
+	}
+	testCases := []struct {
+		text   string
+		expect FilterResult
+	}{
+		{"(cn=alice smith)", FilterTrue},
+		{"(cn=bob)", FilterFalse},
+		{"(name=ally)", FilterTrue},
+		{"(cn;lang-en=ally)", FilterTrue},
+		{"(cn;lang-fr=ally)", FilterFalse},
+		{"(sampleCN=alice smith)", FilterFalse},
+		{"(cn=ali*)", FilterTrue},
+		{"(cn=*smi*)", FilterTrue},
+		{"(sampleAge>=40)", FilterTrue},
+		{"(sampleAge<=40)", FilterFalse},
+		{"(sampleAge=x)", FilterUndefined},
+		{"(cn>=a)", FilterUndefined},
+		{"(unknownAttr=1)", FilterUndefined},
+		{"(x-unknown=*)", FilterTrue},
+		{"(mail=*)", FilterFalse},
+		{"(!(unknownAttr=1))", FilterUndefined},
+		{"(|(unknownAttr=1)(sn=smith))", FilterTrue},
+		{"(&(unknownAttr=1)(sn=bob))", FilterFalse},
+		{"(&(unknownAttr=1)(sn=smith))", FilterUndefined},
+		{"(!(sn=bob))", FilterTrue},
+		{"(sn~=SMITH)", FilterTrue},
+		{"(sn:caseExactMatch:=smith)", FilterFalse},
+		{"(sn:caseExactMatch:=Smith)", FilterTrue},
+		{"(:integerMatch:=42)", FilterTrue},
+		{"(:dn:caseIgnoreMatch:=EXAMPLE)", FilterTrue},
+		{"(:caseIgnoreMatch:=EXAMPLE)", FilterFalse},
+		{"(name:dn:=example)", FilterFalse},
+		{"(cn:dn:=alice smith)", FilterTrue},
+		{"(sampleAge:integerOrderingMatch:=50)", FilterTrue},
+		{"(sn:unknownMatch:=smith)", FilterUndefined},
+	}
+	for _, testCase := range testCases {
+		filter, err := ParseFilter(testCase.text)
+		if nil != err {
+			t.Errorf("failed on parsing %q: %v", testCase.text, err)
+			continue
+		}
+		result, err := store.EvaluateFilter(filter, dn, attrs)
+		if nil != err {
+			t.Errorf("failed on evaluating %q: %v", testCase.text, err)
+		} else if result != testCase.expect {
+			t.Errorf("expecting %v for %q but have %v", testCase.expect, testCase.text, result)
+		}
+	}
+	for text, expect := range map[string]FilterResult{
+		"(cn=alice smith)":               FilterTrue,
+		"(cn:dn:=a)":                     FilterUndefined,
+		"(|(cn:dn:=a)(sn=smith))":        FilterTrue,
+		"(:dn:caseIgnoreMatch:=EXAMPLE)": FilterUndefined,
+	} {
+		filter, _ := ParseFilter(text)
+		result, err := store.EvaluateFilter(filter, "cn=a,,", attrs)
+		if nil != err {
+			t.Errorf("failed on evaluating %q with invalid DN: %v", text, err)
+		} else if result != expect {
+			t.Errorf("expecting %v for %q with invalid DN but have %v", expect, text, result)
+		}
+	}
+}
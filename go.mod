@@ -4,6 +4,7 @@ go 1.12
 
 require (
 	github.com/go-ldap/ldif v0.0.0-20180918085934-3491d58cdb60
+	golang.org/x/text v0.3.8
 	gopkg.in/asn1-ber.v1 v1.0.0-20181015200546-f715ec2f112d // indirect
 	gopkg.in/ldap.v2 v2.5.1
 )
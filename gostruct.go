@@ -0,0 +1,394 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// GoStructOption configures generation of Go structs from object classes.
+type GoStructOption struct {
+	// PackageName is the package clause of generated code.
+	PackageName string
+
+	// ObjectClasses are names or numeric OIDs of object classes to generate
+	// struct for.
+	ObjectClasses []string
+}
+
+// goStructValueType is Go type of attribute value with code converting
+// from and into string form. Converter is used instead of parser for
+// conversion which cannot fail.
+type goStructValueType struct {
+	goType       string
+	imports      []string
+	nonZeroCheck string
+	formatter    string
+	converter    string
+	parser       string
+}
+
+var goStructStringType = &goStructValueType{
+	goType:       "string",
+	nonZeroCheck: "\"\" != %s",
+	formatter:    "%s",
+	converter:    "%s",
+}
+
+// goStructValueTypes maps syntax OID to Go type of attribute value.
+// Attribute types of other syntaxes are mapped to string.
+var goStructValueTypes = map[string]*goStructValueType{
+	SyntaxOIDInteger: {
+		goType:       "int64",
+		imports:      []string{"strconv"},
+		nonZeroCheck: "0 != %s",
+		formatter:    "strconv.FormatInt(%s, 10)",
+		parser:       "%s, err = strconv.ParseInt(%s, 10, 64)",
+	},
+	SyntaxOIDBoolean: {
+		goType:       "bool",
+		imports:      []string{"strconv", "strings"},
+		nonZeroCheck: "%s",
+		formatter:    "strings.ToUpper(strconv.FormatBool(%s))",
+		parser:       "%s, err = v.parseLDAPBoolean(%s)",
+	},
+	SyntaxOIDGeneralizedTime: {
+		goType:       "time.Time",
+		imports:      []string{"time"},
+		nonZeroCheck: "!%s.IsZero()",
+		formatter:    "%s.UTC().Format(\"20060102150405.999999999Z\")",
+		parser:       "%s, err = v.parseLDAPGeneralizedTime(%s)",
+	},
+	SyntaxOIDOctetString:     goStructBytesType,
+	SyntaxOIDJPEG:            goStructBytesType,
+	SyntaxOIDCertificate:     goStructBytesType,
+	SyntaxOIDCertificateList: goStructBytesType,
+	SyntaxOIDCertificatePair: goStructBytesType,
+}
+
+var goStructBytesType = &goStructValueType{
+	goType:       "[]byte",
+	nonZeroCheck: "len(%s) > 0",
+	formatter:    "string(%s)",
+	converter:    "[]byte(%s)",
+}
+
+// goStructGeneralizedTimeLayouts are layouts of GeneralizedTime values with
+// time zone offset in `Z`, `+hhmm` or `+hh` form. Fraction of second is
+// accepted by time.Parse without being in layout.
+var goStructGeneralizedTimeLayouts = []string{
+	"20060102150405Z0700",
+	"20060102150405Z07",
+	"200601021504Z0700",
+	"200601021504Z07",
+	"2006010215Z0700",
+	"2006010215Z07",
+}
+
+// goStructHelpers are methods emitted for each generated struct which
+// parser of value type refers to. Being methods, helpers do not collide
+// when structs of one package are generated into several files.
+var goStructHelpers = []struct {
+	name    string
+	imports []string
+	code    string
+}{
+	{
+		name:    "parseLDAPBoolean",
+		imports: []string{"errors"},
+		code: `parseLDAPBoolean(value string) (bool, error) {
+	switch value {
+	case "TRUE":
+		return true, nil
+	case "FALSE":
+		return false, nil
+	}
+	return false, errors.New("invalid boolean: " + value)
+}
+`,
+	},
+	{
+		name:    "parseLDAPGeneralizedTime",
+		imports: []string{"time"},
+		code: `parseLDAPGeneralizedTime(value string) (t time.Time, err error) {
+	for _, layout := range []string{"` + strings.Join(goStructGeneralizedTimeLayouts, `", "`) + `"} {
+		if t, err = time.Parse(layout, value); nil == err {
+			return
+		}
+	}
+	return
+}
+`,
+	},
+}
+
+type goStructField struct {
+	name      string
+	attrName  string
+	keys      []string
+	valueType *goStructValueType
+	single    bool
+	required  bool
+}
+
+type goStructType struct {
+	name        string
+	objectClass *ObjectClassSchema
+	fields      []*goStructField
+}
+
+// goExportedIdentifier converts schema element name into exported Go
+// identifier by capitalizing parts separated by characters other than
+// letters and digits.
+func goExportedIdentifier(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	identifier := b.String()
+	if ("" == identifier) || !unicode.IsLetter(rune(identifier[0])) {
+		identifier = "Attr" + identifier
+	}
+	return identifier
+}
+
+// uniqueGoIdentifier appends sequence number to identifier already used.
+func uniqueGoIdentifier(identifier string, used map[string]bool) string {
+	result := identifier
+	for seq := 2; used[result]; seq++ {
+		result = identifier + strconv.Itoa(seq)
+	}
+	used[result] = true
+	return result
+}
+
+// uniqueGoStructKeys removes keys already used by the unmarshal switch.
+// Names differing only in letter case become the same key once lowercased.
+func uniqueGoStructKeys(keys []string, used map[string]bool) (result []string) {
+	for _, key := range keys {
+		if used[key] {
+			continue
+		}
+		used[key] = true
+		result = append(result, key)
+	}
+	return
+}
+
+func (store *LDAPSchemaStore) makeGoStructField(attr *ObjectClassClosureAttribute, required bool) (field *goStructField, err error) {
+	field = &goStructField{
+		attrName:  attr.Name,
+		keys:      []string{strings.ToLower(attr.Name)},
+		valueType: goStructStringType,
+		required:  required,
+	}
+	genericSchema := store.findAttributeTypeGenericSchema(attr.Name)
+	if nil == genericSchema {
+		return
+	}
+	attributeTypeSchema, err := NewAttributeTypeSchemaViaGenericSchema(genericSchema)
+	if nil != err {
+		return
+	}
+	effective, err := store.resolveEffectiveAttributeType(attributeTypeSchema)
+	if nil != err {
+		return
+	}
+	field.keys = []string{attributeTypeSchema.NumericOID}
+	for _, name := range attributeTypeSchema.Name {
+		field.keys = append(field.keys, strings.ToLower(name))
+	}
+	if valueType := goStructValueTypes[effective.SyntaxOID()]; nil != valueType {
+		field.valueType = valueType
+	}
+	field.single = attributeTypeSchema.SingleValue
+	return field, nil
+}
+
+func (store *LDAPSchemaStore) makeGoStructType(objectClassName string, usedTypeNames map[string]bool) (structType *goStructType, err error) {
+	objectClassSchema, err := store.ObjectClass(objectClassName)
+	if nil != err {
+		return
+	}
+	closure, err := store.ObjectClassClosure(objectClassSchema.NumericOID)
+	if nil != err {
+		return
+	}
+	structType = &goStructType{
+		name:        uniqueGoIdentifier(goExportedIdentifier(objectClassSchema.ShortIdentifier()), usedTypeNames),
+		objectClass: objectClassSchema,
+	}
+	usedFieldNames := make(map[string]bool)
+	usedKeys := make(map[string]bool)
+	for idx, attrs := range [][]*ObjectClassClosureAttribute{closure.Must, closure.May} {
+		for _, attr := range attrs {
+			field, err := store.makeGoStructField(attr, 0 == idx)
+			if nil != err {
+				return nil, err
+			}
+			field.keys = uniqueGoStructKeys(field.keys, usedKeys)
+			field.name = uniqueGoIdentifier(goExportedIdentifier(field.attrName), usedFieldNames)
+			structType.fields = append(structType.fields, field)
+		}
+	}
+	return structType, nil
+}
+
+func (field *goStructField) goType() string {
+	if field.single {
+		return field.valueType.goType
+	}
+	return "[]" + field.valueType.goType
+}
+
+func (field *goStructField) writeMarshal(b *strings.Builder) {
+	fieldRef := "v." + field.name
+	if field.single {
+		if field.required {
+			fmt.Fprintf(b, "\tattrs[%q] = []string{%s}\n", field.attrName, fmt.Sprintf(field.valueType.formatter, fieldRef))
+			return
+		}
+		fmt.Fprintf(b, "\tif %s {\n", fmt.Sprintf(field.valueType.nonZeroCheck, fieldRef))
+		fmt.Fprintf(b, "\t\tattrs[%q] = []string{%s}\n\t}\n", field.attrName, fmt.Sprintf(field.valueType.formatter, fieldRef))
+		return
+	}
+	fmt.Fprintf(b, "\tif len(%s) > 0 {\n", fieldRef)
+	if goStructStringType == field.valueType {
+		fmt.Fprintf(b, "\t\tattrs[%q] = append([]string(nil), %s...)\n\t}\n", field.attrName, fieldRef)
+		return
+	}
+	fmt.Fprintf(b, "\t\tvalues := make([]string, len(%s))\n", fieldRef)
+	fmt.Fprintf(b, "\t\tfor idx, value := range %s {\n", fieldRef)
+	fmt.Fprintf(b, "\t\t\tvalues[idx] = %s\n\t\t}\n", fmt.Sprintf(field.valueType.formatter, "value"))
+	fmt.Fprintf(b, "\t\tattrs[%q] = values\n\t}\n", field.attrName)
+}
+
+func (field *goStructField) writeUnmarshal(b *strings.Builder) {
+	if 0 == len(field.keys) {
+		return
+	}
+	quotedKeys := make([]string, 0, len(field.keys))
+	for _, key := range field.keys {
+		quotedKeys = append(quotedKeys, strconv.Quote(key))
+	}
+	fieldRef := "v." + field.name
+	fmt.Fprintf(b, "\t\tcase %s:\n", strings.Join(quotedKeys, ", "))
+	if field.single {
+		b.WriteString("\t\t\tif len(values) > 1 {\n")
+		fmt.Fprintf(b, "\t\t\t\treturn fmt.Errorf(\"attribute %%s is single-valued but has %%d values\", name, len(values))\n\t\t\t}\n")
+		b.WriteString("\t\t\tif len(values) == 1 {\n")
+		if "" != field.valueType.converter {
+			fmt.Fprintf(b, "\t\t\t\t%s = %s\n\t\t\t}\n", fieldRef, fmt.Sprintf(field.valueType.converter, "values[0]"))
+		} else {
+			fmt.Fprintf(b, "\t\t\t\t%s\n\t\t\t}\n", fmt.Sprintf(field.valueType.parser, fieldRef, "values[0]"))
+		}
+		return
+	}
+	if goStructStringType == field.valueType {
+		fmt.Fprintf(b, "\t\t\t%s = append([]string(nil), values...)\n", fieldRef)
+		return
+	}
+	fmt.Fprintf(b, "\t\t\t%s = make(%s, len(values))\n", fieldRef, field.goType())
+	b.WriteString("\t\t\tfor idx, value := range values {\n")
+	if "" != field.valueType.converter {
+		fmt.Fprintf(b, "\t\t\t\t%s[idx] = %s\n\t\t\t}\n", fieldRef, fmt.Sprintf(field.valueType.converter, "value"))
+	} else {
+		fmt.Fprintf(b, "\t\t\t\tif %s; nil != err {\n\t\t\t\t\tbreak\n\t\t\t\t}\n\t\t\t}\n", fmt.Sprintf(field.valueType.parser, fieldRef+"[idx]", "value"))
+	}
+}
+
+func (structType *goStructType) write(b *strings.Builder) {
+	fmt.Fprintf(b, "// %s is entry of object class %s (%s).\n", structType.name, structType.objectClass.ShortIdentifier(), structType.objectClass.NumericOID)
+	fmt.Fprintf(b, "type %s struct {\n", structType.name)
+	for _, field := range structType.fields {
+		fmt.Fprintf(b, "\t%s %s `ldap:%q`\n", field.name, field.goType(), field.attrName)
+	}
+	b.WriteString("}\n\n")
+	fmt.Fprintf(b, "// MarshalLDAPAttributes converts %s into attribute values. Empty\n// optional attributes are omitted.\n", structType.name)
+	fmt.Fprintf(b, "func (v *%s) MarshalLDAPAttributes() map[string][]string {\n", structType.name)
+	b.WriteString("\tattrs := make(map[string][]string)\n")
+	for _, field := range structType.fields {
+		field.writeMarshal(b)
+	}
+	b.WriteString("\treturn attrs\n}\n\n")
+	fmt.Fprintf(b, "// UnmarshalLDAPAttributes fills %s with attribute values. Attribute\n// names are matched case-insensitively, unknown attributes are ignored.\n", structType.name)
+	fmt.Fprintf(b, "func (v *%s) UnmarshalLDAPAttributes(attrs map[string][]string) (err error) {\n", structType.name)
+	b.WriteString("\tfor name, values := range attrs {\n\t\tswitch strings.ToLower(name) {\n")
+	for _, field := range structType.fields {
+		field.writeUnmarshal(b)
+	}
+	b.WriteString("\t\t}\n\t\tif nil != err {\n\t\t\treturn fmt.Errorf(\"cannot unmarshal attribute %s: %v\", name, err)\n\t\t}\n\t}\n\treturn nil\n}\n\n")
+}
+
+// GenerateGoStructs writes Go source code of structs mapping entries of
+// given object classes. Each struct has one field for each attribute type
+// in closure of the object class with `ldap` tag of attribute name. Field
+// type is derived from syntax of attribute type and is slice unless the
+// attribute type is SINGLE-VALUE. MarshalLDAPAttributes and
+// UnmarshalLDAPAttributes methods converting from and into
+// map[string][]string are generated for each struct.
+func (store *LDAPSchemaStore) GenerateGoStructs(w io.Writer, option *GoStructOption) (err error) {
+	if (nil == option) || ("" == option.PackageName) {
+		return &ErrMissingField{
+			FieldName: "PackageName",
+		}
+	}
+	if 0 == len(option.ObjectClasses) {
+		return &ErrMissingField{
+			FieldName: "ObjectClasses",
+		}
+	}
+	usedTypeNames := make(map[string]bool)
+	imports := map[string]bool{
+		"fmt":     true,
+		"strings": true,
+	}
+	var body strings.Builder
+	for _, objectClassName := range option.ObjectClasses {
+		structType, err := store.makeGoStructType(objectClassName, usedTypeNames)
+		if nil != err {
+			return err
+		}
+		for _, field := range structType.fields {
+			for _, importPath := range field.valueType.imports {
+				imports[importPath] = true
+			}
+		}
+		var structBody strings.Builder
+		structType.write(&structBody)
+		for _, helper := range goStructHelpers {
+			if !strings.Contains(structBody.String(), "v."+helper.name+"(") {
+				continue
+			}
+			for _, importPath := range helper.imports {
+				imports[importPath] = true
+			}
+			fmt.Fprintf(&structBody, "func (*%s) %s\n", structType.name, helper.code)
+		}
+		body.WriteString(structBody.String())
+	}
+	var b strings.Builder
+	b.WriteString("// Code generated from LDAP schema. DO NOT EDIT.\n\n")
+	b.WriteString("package " + option.PackageName + "\n\nimport (\n")
+	importPaths := make([]string, 0, len(imports))
+	for importPath := range imports {
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+	for _, importPath := range importPaths {
+		b.WriteString("\t" + strconv.Quote(importPath) + "\n")
+	}
+	b.WriteString(")\n\n" + body.String())
+	source, err := format.Source([]byte(b.String()))
+	if nil != err {
+		return
+	}
+	_, err = w.Write(source)
+	return
+}
@@ -0,0 +1,218 @@
+package ldapschemaparser
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeGoStructSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := makeSchemaGraphSampleStore(t)
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.1466.115.121.1.7 DESC 'Boolean' )",
+		"( 1.3.6.1.4.1.1466.115.121.1.24 DESC 'Generalized Time' )",
+		"( 1.3.6.1.4.1.1466.115.121.1.27 DESC 'INTEGER' )",
+		"( 1.3.6.1.4.1.1466.115.121.1.40 DESC 'Octet String' )",
+	} {
+		if err := store.AddLDAPSyntaxSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding LDAP syntax: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.11 NAME ( 'sampleRackUnits' 'sampleRU' ) SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 SINGLE-VALUE )",
+		"( 1.3.6.1.4.1.99999.1.12 NAME 'sampleEnabled' SYNTAX 1.3.6.1.4.1.1466.115.121.1.7 SINGLE-VALUE )",
+		"( 1.3.6.1.4.1.99999.1.13 NAME 'sample-installed-at' SYNTAX 1.3.6.1.4.1.1466.115.121.1.24 SINGLE-VALUE )",
+		"( 1.3.6.1.4.1.99999.1.14 NAME 'sampleFirmware' SYNTAX 1.3.6.1.4.1.1466.115.121.1.40 )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	if err := store.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.4 NAME 'sampleRackDevice' SUP sampleDevice MUST sampleRackUnits MAY ( sampleEnabled $ sample-installed-at $ sampleFirmware ) )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	return store
+}
+
+func TestLDAPSchemaStoreGenerateGoStructs_1(t *testing.T) {
+	store := makeGoStructSampleStore(t)
+	var b strings.Builder
+	if err := store.GenerateGoStructs(&b, &GoStructOption{
+		PackageName:   "sample",
+		ObjectClasses: []string{"sampleExtra"},
+	}); nil != err {
+		t.Fatalf("failed on generating Go structs: %v", err)
+	}
+	expect := `// Code generated from LDAP schema. DO NOT EDIT.
+
+package sample
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SampleExtra is entry of object class sampleExtra (1.3.6.1.4.1.99999.2.2).
+type SampleExtra struct {
+	Description []string ` + "`ldap:\"description\"`" + `
+}
+
+// MarshalLDAPAttributes converts SampleExtra into attribute values. Empty
+// optional attributes are omitted.
+func (v *SampleExtra) MarshalLDAPAttributes() map[string][]string {
+	attrs := make(map[string][]string)
+	if len(v.Description) > 0 {
+		attrs["description"] = append([]string(nil), v.Description...)
+	}
+	return attrs
+}
+
+// UnmarshalLDAPAttributes fills SampleExtra with attribute values. Attribute
+// names are matched case-insensitively, unknown attributes are ignored.
+func (v *SampleExtra) UnmarshalLDAPAttributes(attrs map[string][]string) (err error) {
+	for name, values := range attrs {
+		switch strings.ToLower(name) {
+		case "2.5.4.13", "description":
+			v.Description = append([]string(nil), values...)
+		}
+		if nil != err {
+			return fmt.Errorf("cannot unmarshal attribute %s: %v", name, err)
+		}
+	}
+	return nil
+}
+`
+	if v := b.String(); v != expect {
+		t.Errorf("expecting:\n%v\nbut have:\n%v", expect, v)
+	}
+}
+
+func TestLDAPSchemaStoreGenerateGoStructs_2(t *testing.T) {
+	store := makeGoStructSampleStore(t)
+	var b strings.Builder
+	if err := store.GenerateGoStructs(&b, &GoStructOption{
+		PackageName:   "sample",
+		ObjectClasses: []string{"1.3.6.1.4.1.99999.2.4"},
+	}); nil != err {
+		t.Fatalf("failed on generating Go structs: %v", err)
+	}
+	code := b.String()
+	for _, expect := range []string{
+		"\t\"errors\"\n\t\"fmt\"\n\t\"strconv\"\n\t\"strings\"\n\t\"time\"\n",
+		"\tSampleRackUnits   int64     `ldap:\"sampleRackUnits\"`\n",
+		"\tCn                []string  `ldap:\"cn\"`\n",
+		"\tSampleEnabled     bool      `ldap:\"sampleEnabled\"`\n",
+		"\tSampleInstalledAt time.Time `ldap:\"sample-installed-at\"`\n",
+		"\tSampleFirmware    [][]byte  `ldap:\"sampleFirmware\"`\n",
+		"\tattrs[\"sampleRackUnits\"] = []string{strconv.FormatInt(v.SampleRackUnits, 10)}\n",
+		"\t\tcase \"1.3.6.1.4.1.99999.1.11\", \"samplerackunits\", \"sampleru\":\n",
+		"\t\t\t\tv.SampleEnabled, err = v.parseLDAPBoolean(values[0])\n",
+		"func (*SampleRackDevice) parseLDAPGeneralizedTime(value string) (t time.Time, err error) {\n",
+	} {
+		if !strings.Contains(code, expect) {
+			t.Errorf("expecting %q in code:\n%v", expect, code)
+		}
+	}
+	if err := store.GenerateGoStructs(&b, &GoStructOption{
+		PackageName:   "sample",
+		ObjectClasses: []string{"sampleUnknown"},
+	}); nil == err {
+		t.Error("expecting error for unknown object class")
+	}
+	if err := store.GenerateGoStructs(&b, &GoStructOption{
+		ObjectClasses: []string{"sampleExtra"},
+	}); nil == err {
+		t.Error("expecting error for missing package name")
+	}
+}
+
+func TestLDAPSchemaStoreGenerateGoStructs_3(t *testing.T) {
+	store := makeGoStructSampleStore(t)
+	var b strings.Builder
+	if err := store.GenerateGoStructs(&b, &GoStructOption{
+		PackageName:   "sample",
+		ObjectClasses: []string{"sampleExtra", "sampleRackDevice"},
+	}); nil != err {
+		t.Fatalf("failed on generating Go structs: %v", err)
+	}
+	code := b.String()
+	if strings.Contains(code, "\nfunc parseLDAP") {
+		t.Errorf("unexpected package level helper in code:\n%v", code)
+	}
+	if v := strings.Count(code, ") parseLDAPBoolean("); v != 1 {
+		t.Errorf("expecting helper only for struct using it but have %d:\n%v", v, code)
+	}
+}
+
+func TestLDAPSchemaStoreGenerateGoStructs_4(t *testing.T) {
+	store := makeGoStructSampleStore(t)
+	if err := store.AddAttributeTypeSchemaText("( 2.5.4.25 NAME ( 'internationalISDNNumber' 'internationaliSDNNumber' ) SYNTAX 1.3.6.1.4.1.1466.115.121.1.36 )"); nil != err {
+		t.Fatalf("failed on adding attribute type: %v", err)
+	}
+	if err := store.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.5 NAME 'sampleISDNDevice' SUP sampleRackDevice MAY internationalISDNNumber )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	var b strings.Builder
+	if err := store.GenerateGoStructs(&b, &GoStructOption{
+		PackageName:   "sample",
+		ObjectClasses: []string{"sampleISDNDevice"},
+	}); nil != err {
+		t.Fatalf("failed on generating Go structs: %v", err)
+	}
+	code := b.String()
+	if v := strings.Count(code, `"internationalisdnnumber"`); v != 1 {
+		t.Errorf("expecting one case key of internationalisdnnumber but have %d:\n%v", v, code)
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "sample.go", code, 0)
+	if nil != err {
+		t.Fatalf("failed on parsing generated code: %v", err)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	if _, err = conf.Check("sample", fset, []*ast.File{file}, nil); nil != err {
+		t.Errorf("failed on type checking generated code: %v\n%v", err, code)
+	}
+}
+
+func TestGoStructGeneralizedTimeLayouts_1(t *testing.T) {
+	for value, expect := range map[string]string{
+		"20240102030405Z":       "2024-01-02T03:04:05Z",
+		"20240102030405.5Z":     "2024-01-02T03:04:05.5Z",
+		"20240102030405+0930":   "2024-01-01T17:34:05Z",
+		"20240102030405-05":     "2024-01-02T08:04:05Z",
+		"202401020304+08":       "2024-01-01T19:04:00Z",
+		"2024010203Z":           "2024-01-02T03:00:00Z",
+		"2024010203-0130":       "2024-01-02T04:30:00Z",
+		"20240102030405.123+01": "2024-01-02T02:04:05.123Z",
+	} {
+		var parsed time.Time
+		var err error
+		for _, layout := range goStructGeneralizedTimeLayouts {
+			if parsed, err = time.Parse(layout, value); nil == err {
+				break
+			}
+		}
+		if nil != err {
+			t.Errorf("failed on parsing %s: %v", value, err)
+		} else if v := parsed.UTC().Format(time.RFC3339Nano); v != expect {
+			t.Errorf("expecting %s for %s but have %s", expect, value, v)
+		}
+	}
+}
+
+func TestGoExportedIdentifier_1(t *testing.T) {
+	for name, expect := range map[string]string{
+		"cn":                  "Cn",
+		"sample-installed-at": "SampleInstalledAt",
+		"1.2.3":               "Attr123",
+	} {
+		if v := goExportedIdentifier(name); v != expect {
+			t.Errorf("expecting %s for %s but have %s", expect, name, v)
+		}
+	}
+}
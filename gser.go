@@ -0,0 +1,257 @@
+package ldapschemaparser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// gserValueKind is kind of value in Generic String Encoding Rules (GSER,
+// RFC-3641).
+type gserValueKind int
+
+const (
+	gserString gserValueKind = iota
+	gserNumber
+	gserWord
+	gserNumericOID
+	gserBinary
+	gserChoice
+	gserSequence
+	gserSequenceOf
+)
+
+// gserComponent is an identified component of GSER SEQUENCE value.
+type gserComponent struct {
+	identifier string
+	value      *gserValue
+}
+
+// gserValue is a parsed GSER value. Empty braces are parsed as SEQUENCE
+// without component.
+type gserValue struct {
+	kind gserValueKind
+
+	// text is the unescaped content of string, the token of number, word,
+	// numeric OID and binary values, or the identifier of CHOICE value.
+	text string
+
+	// chosen is the value of CHOICE value.
+	chosen *gserValue
+
+	components []*gserComponent
+	items      []*gserValue
+}
+
+type gserParser struct {
+	text string
+	pos  int
+}
+
+func (p *gserParser) errorf(message string) error {
+	return errors.New(message + " at offset " + strconv.Itoa(p.pos))
+}
+
+func (p *gserParser) skipSpaces() int {
+	start := p.pos
+	for (p.pos < len(p.text)) && (p.text[p.pos] == ' ') {
+		p.pos++
+	}
+	return p.pos - start
+}
+
+func (p *gserParser) peek() byte {
+	if p.pos < len(p.text) {
+		return p.text[p.pos]
+	}
+	return 0
+}
+
+func isGSERWordByte(ch byte) bool {
+	return isAlphaByte(ch) || isDigitByte(ch) || (ch == '-')
+}
+
+func (p *gserParser) parseWord() string {
+	start := p.pos
+	for (p.pos < len(p.text)) && isGSERWordByte(p.text[p.pos]) {
+		p.pos++
+	}
+	return p.text[start:p.pos]
+}
+
+func (p *gserParser) parseString() (value *gserValue, err error) {
+	var b strings.Builder
+	p.pos++
+	for p.pos < len(p.text) {
+		ch := p.text[p.pos]
+		p.pos++
+		if ch != '"' {
+			b.WriteByte(ch)
+			continue
+		}
+		if p.peek() != '"' {
+			return &gserValue{
+				kind: gserString,
+				text: b.String(),
+			}, nil
+		}
+		b.WriteByte('"')
+		p.pos++
+	}
+	return nil, p.errorf("unterminated string")
+}
+
+// parseBinary parses bstring ('0101'B) or hstring ('0AF'H).
+func (p *gserParser) parseBinary() (value *gserValue, err error) {
+	start := p.pos
+	end := strings.IndexByte(p.text[start+1:], '\'')
+	if (end < 0) || (start+end+2 >= len(p.text)) {
+		return nil, p.errorf("unterminated binary string")
+	}
+	digits := p.text[start+1 : start+1+end]
+	p.pos = start + end + 3
+	switch p.text[start+end+2] {
+	case 'B':
+		for idx := 0; idx < len(digits); idx++ {
+			if (digits[idx] != '0') && (digits[idx] != '1') {
+				return nil, p.errorf("invalid binary digit")
+			}
+		}
+	case 'H':
+		for idx := 0; idx < len(digits); idx++ {
+			if !isDigitByte(digits[idx]) && ((digits[idx] < 'A') || (digits[idx] > 'F')) {
+				return nil, p.errorf("invalid hex digit")
+			}
+		}
+	default:
+		return nil, p.errorf("expecting B or H after binary string")
+	}
+	return &gserValue{
+		kind: gserBinary,
+		text: p.text[start:p.pos],
+	}, nil
+}
+
+// parseNumber parses integer, real number and numeric OID.
+func (p *gserParser) parseNumber() (value *gserValue, err error) {
+	start := p.pos
+	if p.peek() == '-' {
+		p.pos++
+	}
+	for (p.pos < len(p.text)) && (isDigitByte(p.text[p.pos]) || (strings.IndexByte(".eE+-", p.text[p.pos]) >= 0)) {
+		p.pos++
+	}
+	token := p.text[start:p.pos]
+	if isNumericOIDValue(token) {
+		return &gserValue{
+			kind: gserNumericOID,
+			text: token,
+		}, nil
+	}
+	if _, err = strconv.ParseFloat(token, 64); nil != err {
+		return nil, errors.New("invalid number: " + token)
+	}
+	return &gserValue{
+		kind: gserNumber,
+		text: token,
+	}, nil
+}
+
+// parseBraces parses SEQUENCE value (identifier msp Value pairs) or
+// SEQUENCE OF value (Value list) between braces.
+func (p *gserParser) parseBraces() (value *gserValue, err error) {
+	p.pos++
+	value = &gserValue{
+		kind: gserSequence,
+	}
+	p.skipSpaces()
+	if p.peek() == '}' {
+		p.pos++
+		return value, nil
+	}
+	for idx := 0; ; idx++ {
+		if idx > 0 {
+			p.skipSpaces()
+		}
+		mark := p.pos
+		var component *gserComponent
+		if identifier := p.parseWord(); ("" != identifier) && isAlphaByte(identifier[0]) && (p.skipSpaces() > 0) && (p.peek() != '}') && (p.peek() != ',') {
+			component = &gserComponent{
+				identifier: identifier,
+			}
+		} else {
+			p.pos = mark
+		}
+		if (idx > 0) && ((nil != component) != (gserSequence == value.kind)) {
+			return nil, p.errorf("mixed components and values")
+		}
+		item, err := p.parseValue()
+		if nil != err {
+			return nil, err
+		}
+		if nil != component {
+			component.value = item
+			value.components = append(value.components, component)
+		} else {
+			value.kind = gserSequenceOf
+			value.items = append(value.items, item)
+		}
+		p.skipSpaces()
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case '}':
+			p.pos++
+			return value, nil
+		default:
+			return nil, p.errorf("expecting , or }")
+		}
+	}
+}
+
+func (p *gserParser) parseValue() (value *gserValue, err error) {
+	ch := p.peek()
+	switch {
+	case ch == '"':
+		return p.parseString()
+	case ch == '\'':
+		return p.parseBinary()
+	case ch == '{':
+		return p.parseBraces()
+	case (ch == '-') || isDigitByte(ch):
+		return p.parseNumber()
+	case isAlphaByte(ch):
+		word := p.parseWord()
+		if p.peek() != ':' {
+			return &gserValue{
+				kind: gserWord,
+				text: word,
+			}, nil
+		}
+		p.pos++
+		chosen, err := p.parseValue()
+		if nil != err {
+			return nil, err
+		}
+		return &gserValue{
+			kind:   gserChoice,
+			text:   word,
+			chosen: chosen,
+		}, nil
+	}
+	return nil, p.errorf("unexpected character")
+}
+
+// parseGSERValue parses text as one GSER value.
+func parseGSERValue(text string) (value *gserValue, err error) {
+	p := &gserParser{
+		text: text,
+	}
+	if value, err = p.parseValue(); nil != err {
+		return
+	}
+	if p.pos != len(p.text) {
+		return nil, p.errorf("trailing text")
+	}
+	return value, nil
+}
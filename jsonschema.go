@@ -0,0 +1,191 @@
+package ldapschemaparser
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+)
+
+// JSONSchemaDraft202012 is URI of meta-schema of JSON Schema draft 2020-12.
+const JSONSchemaDraft202012 = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is the subset of JSON Schema (draft 2020-12) keywords used to
+// describe entries of object classes.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	MaxLength            int32                  `json:"maxLength,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	MinItems             int                    `json:"minItems,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	ReadOnly             bool                   `json:"readOnly,omitempty"`
+	Deprecated           bool                   `json:"deprecated,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// jsonSchemaValueType is JSON Schema of attribute value of a syntax.
+// MaxLength of base64 encoded values is computed from length of decoded
+// content.
+type jsonSchemaValueType struct {
+	jsonType        string
+	pattern         string
+	contentEncoding string
+}
+
+var jsonSchemaStringType = &jsonSchemaValueType{
+	jsonType: "string",
+}
+
+var jsonSchemaBase64Type = &jsonSchemaValueType{
+	jsonType:        "string",
+	contentEncoding: "base64",
+}
+
+// jsonSchemaValueTypes maps syntax OID to JSON Schema of attribute value.
+// Attribute types of other syntaxes are described as string.
+var jsonSchemaValueTypes = map[string]*jsonSchemaValueType{
+	SyntaxOIDInteger: {
+		jsonType: "integer",
+	},
+	SyntaxOIDBoolean: {
+		jsonType: "boolean",
+	},
+	SyntaxOIDGeneralizedTime: {
+		jsonType: "string",
+		pattern:  "^[0-9]{10}([0-9]{2}([0-9]{2})?)?([.,][0-9]+)?(Z|[+-][0-9]{2}([0-9]{2})?)$",
+	},
+	SyntaxOIDNumericString: {
+		jsonType: "string",
+		pattern:  "^[0-9 ]+$",
+	},
+	SyntaxOIDOctetString:     jsonSchemaBase64Type,
+	SyntaxOIDJPEG:            jsonSchemaBase64Type,
+	SyntaxOIDCertificate:     jsonSchemaBase64Type,
+	SyntaxOIDCertificateList: jsonSchemaBase64Type,
+	SyntaxOIDCertificatePair: jsonSchemaBase64Type,
+}
+
+func (store *LDAPSchemaStore) attributeJSONSchema(attr *ObjectClassClosureAttribute, required bool) (property *JSONSchema, err error) {
+	value := &JSONSchema{
+		Type: jsonSchemaStringType.jsonType,
+	}
+	property = &JSONSchema{
+		Type:  "array",
+		Items: value,
+	}
+	if required {
+		property.MinItems = 1
+	}
+	genericSchema := store.findAttributeTypeGenericSchema(attr.Name)
+	if nil == genericSchema {
+		return
+	}
+	attributeTypeSchema, err := NewAttributeTypeSchemaViaGenericSchema(genericSchema)
+	if nil != err {
+		return
+	}
+	effective, err := store.resolveEffectiveAttributeType(attributeTypeSchema)
+	if nil != err {
+		return
+	}
+	valueType := jsonSchemaValueTypes[effective.SyntaxOID()]
+	if nil == valueType {
+		valueType = jsonSchemaStringType
+	}
+	value.Type = valueType.jsonType
+	value.Pattern = valueType.pattern
+	value.ContentEncoding = valueType.contentEncoding
+	if syntaxLength := effective.SyntaxLength(); (syntaxLength > 0) && ("string" == valueType.jsonType) {
+		if "base64" == valueType.contentEncoding {
+			syntaxLength = (syntaxLength + 2) / 3 * 4
+		}
+		value.MaxLength = syntaxLength
+	}
+	if attributeTypeSchema.SingleValue {
+		property = value
+	}
+	property.Description = attributeTypeSchema.Description
+	property.ReadOnly = attributeTypeSchema.NoUserModification
+	property.Deprecated = attributeTypeSchema.Obsolete
+	return property, nil
+}
+
+// ObjectClassJSONSchema makes JSON Schema (draft 2020-12) of entries of
+// given object class. Properties are attribute types of the object class
+// and all its superclasses, named with the first name of attribute type.
+// MUST attribute types are listed in required. Values of SINGLE-VALUE
+// attribute types are scalars and others are arrays. Value types are
+// derived from syntax of attribute types with maxLength from the syntax
+// length bound.
+func (store *LDAPSchemaStore) ObjectClassJSONSchema(objectClassName string) (schema *JSONSchema, err error) {
+	objectClassSchema, err := store.ObjectClass(objectClassName)
+	if nil != err {
+		return
+	}
+	closure, err := store.ObjectClassClosure(objectClassSchema.NumericOID)
+	if nil != err {
+		return
+	}
+	additionalProperties := false
+	schema = &JSONSchema{
+		Schema:               JSONSchemaDraft202012,
+		Title:                objectClassSchema.ShortIdentifier(),
+		Description:          objectClassSchema.Description,
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: &additionalProperties,
+		Deprecated:           objectClassSchema.Obsolete,
+	}
+	for idx, attrs := range [][]*ObjectClassClosureAttribute{closure.Must, closure.May} {
+		for _, attr := range attrs {
+			property, err := store.attributeJSONSchema(attr, 0 == idx)
+			if nil != err {
+				return nil, err
+			}
+			schema.Properties[attr.Name] = property
+			if 0 == idx {
+				schema.Required = append(schema.Required, attr.Name)
+			}
+		}
+	}
+	return schema, nil
+}
+
+// WriteJSONSchema writes JSON Schema of given object classes as $defs of
+// one schema document keyed with short identifier of object classes. All
+// object classes in store are written when no object class is given, and
+// object classes referencing superclasses missing from store are skipped
+// with a warning.
+func (store *LDAPSchemaStore) WriteJSONSchema(w io.Writer, objectClassNames ...string) (err error) {
+	skipMissing := false
+	if 0 == len(objectClassNames) {
+		for _, oid := range sortedMapKey(store.objectClassSchemaIndex) {
+			objectClassNames = append(objectClassNames, oid)
+		}
+		skipMissing = true
+	}
+	document := &JSONSchema{
+		Schema: JSONSchemaDraft202012,
+		Defs:   make(map[string]*JSONSchema),
+	}
+	for _, objectClassName := range objectClassNames {
+		schema, err := store.ObjectClassJSONSchema(objectClassName)
+		if _, ok := err.(*ErrSchemaNotFound); ok && skipMissing {
+			log.Printf("WARN: skip JSON Schema of object class %s: %v", objectClassName, err)
+			continue
+		} else if nil != err {
+			return err
+		}
+		schema.Schema = ""
+		document.Defs[schema.Title] = schema
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(document)
+}
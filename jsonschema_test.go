@@ -0,0 +1,116 @@
+package ldapschemaparser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLDAPSchemaStoreObjectClassJSONSchema_1(t *testing.T) {
+	store := makeGoStructSampleStore(t)
+	store.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.15 NAME 'sampleLabel' DESC 'printed label' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32} SINGLE-VALUE )")
+	store.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.16 NAME 'sampleCertificate' SYNTAX 1.3.6.1.4.1.1466.115.121.1.40{100} SINGLE-VALUE NO-USER-MODIFICATION )")
+	store.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.5 NAME 'sampleLabeled' AUXILIARY MUST sampleLabel MAY ( sampleCertificate $ sampleMissing ) )")
+	schema, err := store.ObjectClassJSONSchema("sampleLabeled")
+	if nil != err {
+		t.Fatalf("failed on making JSON schema: %v", err)
+	}
+	buf, err := json.Marshal(schema)
+	if nil != err {
+		t.Fatalf("failed on encoding JSON schema: %v", err)
+	}
+	expect := `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"sampleLabeled","type":"object",` +
+		`"properties":{"sampleCertificate":{"type":"string","contentEncoding":"base64","maxLength":136,"readOnly":true},` +
+		`"sampleLabel":{"description":"printed label","type":"string","maxLength":32},` +
+		`"sampleMissing":{"type":"array","items":{"type":"string"}}},` +
+		`"required":["sampleLabel"],"additionalProperties":false}`
+	if v := string(buf); v != expect {
+		t.Errorf("expecting:\n%v\nbut have:\n%v", expect, v)
+	}
+	if schema, err = store.ObjectClassJSONSchema("sampleRackDevice"); nil != err {
+		t.Fatalf("failed on making JSON schema: %v", err)
+	}
+	if v := strings.Join(schema.Required, " "); v != "sampleRackUnits cn" {
+		t.Errorf("unexpected required properties: %v", v)
+	}
+	for name, expect := range map[string]string{
+		"sampleRackUnits":     `{"type":"integer"}`,
+		"sampleEnabled":       `{"type":"boolean"}`,
+		"sample-installed-at": `{"type":"string","pattern":"^[0-9]{10}([0-9]{2}([0-9]{2})?)?([.,][0-9]+)?(Z|[+-][0-9]{2}([0-9]{2})?)$"}`,
+		"sampleFirmware":      `{"type":"array","items":{"type":"string","contentEncoding":"base64"}}`,
+		"cn":                  `{"type":"array","items":{"type":"string"},"minItems":1}`,
+	} {
+		if buf, err = json.Marshal(schema.Properties[name]); nil != err {
+			t.Fatalf("failed on encoding JSON schema of property %s: %v", name, err)
+		}
+		if v := string(buf); v != expect {
+			t.Errorf("expecting %s for property %s but have %s", expect, name, v)
+		}
+	}
+	if _, err = store.ObjectClassJSONSchema("sampleUnknown"); nil == err {
+		t.Error("expecting error for unknown object class")
+	}
+}
+
+func TestLDAPSchemaStoreWriteJSONSchema_1(t *testing.T) {
+	store := makeGoStructSampleStore(t)
+	var b strings.Builder
+	if err := store.WriteJSONSchema(&b, "sampleExtra"); nil != err {
+		t.Fatalf("failed on writing JSON schema: %v", err)
+	}
+	expect := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$defs": {
+    "sampleExtra": {
+      "title": "sampleExtra",
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      },
+      "additionalProperties": false
+    }
+  }
+}
+`
+	if v := b.String(); v != expect {
+		t.Errorf("expecting:\n%v\nbut have:\n%v", expect, v)
+	}
+	b.Reset()
+	if err := store.WriteJSONSchema(&b); nil != err {
+		t.Fatalf("failed on writing JSON schema: %v", err)
+	}
+	var document JSONSchema
+	if err := json.Unmarshal([]byte(b.String()), &document); nil != err {
+		t.Fatalf("failed on loading JSON schema: %v", err)
+	}
+	if len(document.Defs) != 4 || (nil == document.Defs["top"]) || (nil == document.Defs["sampleRackDevice"]) {
+		t.Errorf("unexpected definitions: %v", document.Defs)
+	}
+}
+
+func TestLDAPSchemaStoreWriteJSONSchema_2(t *testing.T) {
+	store := makeGoStructSampleStore(t)
+	if err := store.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.5 NAME 'sampleEmployee' SUP person STRUCTURAL MUST cn )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	var b strings.Builder
+	if err := store.WriteJSONSchema(&b); nil != err {
+		t.Fatalf("failed on writing JSON schema of partial store: %v", err)
+	}
+	var document JSONSchema
+	if err := json.Unmarshal([]byte(b.String()), &document); nil != err {
+		t.Fatalf("failed on loading JSON schema: %v", err)
+	}
+	if len(document.Defs) != 4 || (nil != document.Defs["sampleEmployee"]) || (nil == document.Defs["sampleRackDevice"]) {
+		t.Errorf("unexpected definitions: %v", document.Defs)
+	}
+	b.Reset()
+	if err := store.WriteJSONSchema(&b, "sampleEmployee"); nil == err {
+		t.Error("expecting error for object class given by name with missing superclass")
+	}
+}
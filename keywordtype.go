@@ -10,6 +10,7 @@ var keywordTypeLookupMap = map[string]int{
 	"MUST":     NOIDS_ATTR_KEYWORD,
 	"NAME":     QSTRINGS_ATTR_KEYWORD,
 	"NOT":      NOIDS_ATTR_KEYWORD,
+	"OC":       NOIDS_ATTR_KEYWORD,
 	"ORDERING": NOIDS_ATTR_KEYWORD,
 	"SUBSTR":   NOIDS_ATTR_KEYWORD,
 	"SUP":      NOIDS_ATTR_KEYWORD,
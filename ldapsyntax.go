@@ -2,9 +2,9 @@ package ldapschemaparser
 
 // LDAPSyntaxSchema represent schema of LDAP syntax
 type LDAPSyntaxSchema struct {
-	NumericOID  string
-	Description string
-	Extensions  map[string][]string
+	NumericOID  string              `json:"oid"`
+	Description string              `json:"desc,omitempty"`
+	Extensions  map[string][]string `json:"extensions,omitempty"`
 }
 
 // NewLDAPSyntaxSchemaViaGenericSchema creates matching rule use schema instance from GenericSchema
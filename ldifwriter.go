@@ -0,0 +1,62 @@
+package ldapschemaparser
+
+import (
+	"encoding/base64"
+	"io"
+)
+
+const ldifLineWidth = 76
+
+func isLDIFSafeString(v string) bool {
+	if "" == v {
+		return true
+	}
+	switch v[0] {
+	case ' ', ':', '<':
+		return false
+	}
+	if v[len(v)-1] == ' ' {
+		return false
+	}
+	for idx := 0; idx < len(v); idx++ {
+		ch := v[idx]
+		if (ch == 0) || (ch == '\n') || (ch == '\r') || (ch > 127) {
+			return false
+		}
+	}
+	return true
+}
+
+// foldLDIFLine folds given line into lines of ldifLineWidth columns.
+// Continued lines are started with one space. (RFC-2849)
+func foldLDIFLine(ln string) string {
+	if len(ln) <= ldifLineWidth {
+		return ln + "\n"
+	}
+	result := make([]byte, 0, len(ln)+(len(ln)/ldifLineWidth)*2+1)
+	result = append(result, ln[:ldifLineWidth]...)
+	for idx := ldifLineWidth; idx < len(ln); idx += ldifLineWidth - 1 {
+		boundIdx := idx + ldifLineWidth - 1
+		if boundIdx > len(ln) {
+			boundIdx = len(ln)
+		}
+		result = append(result, '\n', ' ')
+		result = append(result, ln[idx:boundIdx]...)
+	}
+	result = append(result, '\n')
+	return string(result)
+}
+
+func writeLDIFLine(w io.Writer, ln string) (err error) {
+	_, err = io.WriteString(w, foldLDIFLine(ln))
+	return
+}
+
+// writeLDIFAttributeValue writes an attribute value line in LDIF form.
+// Values which are not safe strings are base64 encoded.
+func writeLDIFAttributeValue(w io.Writer, attrName, value string) (err error) {
+	if isLDIFSafeString(value) {
+		return writeLDIFLine(w, attrName+": "+value)
+	}
+	return writeLDIFLine(w, attrName+":: "+base64.StdEncoding.EncodeToString([]byte(value)))
+}
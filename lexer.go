@@ -4,13 +4,14 @@ package ldapschemaparser
 //go:generate ./keyword-type-lookup-table-gen -in SYNTAX.md -out keywordtype.go
 
 import (
-	"log"
 	"strings"
 	"unicode"
 )
 
 const dataEOF = 0
 
+const parseErrorSnippetRadius = 20
+
 func init() {
 	yyErrorVerbose = true
 }
@@ -29,11 +30,17 @@ type schemaLexer struct {
 	dataLength   int
 	currentIndex int
 
-	result *GenericSchema
+	tokenStartIndex int
+
+	result     *GenericSchema
+	parseError *ParseError
 }
 
 func newSchemaLexer(schemaText string) *schemaLexer {
-	d := []rune(schemaText)
+	return newSchemaLexerWithRunes([]rune(schemaText))
+}
+
+func newSchemaLexerWithRunes(d []rune) *schemaLexer {
 	return &schemaLexer{
 		dataContent: d,
 		dataLength:  len(d),
@@ -43,6 +50,7 @@ func newSchemaLexer(schemaText string) *schemaLexer {
 func (lexer *schemaLexer) Lex(lval *yySymType) (lexIdentifier int) {
 	var result []rune
 	startIndex := lexer.currentIndex
+	lexer.tokenStartIndex = startIndex
 	for {
 		ch := lexer.next()
 		if ch == dataEOF {
@@ -170,6 +178,61 @@ func (lexer *schemaLexer) escapedQuotedCharacter(result []rune) []rune {
 	return result
 }
 
+func (lexer *schemaLexer) lineColumn(offset int) (line, column int) {
+	line = 1
+	column = 1
+	for idx := 0; (idx < offset) && (idx < lexer.dataLength); idx++ {
+		if lexer.dataContent[idx] == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return
+}
+
+func (lexer *schemaLexer) snippet(offset int) string {
+	startIndex := offset - parseErrorSnippetRadius
+	if startIndex < 0 {
+		startIndex = 0
+	}
+	boundIndex := offset + parseErrorSnippetRadius
+	if boundIndex > lexer.dataLength {
+		boundIndex = lexer.dataLength
+	}
+	if startIndex >= boundIndex {
+		return ""
+	}
+	return string(lexer.dataContent[startIndex:boundIndex])
+}
+
+func parseExpectedTokens(e string) (expected []string) {
+	const expectingMark = ", expecting "
+	idx := strings.Index(e, expectingMark)
+	if idx < 0 {
+		return nil
+	}
+	return strings.Split(e[idx+len(expectingMark):], " or ")
+}
+
 func (lexer *schemaLexer) Error(e string) {
-	log.Printf("parse error: %s", e)
+	offset := lexer.tokenStartIndex
+	if offset > lexer.dataLength {
+		offset = lexer.dataLength
+	}
+	boundIndex := lexer.currentIndex
+	if boundIndex > lexer.dataLength {
+		boundIndex = lexer.dataLength
+	}
+	line, column := lexer.lineColumn(offset)
+	lexer.parseError = &ParseError{
+		Message:  e,
+		Offset:   offset,
+		Line:     line,
+		Column:   column,
+		Token:    string(lexer.dataContent[offset:boundIndex]),
+		Expected: parseExpectedTokens(e),
+		Snippet:  lexer.snippet(offset),
+	}
 }
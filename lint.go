@@ -0,0 +1,209 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LintSeverity indicates the severity of lint issue
+type LintSeverity string
+
+// LintSeverityError and LintSeverityWarning are severity levels of lint issues.
+// Errors violate requirements of RFC-4512. Warnings are legal but suspicious.
+const (
+	LintSeverityError   LintSeverity = "error"
+	LintSeverityWarning LintSeverity = "warning"
+)
+
+// Identifiers of lint rules.
+const (
+	LintRuleAbstractSuperClass      = "abstract-superclass"
+	LintRuleStructuralSuperClass    = "structural-superclass"
+	LintRuleAuxiliarySuperClass     = "auxiliary-superclass"
+	LintRuleMissingSuperClass       = "missing-superclass"
+	LintRuleMustMayOverlap          = "must-may-overlap"
+	LintRuleMissingSupOrSyntax      = "missing-sup-or-syntax"
+	LintRuleCollectiveUsage         = "collective-usage"
+	LintRuleNoUserModificationUsage = "no-user-modification-usage"
+	LintRuleSubtypeUsage            = "subtype-usage"
+	LintRuleSubtypeCollective       = "subtype-collective"
+	LintRuleObsoleteSuperior        = "obsolete-superior"
+)
+
+const (
+	objectClassTopName       = "top"
+	objectClassTopNumericOID = "2.5.6.0"
+)
+
+// LintIssue represents a violation found by Lint.
+type LintIssue struct {
+	Severity   LintSeverity `json:"severity"`
+	Rule       string       `json:"rule"`
+	Kind       SchemaKind   `json:"kind"`
+	Source     string       `json:"source"`
+	SourceName string       `json:"source_name,omitempty"`
+	Message    string       `json:"message"`
+}
+
+func (issue *LintIssue) String() string {
+	source := issue.Source
+	if "" != issue.SourceName {
+		source = issue.Source + " (" + issue.SourceName + ")"
+	}
+	return fmt.Sprintf("%s: %s %s: %s [%s]", issue.Severity, issue.Kind, source, issue.Message, issue.Rule)
+}
+
+type schemaLinter struct {
+	store  *LDAPSchemaStore
+	issues []*LintIssue
+}
+
+func (linter *schemaLinter) report(severity LintSeverity, rule string, kind SchemaKind, source string, sourceNames []string, message string) {
+	sourceName := ""
+	if len(sourceNames) > 0 {
+		sourceName = sourceNames[0]
+	}
+	linter.issues = append(linter.issues, &LintIssue{
+		Severity:   severity,
+		Rule:       rule,
+		Kind:       kind,
+		Source:     source,
+		SourceName: sourceName,
+		Message:    message,
+	})
+}
+
+func isObjectClassTop(s *ObjectClassSchema) bool {
+	if s.NumericOID == objectClassTopNumericOID {
+		return true
+	}
+	for _, name := range s.Name {
+		if strings.EqualFold(name, objectClassTopName) {
+			return true
+		}
+	}
+	return false
+}
+
+func (linter *schemaLinter) lintObjectClass(s *ObjectClassSchema) (err error) {
+	if (0 == len(s.SuperClasses)) && !isObjectClassTop(s) {
+		linter.report(LintSeverityWarning, LintRuleMissingSuperClass, SchemaKindObjectClass, s.NumericOID, s.Name,
+			"object class has no superclass, `top` is assumed")
+	}
+	for _, superClassName := range s.SuperClasses {
+		supGenericSchema := linter.store.findObjectClassGenericSchema(superClassName)
+		if nil == supGenericSchema {
+			continue
+		}
+		sup, err := NewObjectClassSchemaViaGenericSchema(supGenericSchema)
+		if nil != err {
+			return err
+		}
+		switch s.ClassKind {
+		case ClassKindAbstract:
+			if sup.ClassKind != ClassKindAbstract {
+				linter.report(LintSeverityError, LintRuleAbstractSuperClass, SchemaKindObjectClass, s.NumericOID, s.Name,
+					fmt.Sprintf("abstract object class cannot subclass %s object class %s", sup.ClassKind, superClassName))
+			}
+		case ClassKindStructural:
+			if sup.ClassKind == ClassKindAuxiliary {
+				linter.report(LintSeverityError, LintRuleStructuralSuperClass, SchemaKindObjectClass, s.NumericOID, s.Name,
+					fmt.Sprintf("structural object class cannot subclass auxiliary object class %s", superClassName))
+			}
+		case ClassKindAuxiliary:
+			if sup.ClassKind == ClassKindStructural {
+				linter.report(LintSeverityError, LintRuleAuxiliarySuperClass, SchemaKindObjectClass, s.NumericOID, s.Name,
+					fmt.Sprintf("auxiliary object class cannot subclass structural object class %s", superClassName))
+			}
+		}
+		if sup.Obsolete && !s.Obsolete {
+			linter.report(LintSeverityWarning, LintRuleObsoleteSuperior, SchemaKindObjectClass, s.NumericOID, s.Name,
+				fmt.Sprintf("superclass %s is obsolete", superClassName))
+		}
+	}
+	for _, mustName := range s.Must {
+		for _, mayName := range s.May {
+			if linter.sameAttributeType(mustName, mayName) {
+				linter.report(LintSeverityWarning, LintRuleMustMayOverlap, SchemaKindObjectClass, s.NumericOID, s.Name,
+					fmt.Sprintf("attribute type %s is listed in both MUST and MAY", mustName))
+			}
+		}
+	}
+	return nil
+}
+
+func (linter *schemaLinter) sameAttributeType(a, b string) bool {
+	genericA := linter.store.findAttributeTypeGenericSchema(a)
+	genericB := linter.store.findAttributeTypeGenericSchema(b)
+	if (nil == genericA) || (nil == genericB) {
+		return a == b
+	}
+	return genericA == genericB
+}
+
+func (linter *schemaLinter) lintAttributeType(s *AttributeTypeSchema) (err error) {
+	if ("" == s.SuperType) && ("" == s.SyntaxOID) {
+		linter.report(LintSeverityError, LintRuleMissingSupOrSyntax, SchemaKindAttributeType, s.NumericOID, s.Name,
+			"attribute type must have at least one of SUP or SYNTAX")
+	}
+	if s.Collective && (s.Usage != AttributeUsageUserApplications) {
+		linter.report(LintSeverityError, LintRuleCollectiveUsage, SchemaKindAttributeType, s.NumericOID, s.Name,
+			"COLLECTIVE requires usage of userApplications but got "+s.Usage)
+	}
+	if s.NoUserModification && (s.Usage == AttributeUsageUserApplications) {
+		linter.report(LintSeverityError, LintRuleNoUserModificationUsage, SchemaKindAttributeType, s.NumericOID, s.Name,
+			"NO-USER-MODIFICATION requires an operational usage")
+	}
+	if "" == s.SuperType {
+		return nil
+	}
+	supGenericSchema := linter.store.findAttributeTypeGenericSchema(s.SuperType)
+	if nil == supGenericSchema {
+		return nil
+	}
+	sup, err := NewAttributeTypeSchemaViaGenericSchema(supGenericSchema)
+	if nil != err {
+		return err
+	}
+	if sup.Usage != s.Usage {
+		linter.report(LintSeverityError, LintRuleSubtypeUsage, SchemaKindAttributeType, s.NumericOID, s.Name,
+			fmt.Sprintf("usage %s differs from usage %s of supertype %s", s.Usage, sup.Usage, s.SuperType))
+	}
+	if sup.Collective && !s.Collective {
+		linter.report(LintSeverityError, LintRuleSubtypeCollective, SchemaKindAttributeType, s.NumericOID, s.Name,
+			fmt.Sprintf("subtype of collective attribute type %s must be collective", s.SuperType))
+	}
+	if sup.Obsolete && !s.Obsolete {
+		linter.report(LintSeverityWarning, LintRuleObsoleteSuperior, SchemaKindAttributeType, s.NumericOID, s.Name,
+			fmt.Sprintf("supertype %s is obsolete", s.SuperType))
+	}
+	return nil
+}
+
+// Lint checks object classes and attribute types in store against semantic
+// rules of RFC-4512. References which cannot be resolved are skipped here,
+// use Validate to find them.
+func (store *LDAPSchemaStore) Lint() (issues []*LintIssue, err error) {
+	linter := &schemaLinter{
+		store: store,
+	}
+	attributeTypeSchemas, err := store.makeOIDOrderedAttributeTypeSchemas()
+	if nil != err {
+		return
+	}
+	for _, attributeTypeSchema := range attributeTypeSchemas {
+		if err = linter.lintAttributeType(attributeTypeSchema); nil != err {
+			return nil, err
+		}
+	}
+	objectClassSchemas, err := store.makeOIDOrderedObjectClassSchemas()
+	if nil != err {
+		return
+	}
+	for _, objectClassSchema := range objectClassSchemas {
+		if err = linter.lintObjectClass(objectClassSchema); nil != err {
+			return nil, err
+		}
+	}
+	return linter.issues, nil
+}
@@ -0,0 +1,65 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func TestLDAPSchemaStoreLint_1(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleNoSyntax' )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleCollective' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 COLLECTIVE USAGE dSAOperation )",
+		"( 1.3.6.1.4.1.99999.1.3 NAME 'sampleReadOnly' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 NO-USER-MODIFICATION )",
+		"( 1.3.6.1.4.1.99999.1.4 NAME 'sampleOperational' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 USAGE directoryOperation )",
+		"( 1.3.6.1.4.1.99999.1.5 NAME 'sampleSubtype' SUP sampleOperational )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 2.5.6.0 NAME 'top' ABSTRACT MUST objectClass )",
+		"( 1.3.6.1.4.1.99999.2.1 NAME 'samplePerson' SUP top STRUCTURAL MUST sampleSubtype MAY sampleSubtype )",
+		"( 1.3.6.1.4.1.99999.2.2 NAME 'sampleMixin' SUP samplePerson AUXILIARY )",
+		"( 1.3.6.1.4.1.99999.2.3 NAME 'sampleOrphan' AUXILIARY )",
+	} {
+		if err := store.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	issues, err := store.Lint()
+	if nil != err {
+		t.Fatalf("failed on lint: %v", err)
+	}
+	expects := []string{
+		"error: attribute-type 1.3.6.1.4.1.99999.1.1 (sampleNoSyntax): attribute type must have at least one of SUP or SYNTAX [missing-sup-or-syntax]",
+		"error: attribute-type 1.3.6.1.4.1.99999.1.2 (sampleCollective): COLLECTIVE requires usage of userApplications but got dSAOperation [collective-usage]",
+		"error: attribute-type 1.3.6.1.4.1.99999.1.3 (sampleReadOnly): NO-USER-MODIFICATION requires an operational usage [no-user-modification-usage]",
+		"error: attribute-type 1.3.6.1.4.1.99999.1.5 (sampleSubtype): usage userApplications differs from usage directoryOperation of supertype sampleOperational [subtype-usage]",
+		"warning: object-class 1.3.6.1.4.1.99999.2.1 (samplePerson): attribute type sampleSubtype is listed in both MUST and MAY [must-may-overlap]",
+		"error: object-class 1.3.6.1.4.1.99999.2.2 (sampleMixin): auxiliary object class cannot subclass structural object class samplePerson [auxiliary-superclass]",
+		"warning: object-class 1.3.6.1.4.1.99999.2.3 (sampleOrphan): object class has no superclass, `top` is assumed [missing-superclass]",
+	}
+	if len(issues) != len(expects) {
+		t.Fatalf("expecting %d issues but have %d: %v", len(expects), len(issues), issues)
+	}
+	for idx, expect := range expects {
+		if v := issues[idx].String(); v != expect {
+			t.Errorf("expecting issue %d: %v but have %v", idx, expect, v)
+		}
+	}
+}
+
+func TestLDAPSchemaStoreLint_2(t *testing.T) {
+	store := makeOpenLDAPConfigSampleStore(t)
+	store.AddObjectClassSchemaText("( 2.5.6.0 NAME 'top' ABSTRACT )")
+	issues, err := store.Lint()
+	if nil != err {
+		t.Fatalf("failed on lint: %v", err)
+	}
+	for _, issue := range issues {
+		if issue.Severity == LintSeverityError {
+			t.Errorf("unexpected lint error: %v", issue)
+		}
+	}
+}
@@ -2,12 +2,12 @@ package ldapschemaparser
 
 // MatchingRuleSchema represent schema of matching rule
 type MatchingRuleSchema struct {
-	NumericOID  string
-	Name        []string
-	Description string
-	Obsolete    bool
-	Syntax      string
-	Extensions  map[string][]string
+	NumericOID  string              `json:"oid"`
+	Name        []string            `json:"name,omitempty"`
+	Description string              `json:"desc,omitempty"`
+	Obsolete    bool                `json:"obsolete,omitempty"`
+	Syntax      string              `json:"syntax,omitempty"`
+	Extensions  map[string][]string `json:"extensions,omitempty"`
 }
 
 // NewMatchingRuleSchemaViaGenericSchema creates matching rule schema instance from GenericSchema
@@ -0,0 +1,571 @@
+package ldapschemaparser
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MatchingRuleUsage indicates the kind of assertion a matching rule evaluates
+type MatchingRuleUsage string
+
+// MatchingRuleEquality, MatchingRuleOrdering and MatchingRuleSubstrings are
+// usages of matching rules.
+const (
+	MatchingRuleEquality   MatchingRuleUsage = "equality"
+	MatchingRuleOrdering   MatchingRuleUsage = "ordering"
+	MatchingRuleSubstrings MatchingRuleUsage = "substrings"
+)
+
+// MatchingRuleEvaluator evaluates assertions with semantics of a matching rule.
+type MatchingRuleEvaluator struct {
+	NumericOID string
+	Name       string
+	Usage      MatchingRuleUsage
+
+	// Normalize converts attribute value or assertion value into the form
+	// for comparison. Error is returned for value not fitting the rule.
+	Normalize func(value string) (string, error)
+
+	// NormalizeSubstring converts component of substring assertion.
+	// Normalize is used when nil.
+	NormalizeSubstring func(value string) (string, error)
+
+	// Compare orders normalized values. strings.Compare is used when nil.
+	Compare func(a, b string) int
+
+	// Match check if normalized attribute value matches normalized
+	// assertion value of equality rule. Normalized values are compared
+	// for equality when nil.
+	Match func(attributeValue, assertionValue string) bool
+}
+
+// SubstringAssertion is the assertion of substrings matching rules.
+type SubstringAssertion struct {
+	Initial string
+	Any     []string
+	Final   string
+}
+
+func (assertion *SubstringAssertion) String() string {
+	return assertion.Initial + "*" + strings.Join(append(append([]string{}, assertion.Any...), assertion.Final), "*")
+}
+
+// ErrMatchingRuleUsage indicates matching rule cannot evaluate requested assertion.
+type ErrMatchingRuleUsage struct {
+	Rule  string
+	Usage MatchingRuleUsage
+}
+
+func (usageError *ErrMatchingRuleUsage) Error() string {
+	return fmt.Sprintf("matching rule %s cannot evaluate %s assertion", usageError.Rule, usageError.Usage)
+}
+
+func (rule *MatchingRuleEvaluator) normalize(value string) (string, error) {
+	if nil == rule.Normalize {
+		return value, nil
+	}
+	return rule.Normalize(value)
+}
+
+func (rule *MatchingRuleEvaluator) normalizeSubstring(value string) (string, error) {
+	if nil != rule.NormalizeSubstring {
+		return rule.NormalizeSubstring(value)
+	}
+	return rule.normalize(value)
+}
+
+// Equal check if attribute value equals to assertion value.
+func (rule *MatchingRuleEvaluator) Equal(attributeValue, assertionValue string) (result bool, err error) {
+	if rule.Usage != MatchingRuleEquality {
+		return false, &ErrMatchingRuleUsage{Rule: rule.Name, Usage: MatchingRuleEquality}
+	}
+	a, err := rule.normalize(attributeValue)
+	if nil != err {
+		return
+	}
+	b, err := rule.normalize(assertionValue)
+	if nil != err {
+		return
+	}
+	if nil != rule.Match {
+		return rule.Match(a, b), nil
+	}
+	return a == b, nil
+}
+
+// CompareValues orders attribute value against assertion value. Result is
+// negative when attribute value is less than assertion value, zero when
+// equal and positive when greater.
+func (rule *MatchingRuleEvaluator) CompareValues(attributeValue, assertionValue string) (result int, err error) {
+	if rule.Usage != MatchingRuleOrdering {
+		return 0, &ErrMatchingRuleUsage{Rule: rule.Name, Usage: MatchingRuleOrdering}
+	}
+	a, err := rule.normalize(attributeValue)
+	if nil != err {
+		return
+	}
+	b, err := rule.normalize(assertionValue)
+	if nil != err {
+		return
+	}
+	if nil != rule.Compare {
+		return rule.Compare(a, b), nil
+	}
+	return strings.Compare(a, b), nil
+}
+
+// MatchSubstrings check if attribute value matches given substring assertion.
+func (rule *MatchingRuleEvaluator) MatchSubstrings(attributeValue string, assertion *SubstringAssertion) (result bool, err error) {
+	if rule.Usage != MatchingRuleSubstrings {
+		return false, &ErrMatchingRuleUsage{Rule: rule.Name, Usage: MatchingRuleSubstrings}
+	}
+	value, err := rule.normalize(attributeValue)
+	if nil != err {
+		return
+	}
+	if "" != assertion.Initial {
+		initial, err := rule.normalizeSubstring(assertion.Initial)
+		if nil != err {
+			return false, err
+		}
+		initial = strings.TrimLeft(initial, " ")
+		if !strings.HasPrefix(value, initial) {
+			return false, nil
+		}
+		value = value[len(initial):]
+	}
+	final := ""
+	if "" != assertion.Final {
+		if final, err = rule.normalizeSubstring(assertion.Final); nil != err {
+			return
+		}
+		final = strings.TrimRight(final, " ")
+	}
+	for _, anyValue := range assertion.Any {
+		component, err := rule.normalizeSubstring(anyValue)
+		if nil != err {
+			return false, err
+		}
+		idx := strings.Index(value, component)
+		if idx < 0 {
+			return false, nil
+		}
+		value = value[idx+len(component):]
+	}
+	return strings.HasSuffix(value, final), nil
+}
+
+// MatchingRuleEngine holds matching rule evaluators keyed by numeric OID and name.
+type MatchingRuleEngine struct {
+	rules     map[string]*MatchingRuleEvaluator
+	nameIndex map[string]*MatchingRuleEvaluator
+}
+
+// NewMatchingRuleEngine create an empty instance of MatchingRuleEngine.
+func NewMatchingRuleEngine() *MatchingRuleEngine {
+	return &MatchingRuleEngine{
+		rules:     make(map[string]*MatchingRuleEvaluator),
+		nameIndex: make(map[string]*MatchingRuleEvaluator),
+	}
+}
+
+// NewBuiltinMatchingRuleEngine create an instance of MatchingRuleEngine
+// with matching rules of RFC-4517 and RFC-4530.
+func NewBuiltinMatchingRuleEngine() *MatchingRuleEngine {
+	engine := NewMatchingRuleEngine()
+	for _, rule := range builtinMatchingRules() {
+		engine.Register(rule)
+	}
+	return engine
+}
+
+// DefaultMatchingRuleEngine is used by stores without their own engine.
+var DefaultMatchingRuleEngine = NewBuiltinMatchingRuleEngine()
+
+// Register add given evaluator. Existed evaluator of the same OID or name is replaced.
+func (engine *MatchingRuleEngine) Register(rule *MatchingRuleEvaluator) {
+	engine.rules[rule.NumericOID] = rule
+	if "" != rule.Name {
+		engine.nameIndex[strings.ToLower(rule.Name)] = rule
+	}
+}
+
+// Lookup return evaluator of given numeric OID or name, or nil if not found.
+func (engine *MatchingRuleEngine) Lookup(identifier string) *MatchingRuleEvaluator {
+	if rule := engine.rules[identifier]; nil != rule {
+		return rule
+	}
+	return engine.nameIndex[strings.ToLower(identifier)]
+}
+
+// AttributeMatchingRules are evaluators picked for an attribute type.
+// Fields are nil when attribute type has no such matching rule or the
+// rule is not known by engine.
+type AttributeMatchingRules struct {
+	Equality   *MatchingRuleEvaluator
+	Ordering   *MatchingRuleEvaluator
+	Substrings *MatchingRuleEvaluator
+}
+
+// SetMatchingRuleEngine set engine used by AttributeMatchingRules.
+// DefaultMatchingRuleEngine is used when engine is nil.
+func (store *LDAPSchemaStore) SetMatchingRuleEngine(engine *MatchingRuleEngine) {
+	store.matchingRuleEngine = engine
+}
+
+func (store *LDAPSchemaStore) currentMatchingRuleEngine() *MatchingRuleEngine {
+	if nil == store.matchingRuleEngine {
+		return DefaultMatchingRuleEngine
+	}
+	return store.matchingRuleEngine
+}
+
+func (store *LDAPSchemaStore) lookupMatchingRuleEvaluator(identifier string) *MatchingRuleEvaluator {
+	if "" == identifier {
+		return nil
+	}
+	engine := store.currentMatchingRuleEngine()
+	if rule := engine.Lookup(identifier); nil != rule {
+		return rule
+	}
+	if genericSchema := store.findMatchingRuleGenericSchema(identifier); nil != genericSchema {
+		return engine.Lookup(genericSchema.NumericOID)
+	}
+	return nil
+}
+
+// AttributeMatchingRules picks evaluators from EQUALITY, ORDERING and
+// SUBSTR of attribute type of given name or numeric OID. Matching rules
+// are inherited through SUP chain.
+func (store *LDAPSchemaStore) AttributeMatchingRules(attributeTypeName string) (rules *AttributeMatchingRules, err error) {
+	effective, err := store.EffectiveAttributeType(attributeTypeName)
+	if nil != err {
+		return
+	}
+	return &AttributeMatchingRules{
+		Equality:   store.lookupMatchingRuleEvaluator(effective.Equality.Value),
+		Ordering:   store.lookupMatchingRuleEvaluator(effective.Ordering.Value),
+		Substrings: store.lookupMatchingRuleEvaluator(effective.SubString.Value),
+	}, nil
+}
+
+func normalizeCaseIgnoreString(value string) (string, error) {
+	return prepareString(value, true)
+}
+
+func normalizeCaseExactString(value string) (string, error) {
+	return prepareString(value, false)
+}
+
+func normalizeCaseIgnoreSubstring(value string) (string, error) {
+	return prepareSubstring(value, true)
+}
+
+func normalizeCaseExactSubstring(value string) (string, error) {
+	return prepareSubstring(value, false)
+}
+
+func normalizeIA5String(caseFold bool) func(value string) (string, error) {
+	return func(value string) (string, error) {
+		if err := validateIA5String(value); nil != err {
+			return "", err
+		}
+		return prepareString(value, caseFold)
+	}
+}
+
+func normalizeIA5Substring(value string) (string, error) {
+	if err := validateIA5String(value); nil != err {
+		return "", err
+	}
+	return prepareSubstring(value, true)
+}
+
+func normalizeNumericString(value string) (string, error) {
+	result, err := prepareStringCharacters(value, false)
+	if nil != err {
+		return "", err
+	}
+	return removeCharacters(result, func(ch rune) bool { return ch == ' ' }), nil
+}
+
+func normalizeTelephoneNumber(value string) (string, error) {
+	result, err := prepareStringCharacters(value, true)
+	if nil != err {
+		return "", err
+	}
+	return removeCharacters(result, isTelephoneNumberInsignificant), nil
+}
+
+// normalizeCaseIgnoreList prepares each line of postal address and joins
+// them with NUL which is mapped to nothing in preparation of lines.
+func normalizeCaseIgnoreList(separator string) func(value string) (string, error) {
+	return func(value string) (string, error) {
+		lines := strings.Split(value, "$")
+		for idx, line := range lines {
+			line = strings.Replace(strings.Replace(line, "\\24", "$", -1), "\\5C", "\\", -1)
+			line = strings.Replace(line, "\\5c", "\\", -1)
+			prepared, err := prepareString(line, true)
+			if nil != err {
+				return "", err
+			}
+			lines[idx] = prepared
+		}
+		return strings.Join(lines, separator), nil
+	}
+}
+
+func normalizeOctetString(value string) (string, error) {
+	return value, nil
+}
+
+func normalizeBoolean(value string) (string, error) {
+	return value, validateBoolean(value)
+}
+
+func normalizeBitString(value string) (string, error) {
+	return value, validateBitString(value)
+}
+
+func normalizeUUID(value string) (string, error) {
+	if err := validateUUID(value); nil != err {
+		return "", err
+	}
+	return strings.ToLower(value), nil
+}
+
+func normalizeInteger(value string) (string, error) {
+	if err := validateInteger(value); nil != err {
+		return "", err
+	}
+	return value, nil
+}
+
+func compareInteger(a, b string) int {
+	x, _ := new(big.Int).SetString(a, 10)
+	y, _ := new(big.Int).SetString(b, 10)
+	return x.Cmp(y)
+}
+
+// normalizeObjectIdentifier keeps numeric OID and lower-cases descriptor.
+// Descriptors are not resolved into numeric OID.
+func normalizeObjectIdentifier(value string) (string, error) {
+	if err := validateOID(value); nil != err {
+		return "", err
+	}
+	if isNumericOIDValue(value) {
+		return value, nil
+	}
+	return strings.ToLower(value), nil
+}
+
+// normalizeFirstComponent takes numeric OID or rule ID of schema
+// description, or the value as is when not a description.
+func normalizeFirstComponent(normalize func(value string) (string, error)) func(value string) (string, error) {
+	return func(value string) (string, error) {
+		if strings.HasPrefix(strings.TrimSpace(value), "(") {
+			genericSchema, err := Parse(value)
+			if nil != err {
+				return "", err
+			}
+			value = genericSchema.NumericOID
+		}
+		return normalize(value)
+	}
+}
+
+// normalizeDirectoryStringFirstComponent normalizes the first component of
+// GSER encoded SEQUENCE value with caseIgnoreMatch. Value not starting with
+// brace is taken as the assertion value itself.
+func normalizeDirectoryStringFirstComponent(value string) (string, error) {
+	if !strings.HasPrefix(value, "{") {
+		return normalizeCaseIgnoreString(value)
+	}
+	parsed, err := parseGSERValue(value)
+	if nil != err {
+		return "", err
+	}
+	if (gserSequence != parsed.kind) || (0 == len(parsed.components)) {
+		return "", errors.New("expecting SEQUENCE value with components")
+	}
+	first := parsed.components[0].value
+	if gserString != first.kind {
+		return "", errors.New("expecting string value of first component " + parsed.components[0].identifier)
+	}
+	return normalizeCaseIgnoreString(first.text)
+}
+
+// matchWordIn makes Match function which checks if assertion value equals
+// to any word of attribute value. Words are separated with characters
+// accepted by isSeparator.
+func matchWordIn(isSeparator func(ch rune) bool) func(attributeValue, assertionValue string) bool {
+	return func(attributeValue, assertionValue string) bool {
+		for _, word := range strings.FieldsFunc(attributeValue, isSeparator) {
+			if word == assertionValue {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// isWordSeparator separates words of wordMatch with spaces.
+func isWordSeparator(ch rune) bool {
+	return ch == ' '
+}
+
+// isKeywordSeparator separates keywords of keywordMatch with spaces and
+// punctuation.
+func isKeywordSeparator(ch rune) bool {
+	return (ch == ' ') || unicode.IsPunct(ch)
+}
+
+// generalizedTimeLayout is the canonical form of normalized generalized
+// time which orders in the same way as the time.
+const generalizedTimeLayout = "20060102150405.000000000Z"
+
+func parseGeneralizedTime(value string) (result time.Time, err error) {
+	if err = validateGeneralizedTime(value); nil != err {
+		return
+	}
+	digits := 0
+	for (digits < len(value)) && isDigitByte(value[digits]) {
+		digits++
+	}
+	offset := digits
+	var fraction float64
+	if (offset < len(value)) && ((value[offset] == '.') || (value[offset] == ',')) {
+		start := offset + 1
+		offset = start
+		for (offset < len(value)) && isDigitByte(value[offset]) {
+			offset++
+		}
+		fraction, _ = strconv.ParseFloat("0."+value[start:offset], 64)
+	}
+	n := func(from, width int) int {
+		v := 0
+		for idx := from; idx < from+width; idx++ {
+			v = v*10 + int(value[idx]-'0')
+		}
+		return v
+	}
+	minute, second := 0, 0
+	unit := time.Hour
+	if digits >= 12 {
+		minute = n(10, 2)
+		unit = time.Minute
+	}
+	if digits >= 14 {
+		second = n(12, 2)
+		unit = time.Second
+	}
+	location := time.UTC
+	if zone := value[offset:]; "Z" != zone {
+		sign := 1
+		if zone[0] == '-' {
+			sign = -1
+		}
+		zoneOffset := n(offset+1, 2) * 3600
+		if len(zone) == 5 {
+			zoneOffset += n(offset+3, 2) * 60
+		}
+		location = time.FixedZone(zone, sign*zoneOffset)
+	}
+	result = time.Date(n(0, 4), time.Month(n(4, 2)), n(6, 2), n(8, 2), minute, second, 0, location)
+	result = result.Add(time.Duration(fraction * float64(unit)))
+	return result.UTC(), nil
+}
+
+func normalizeGeneralizedTime(value string) (string, error) {
+	t, err := parseGeneralizedTime(value)
+	if nil != err {
+		return "", err
+	}
+	return t.Format(generalizedTimeLayout), nil
+}
+
+// normalizeDistinguishedName prepares attribute types and values of each
+// RDN with caseIgnoreMatch and sorts values of multi-valued RDN.
+// Attribute type names are not resolved into numeric OID.
+func normalizeDistinguishedName(value string) (string, error) {
+	rdns, err := ParseDN(value)
+	if nil != err {
+		return "", err
+	}
+	rdnTexts := make([]string, 0, len(rdns))
+	for _, rdn := range rdns {
+		avaTexts := make([]string, 0, len(rdn))
+		for _, ava := range rdn {
+			v := ava.Value
+			if ava.HexEncoded {
+				v = "#" + fmt.Sprintf("%x", ava.Value)
+			} else if v, err = prepareString(v, true); nil != err {
+				return "", err
+			}
+			avaTexts = append(avaTexts, strings.ToLower(ava.Type)+"="+v)
+		}
+		sort.Strings(avaTexts)
+		rdnTexts = append(rdnTexts, strings.Join(avaTexts, "\x00+"))
+	}
+	return strings.Join(rdnTexts, "\x00,"), nil
+}
+
+func normalizeNameAndOptionalUID(value string) (string, error) {
+	uid := ""
+	if idx := strings.LastIndex(value, "#'"); (idx >= 0) && strings.HasSuffix(value, "'B") {
+		if nil == validateBitString(value[idx+1:]) {
+			uid = value[idx:]
+			value = value[:idx]
+		}
+	}
+	dn, err := normalizeDistinguishedName(value)
+	if nil != err {
+		return "", err
+	}
+	return dn + uid, nil
+}
+
+func builtinMatchingRules() []*MatchingRuleEvaluator {
+	return []*MatchingRuleEvaluator{
+		{NumericOID: "2.5.13.0", Name: "objectIdentifierMatch", Usage: MatchingRuleEquality, Normalize: normalizeObjectIdentifier},
+		{NumericOID: "2.5.13.1", Name: "distinguishedNameMatch", Usage: MatchingRuleEquality, Normalize: normalizeDistinguishedName},
+		{NumericOID: "2.5.13.2", Name: "caseIgnoreMatch", Usage: MatchingRuleEquality, Normalize: normalizeCaseIgnoreString},
+		{NumericOID: "2.5.13.3", Name: "caseIgnoreOrderingMatch", Usage: MatchingRuleOrdering, Normalize: normalizeCaseIgnoreString},
+		{NumericOID: "2.5.13.4", Name: "caseIgnoreSubstringsMatch", Usage: MatchingRuleSubstrings, Normalize: normalizeCaseIgnoreString, NormalizeSubstring: normalizeCaseIgnoreSubstring},
+		{NumericOID: "2.5.13.5", Name: "caseExactMatch", Usage: MatchingRuleEquality, Normalize: normalizeCaseExactString},
+		{NumericOID: "2.5.13.6", Name: "caseExactOrderingMatch", Usage: MatchingRuleOrdering, Normalize: normalizeCaseExactString},
+		{NumericOID: "2.5.13.7", Name: "caseExactSubstringsMatch", Usage: MatchingRuleSubstrings, Normalize: normalizeCaseExactString, NormalizeSubstring: normalizeCaseExactSubstring},
+		{NumericOID: "2.5.13.8", Name: "numericStringMatch", Usage: MatchingRuleEquality, Normalize: normalizeNumericString},
+		{NumericOID: "2.5.13.9", Name: "numericStringOrderingMatch", Usage: MatchingRuleOrdering, Normalize: normalizeNumericString},
+		{NumericOID: "2.5.13.10", Name: "numericStringSubstringsMatch", Usage: MatchingRuleSubstrings, Normalize: normalizeNumericString},
+		{NumericOID: "2.5.13.11", Name: "caseIgnoreListMatch", Usage: MatchingRuleEquality, Normalize: normalizeCaseIgnoreList("\x00")},
+		{NumericOID: "2.5.13.12", Name: "caseIgnoreListSubstringsMatch", Usage: MatchingRuleSubstrings, Normalize: normalizeCaseIgnoreList(" "), NormalizeSubstring: normalizeCaseIgnoreSubstring},
+		{NumericOID: "2.5.13.13", Name: "booleanMatch", Usage: MatchingRuleEquality, Normalize: normalizeBoolean},
+		{NumericOID: "2.5.13.14", Name: "integerMatch", Usage: MatchingRuleEquality, Normalize: normalizeInteger},
+		{NumericOID: "2.5.13.15", Name: "integerOrderingMatch", Usage: MatchingRuleOrdering, Normalize: normalizeInteger, Compare: compareInteger},
+		{NumericOID: "2.5.13.16", Name: "bitStringMatch", Usage: MatchingRuleEquality, Normalize: normalizeBitString},
+		{NumericOID: "2.5.13.17", Name: "octetStringMatch", Usage: MatchingRuleEquality, Normalize: normalizeOctetString},
+		{NumericOID: "2.5.13.18", Name: "octetStringOrderingMatch", Usage: MatchingRuleOrdering, Normalize: normalizeOctetString},
+		{NumericOID: "2.5.13.20", Name: "telephoneNumberMatch", Usage: MatchingRuleEquality, Normalize: normalizeTelephoneNumber},
+		{NumericOID: "2.5.13.21", Name: "telephoneNumberSubstringsMatch", Usage: MatchingRuleSubstrings, Normalize: normalizeTelephoneNumber},
+		{NumericOID: "2.5.13.23", Name: "uniqueMemberMatch", Usage: MatchingRuleEquality, Normalize: normalizeNameAndOptionalUID},
+		{NumericOID: "2.5.13.27", Name: "generalizedTimeMatch", Usage: MatchingRuleEquality, Normalize: normalizeGeneralizedTime},
+		{NumericOID: "2.5.13.28", Name: "generalizedTimeOrderingMatch", Usage: MatchingRuleOrdering, Normalize: normalizeGeneralizedTime},
+		{NumericOID: "2.5.13.29", Name: "integerFirstComponentMatch", Usage: MatchingRuleEquality, Normalize: normalizeFirstComponent(normalizeInteger)},
+		{NumericOID: "2.5.13.30", Name: "objectIdentifierFirstComponentMatch", Usage: MatchingRuleEquality, Normalize: normalizeFirstComponent(normalizeObjectIdentifier)},
+		{NumericOID: "2.5.13.31", Name: "directoryStringFirstComponentMatch", Usage: MatchingRuleEquality, Normalize: normalizeDirectoryStringFirstComponent},
+		{NumericOID: "2.5.13.32", Name: "wordMatch", Usage: MatchingRuleEquality, Normalize: normalizeCaseIgnoreString, Match: matchWordIn(isWordSeparator)},
+		{NumericOID: "2.5.13.33", Name: "keywordMatch", Usage: MatchingRuleEquality, Normalize: normalizeCaseIgnoreString, Match: matchWordIn(isKeywordSeparator)},
+		{NumericOID: "1.3.6.1.4.1.1466.109.114.1", Name: "caseExactIA5Match", Usage: MatchingRuleEquality, Normalize: normalizeIA5String(false)},
+		{NumericOID: "1.3.6.1.4.1.1466.109.114.2", Name: "caseIgnoreIA5Match", Usage: MatchingRuleEquality, Normalize: normalizeIA5String(true)},
+		{NumericOID: "1.3.6.1.4.1.1466.109.114.3", Name: "caseIgnoreIA5SubstringsMatch", Usage: MatchingRuleSubstrings, Normalize: normalizeIA5String(true), NormalizeSubstring: normalizeIA5Substring},
+		{NumericOID: "1.3.6.1.1.16.2", Name: "uuidMatch", Usage: MatchingRuleEquality, Normalize: normalizeUUID},
+		{NumericOID: "1.3.6.1.1.16.3", Name: "uuidOrderingMatch", Usage: MatchingRuleOrdering, Normalize: normalizeUUID},
+	}
+}
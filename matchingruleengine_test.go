@@ -0,0 +1,173 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func TestMatchingRuleEngineEquality_1(t *testing.T) {
+	engine := NewBuiltinMatchingRuleEngine()
+	testCases := []struct {
+		rule           string
+		attributeValue string
+		assertionValue string
+		expect         bool
+	}{
+		{"caseIgnoreMatch", "  Hello   World ", "hello world", true},
+		{"caseIgnoreMatch", "Hel­lo", "HELLO", true},
+		{"caseIgnoreMatch", "Hello", "Hallo", false},
+		{"2.5.13.5", "Hello World", "hello world", false},
+		{"caseExactMatch", "Hello  World", "Hello World", true},
+		{"caseIgnoreIA5Match", "User@Example.NET", "user@example.net", true},
+		{"caseExactIA5Match", "User@Example.NET", "user@example.net", false},
+		{"octetStringMatch", "abc", "abc", true},
+		{"octetStringMatch", "abc", "ABC", false},
+		{"integerMatch", "-123", "-123", true},
+		{"integerMatch", "123", "124", false},
+		{"numericStringMatch", "1 234 5", "12345", true},
+		{"telephoneNumberMatch", "+1 512-315-0280", "+15123150280", true},
+		{"distinguishedNameMatch", "CN=Alice  Smith+UID=as,DC=Example,DC=net", "uid=AS+cn=alice smith,dc=example,dc=net", true},
+		{"distinguishedNameMatch", "CN=a\\,b,DC=net", "cn=A\\2cB,dc=net", true},
+		{"distinguishedNameMatch", "CN=a,DC=net", "CN=a,DC=org", false},
+		{"uniqueMemberMatch", "CN=a,DC=net#'01'B", "cn=A,dc=net#'01'B", true},
+		{"generalizedTimeMatch", "199412161032Z", "199412160532-0500", true},
+		{"generalizedTimeMatch", "1994121610.5Z", "19941216103000Z", true},
+		{"booleanMatch", "TRUE", "TRUE", true},
+		{"bitStringMatch", "'0101'B", "'0101'B", true},
+		{"objectIdentifierMatch", "Person", "person", true},
+		{"objectIdentifierFirstComponentMatch", "( 2.5.6.6 NAME 'person' )", "2.5.6.6", true},
+		{"integerFirstComponentMatch", "( 3 NAME 'sampleRule' FORM sampleForm )", "3", true},
+		{"caseIgnoreListMatch", "1 Main St.$Anytown", "1 MAIN ST.$ANYTOWN", true},
+		{"uuidMatch", "597AE2F6-16A6-1027-98F4-D28B5365DC14", "597ae2f6-16a6-1027-98f4-d28b5365dc14", true},
+		{"caseIgnoreMatch", "Caf\u00e9", "CAFE\u0301", true},
+		{"caseExactMatch", "Caf\u00e9", "Cafe\u0301", true},
+		{"caseExactMatch", "Caf\u00e9", "CAFE\u0301", false},
+		{"caseIgnoreMatch", "\uff21\uff22\uff23", "abc", true},
+		{"caseIgnoreMatch", "Stra\u00dfe", "STRASSE", true},
+		{"directoryStringFirstComponentMatch", "{ name \"Alice  Smith\", id 3 }", "alice smith", true},
+		{"directoryStringFirstComponentMatch", "{ name \"Alice\" }", "Bob", false},
+		{"wordMatch", "The Quick  Brown Fox", "quick", true},
+		{"wordMatch", "The Quick, Brown Fox", "quick", false},
+		{"wordMatch", "The Quick Brown Fox", "qui", false},
+		{"keywordMatch", "The Quick, Brown Fox.", "quick", true},
+		{"keywordMatch", "The Quick, Brown Fox.", "fox", true},
+		{"keywordMatch", "The Quick, Brown Fox.", "dog", false},
+	}
+	for _, testCase := range testCases {
+		rule := engine.Lookup(testCase.rule)
+		if nil == rule {
+			t.Errorf("cannot find matching rule %s", testCase.rule)
+			continue
+		}
+		result, err := rule.Equal(testCase.attributeValue, testCase.assertionValue)
+		if nil != err {
+			t.Errorf("failed on %s(%q, %q): %v", testCase.rule, testCase.attributeValue, testCase.assertionValue, err)
+		} else if result != testCase.expect {
+			t.Errorf("expecting %s(%q, %q) = %v", testCase.rule, testCase.attributeValue, testCase.assertionValue, testCase.expect)
+		}
+	}
+	if _, err := engine.Lookup("integerMatch").Equal("12a", "12"); nil == err {
+		t.Error("expecting error for invalid integer")
+	}
+	if _, err := engine.Lookup("caseIgnoreMatch").CompareValues("a", "b"); nil == err {
+		t.Error("expecting error for ordering with equality rule")
+	}
+}
+
+func TestMatchingRuleEngineOrdering_1(t *testing.T) {
+	engine := NewBuiltinMatchingRuleEngine()
+	testCases := []struct {
+		rule           string
+		attributeValue string
+		assertionValue string
+		expect         int
+	}{
+		{"integerOrderingMatch", "-5", "3", -1},
+		{"integerOrderingMatch", "100", "99", 1},
+		{"integerOrderingMatch", "123456789012345678901234567890", "123456789012345678901234567890", 0},
+		{"generalizedTimeOrderingMatch", "199412161032Z", "199412161033Z", -1},
+		{"generalizedTimeOrderingMatch", "199412161032+0100", "199412160932Z", 0},
+		{"generalizedTimeOrderingMatch", "20200101000000Z", "19991231235959Z", 1},
+		{"caseIgnoreOrderingMatch", "apple", "BANANA", -1},
+		{"caseExactOrderingMatch", "apple", "BANANA", 1},
+		{"numericStringOrderingMatch", "1 2", "13", -1},
+	}
+	for _, testCase := range testCases {
+		result, err := engine.Lookup(testCase.rule).CompareValues(testCase.attributeValue, testCase.assertionValue)
+		if nil != err {
+			t.Errorf("failed on %s(%q, %q): %v", testCase.rule, testCase.attributeValue, testCase.assertionValue, err)
+			continue
+		}
+		if result < 0 {
+			result = -1
+		} else if result > 0 {
+			result = 1
+		}
+		if result != testCase.expect {
+			t.Errorf("expecting %s(%q, %q) = %d but have %d", testCase.rule, testCase.attributeValue, testCase.assertionValue, testCase.expect, result)
+		}
+	}
+}
+
+func TestMatchingRuleEngineSubstrings_1(t *testing.T) {
+	engine := NewBuiltinMatchingRuleEngine()
+	testCases := []struct {
+		rule           string
+		attributeValue string
+		assertion      SubstringAssertion
+		expect         bool
+	}{
+		{"caseIgnoreSubstringsMatch", "Alice  Smith", SubstringAssertion{Initial: "ali", Final: "SMITH"}, true},
+		{"caseIgnoreSubstringsMatch", "Alice Smith", SubstringAssertion{Any: []string{"ce  s"}}, true},
+		{"caseIgnoreSubstringsMatch", "Alice Smith", SubstringAssertion{Initial: "smith"}, false},
+		{"caseIgnoreSubstringsMatch", "abcabc", SubstringAssertion{Initial: "a", Any: []string{"c", "b"}, Final: "c"}, true},
+		{"caseIgnoreSubstringsMatch", "abc", SubstringAssertion{Initial: "ab", Final: "bc"}, false},
+		{"caseExactSubstringsMatch", "Alice", SubstringAssertion{Initial: "ali"}, false},
+		{"telephoneNumberSubstringsMatch", "+1 512-315-0280", SubstringAssertion{Any: []string{"5123"}}, true},
+		{"numericStringSubstringsMatch", "1 234 5", SubstringAssertion{Final: "45"}, true},
+		{"caseIgnoreIA5SubstringsMatch", "User@Example.NET", SubstringAssertion{Final: "@example.net"}, true},
+	}
+	for _, testCase := range testCases {
+		result, err := engine.Lookup(testCase.rule).MatchSubstrings(testCase.attributeValue, &testCase.assertion)
+		if nil != err {
+			t.Errorf("failed on %s(%q, %v): %v", testCase.rule, testCase.attributeValue, testCase.assertion.String(), err)
+		} else if result != testCase.expect {
+			t.Errorf("expecting %s(%q, %v) = %v", testCase.rule, testCase.attributeValue, testCase.assertion.String(), testCase.expect)
+		}
+	}
+}
+
+func TestLDAPSchemaStoreAttributeMatchingRules_1(t *testing.T) {
+	store := makeEffectiveAttributeTypeSampleStore(t)
+	store.AddMatchingRuleSchemaText("( 1.3.6.1.4.1.99999.4.1 NAME 'sampleLocalMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )")
+	store.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.2 NAME 'sampleLocal' EQUALITY sampleLocalMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )")
+	rules, err := store.AttributeMatchingRules("cn")
+	if nil != err {
+		t.Fatalf("failed on picking matching rules: %v", err)
+	}
+	if (nil == rules.Equality) || (rules.Equality.Name != "caseIgnoreMatch") {
+		t.Errorf("unexpected equality rule: %#v", rules.Equality)
+	}
+	if nil != rules.Ordering {
+		t.Errorf("unexpected ordering rule: %#v", rules.Ordering)
+	}
+	if (nil == rules.Substrings) || (rules.Substrings.Name != "caseIgnoreSubstringsMatch") {
+		t.Errorf("unexpected substrings rule: %#v", rules.Substrings)
+	}
+	if rules, err = store.AttributeMatchingRules("sampleLocal"); nil != err {
+		t.Fatalf("failed on picking matching rules: %v", err)
+	} else if nil != rules.Equality {
+		t.Errorf("expecting no evaluator for unknown rule: %#v", rules.Equality)
+	}
+	engine := NewBuiltinMatchingRuleEngine()
+	engine.Register(&MatchingRuleEvaluator{
+		NumericOID: "1.3.6.1.4.1.99999.4.1",
+		Usage:      MatchingRuleEquality,
+		Normalize:  normalizeCaseIgnoreString,
+	})
+	store.SetMatchingRuleEngine(engine)
+	if rules, err = store.AttributeMatchingRules("sampleLocal"); nil != err {
+		t.Fatalf("failed on picking matching rules: %v", err)
+	} else if (nil == rules.Equality) || (rules.Equality.NumericOID != "1.3.6.1.4.1.99999.4.1") {
+		t.Errorf("expecting evaluator resolved via OID: %#v", rules.Equality)
+	}
+}
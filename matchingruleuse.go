@@ -2,12 +2,12 @@ package ldapschemaparser
 
 // MatchingRuleUseSchema represent schema of matching rule uses
 type MatchingRuleUseSchema struct {
-	NumericOID  string
-	Name        []string
-	Description string
-	Obsolete    bool
-	AppliesTo   []string
-	Extensions  map[string][]string
+	NumericOID  string              `json:"oid"`
+	Name        []string            `json:"name,omitempty"`
+	Description string              `json:"desc,omitempty"`
+	Obsolete    bool                `json:"obsolete,omitempty"`
+	AppliesTo   []string            `json:"applies,omitempty"`
+	Extensions  map[string][]string `json:"extensions,omitempty"`
 }
 
 // NewMatchingRuleUseSchemaViaGenericSchema creates matching rule use schema instance from GenericSchema
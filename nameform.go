@@ -2,14 +2,14 @@ package ldapschemaparser
 
 // NameFormSchema represent schema of name form
 type NameFormSchema struct {
-	NumericOID  string
-	Name        []string
-	Description string
-	Obsolete    bool
-	ObjectClass string
-	Must        []string
-	May         []string
-	Extensions  map[string][]string
+	NumericOID  string              `json:"oid"`
+	Name        []string            `json:"name,omitempty"`
+	Description string              `json:"desc,omitempty"`
+	Obsolete    bool                `json:"obsolete,omitempty"`
+	ObjectClass string              `json:"oc,omitempty"`
+	Must        []string            `json:"must,omitempty"`
+	May         []string            `json:"may,omitempty"`
+	Extensions  map[string][]string `json:"extensions,omitempty"`
 }
 
 // NewNameFormSchemaViaGenericSchema creates name form schema instance from GenericSchema
@@ -0,0 +1,21 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+const sampleNameForm1 = "( 1.3.6.1.4.1.99999.3.1 NAME 'sampleUnitNameForm' OC organizationalUnit MUST ou )"
+
+func TestNameFormSchema_1(t *testing.T) {
+	s, err := ParseNameFormSchema(sampleNameForm1)
+	if nil != err {
+		t.Fatalf("failed on parsing Name Form sample 1: %v", err)
+	}
+	v := s.String()
+	if v != sampleNameForm1 {
+		t.Errorf("expecting %v but have %v", sampleNameForm1, v)
+	}
+	if s.ObjectClass != "organizationalUnit" {
+		t.Errorf("expecting OC organizationalUnit but have %v", s.ObjectClass)
+	}
+}
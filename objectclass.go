@@ -10,15 +10,15 @@ const (
 
 // ObjectClassSchema represent schema of object class
 type ObjectClassSchema struct {
-	NumericOID   string
-	Name         []string
-	Description  string
-	Obsolete     bool
-	SuperClasses []string
-	ClassKind    string
-	Must         []string
-	May          []string
-	Extensions   map[string][]string
+	NumericOID   string              `json:"oid"`
+	Name         []string            `json:"name,omitempty"`
+	Description  string              `json:"desc,omitempty"`
+	Obsolete     bool                `json:"obsolete,omitempty"`
+	SuperClasses []string            `json:"sup,omitempty"`
+	ClassKind    string              `json:"kind,omitempty"`
+	Must         []string            `json:"must,omitempty"`
+	May          []string            `json:"may,omitempty"`
+	Extensions   map[string][]string `json:"extensions,omitempty"`
 }
 
 // NewObjectClassSchemaViaGenericSchema creates object class schema instance from GenericSchema
@@ -46,6 +46,14 @@ func NewObjectClassSchemaViaGenericSchema(generic *GenericSchema) (result *Objec
 	}, nil
 }
 
+// ShortIdentifier return first short name or numeric OID if no short name available.
+func (s *ObjectClassSchema) ShortIdentifier() string {
+	if len(s.Name) > 0 {
+		return s.Name[0]
+	}
+	return s.NumericOID
+}
+
 func (s *ObjectClassSchema) String() string {
 	b := SchemaTextBuilder{}
 	b.AppendFragment(s.NumericOID)
@@ -0,0 +1,190 @@
+package ldapschemaparser
+
+import (
+	"strings"
+)
+
+// ObjectClassClosureAttribute is an attribute type required or allowed by
+// object classes in closure.
+type ObjectClassClosureAttribute struct {
+	// NumericOID of attribute type. Empty when attribute type is not in store.
+	NumericOID string `json:"oid,omitempty"`
+
+	// Name is the short identifier of attribute type, or the name as
+	// written in object class when attribute type is not in store.
+	Name string `json:"name"`
+
+	// ContributedBy are numeric OIDs of object classes listing the
+	// attribute type, in order of visiting.
+	ContributedBy []string `json:"contributed_by"`
+}
+
+// ObjectClassClosure is the merged MUST and MAY attribute types of object
+// classes and all their superclasses.
+type ObjectClassClosure struct {
+	// ObjectClasses are numeric OIDs of visited object classes. Each class
+	// is placed before its superclasses.
+	ObjectClasses []string `json:"object_classes"`
+
+	Must []*ObjectClassClosureAttribute `json:"must,omitempty"`
+	May  []*ObjectClassClosureAttribute `json:"may,omitempty"`
+
+	mustIndex map[string]*ObjectClassClosureAttribute
+	mayIndex  map[string]*ObjectClassClosureAttribute
+
+	// missingObjectClasses are names of object classes not in store. Only
+	// collected by partialObjectClassClosure.
+	missingObjectClasses []string
+	skipMissing          bool
+}
+
+func attributeClosureKey(numericOID, name string) string {
+	if "" != numericOID {
+		return numericOID
+	}
+	return strings.ToLower(name)
+}
+
+// HasObjectClass check if object class of given numeric OID is in closure.
+func (closure *ObjectClassClosure) HasObjectClass(numericOID string) bool {
+	for _, oid := range closure.ObjectClasses {
+		if oid == numericOID {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupAttribute find attribute in closure by key from attributeClosureKey.
+func (closure *ObjectClassClosure) lookupAttribute(key string) (attr *ObjectClassClosureAttribute, required bool) {
+	if attr = closure.mustIndex[key]; nil != attr {
+		return attr, true
+	}
+	return closure.mayIndex[key], false
+}
+
+func (closure *ObjectClassClosure) addAttribute(store *LDAPSchemaStore, objectClassOID string, attrName string, required bool) {
+	numericOID := ""
+	name := attrName
+	if genericSchema := store.findAttributeTypeGenericSchema(attrName); nil != genericSchema {
+		numericOID = genericSchema.NumericOID
+		name = numericOID
+		if names := genericSchema.getValuesOfParameterizedKeyword("NAME"); len(names) > 0 {
+			name = names[0]
+		}
+	}
+	key := attributeClosureKey(numericOID, attrName)
+	index := closure.mayIndex
+	if required {
+		index = closure.mustIndex
+	}
+	if attr := index[key]; nil != attr {
+		for _, oid := range attr.ContributedBy {
+			if oid == objectClassOID {
+				return
+			}
+		}
+		attr.ContributedBy = append(attr.ContributedBy, objectClassOID)
+		return
+	}
+	attr := &ObjectClassClosureAttribute{
+		NumericOID:    numericOID,
+		Name:          name,
+		ContributedBy: []string{objectClassOID},
+	}
+	index[key] = attr
+	if required {
+		closure.Must = append(closure.Must, attr)
+	} else {
+		closure.May = append(closure.May, attr)
+	}
+}
+
+// removeRequiredFromMay drop attributes which are also required as
+// MUST takes precedence over MAY.
+func (closure *ObjectClassClosure) removeRequiredFromMay() {
+	may := make([]*ObjectClassClosureAttribute, 0, len(closure.May))
+	for _, attr := range closure.May {
+		key := attributeClosureKey(attr.NumericOID, attr.Name)
+		if nil != closure.mustIndex[key] {
+			delete(closure.mayIndex, key)
+			continue
+		}
+		may = append(may, attr)
+	}
+	closure.May = may
+}
+
+func (closure *ObjectClassClosure) addMissingObjectClass(objectClassName string) {
+	for _, name := range closure.missingObjectClasses {
+		if strings.EqualFold(name, objectClassName) {
+			return
+		}
+	}
+	closure.missingObjectClasses = append(closure.missingObjectClasses, objectClassName)
+}
+
+func (closure *ObjectClassClosure) visit(store *LDAPSchemaStore, objectClassName string) (err error) {
+	genericSchema := store.findObjectClassGenericSchema(objectClassName)
+	if nil == genericSchema {
+		if closure.skipMissing {
+			closure.addMissingObjectClass(objectClassName)
+			return nil
+		}
+		return &ErrSchemaNotFound{
+			Kind:       SchemaKindObjectClass,
+			Identifier: objectClassName,
+		}
+	}
+	if closure.HasObjectClass(genericSchema.NumericOID) {
+		return nil
+	}
+	objectClassSchema, err := NewObjectClassSchemaViaGenericSchema(genericSchema)
+	if nil != err {
+		return
+	}
+	closure.ObjectClasses = append(closure.ObjectClasses, objectClassSchema.NumericOID)
+	for _, attrName := range objectClassSchema.Must {
+		closure.addAttribute(store, objectClassSchema.NumericOID, attrName, true)
+	}
+	for _, attrName := range objectClassSchema.May {
+		closure.addAttribute(store, objectClassSchema.NumericOID, attrName, false)
+	}
+	for _, superClassName := range objectClassSchema.SuperClasses {
+		if err = closure.visit(store, superClassName); nil != err {
+			return
+		}
+	}
+	return nil
+}
+
+// ObjectClassClosure collects MUST and MAY attribute types of given object
+// classes (typically a structural class and its auxiliary classes) and all
+// their superclasses. An attribute type required by any class is removed
+// from MAY. Attribute types are identified through attributeTypeNameIndex
+// so different names of the same attribute type are merged.
+func (store *LDAPSchemaStore) ObjectClassClosure(objectClassNames ...string) (closure *ObjectClassClosure, err error) {
+	return store.makeObjectClassClosure(false, objectClassNames)
+}
+
+// partialObjectClassClosure collects closure as ObjectClassClosure but
+// skips object classes not in store. Names of skipped object classes are
+// kept in missingObjectClasses.
+func (store *LDAPSchemaStore) partialObjectClassClosure(objectClassNames ...string) (closure *ObjectClassClosure, err error) {
+	return store.makeObjectClassClosure(true, objectClassNames)
+}
+
+func (store *LDAPSchemaStore) makeObjectClassClosure(skipMissing bool, objectClassNames []string) (closure *ObjectClassClosure, err error) {
+	closure = &ObjectClassClosure{
+		mustIndex:   make(map[string]*ObjectClassClosureAttribute),
+		mayIndex:    make(map[string]*ObjectClassClosureAttribute),
+		skipMissing: skipMissing,
+	}
+	for _, objectClassName := range objectClassNames {
+		if err = closure.visit(store, objectClassName); nil != err {
+			return nil, err
+		}
+	}
+	closure.removeRequiredFromMay()
+	return closure, nil
+}
@@ -0,0 +1,79 @@
+package ldapschemaparser
+
+import (
+	"strings"
+	"testing"
+)
+
+func makeObjectClassClosureSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 2.5.4.0 NAME 'objectClass' SYNTAX 1.3.6.1.4.1.1466.115.121.1.38 )",
+		"( 2.5.4.3 NAME ( 'cn' 'commonName' ) SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 2.5.4.4 NAME ( 'sn' 'surname' ) SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 2.5.4.13 NAME 'description' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 0.9.2342.19200300.100.1.3 NAME ( 'mail' 'rfc822Mailbox' ) SYNTAX 1.3.6.1.4.1.1466.115.121.1.26 )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 2.5.6.0 NAME 'top' ABSTRACT MUST objectClass )",
+		"( 2.5.6.6 NAME 'person' SUP top STRUCTURAL MUST ( sn $ cn ) MAY ( description $ sampleMissing ) )",
+		"( 1.3.6.1.4.1.99999.2.1 NAME 'sampleMailbox' SUP top AUXILIARY MUST rfc822Mailbox MAY ( commonName $ surname ) )",
+	} {
+		if err := store.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	return store
+}
+
+func closureAttributesText(attrs []*ObjectClassClosureAttribute) string {
+	texts := make([]string, 0, len(attrs))
+	for _, attr := range attrs {
+		texts = append(texts, attr.Name+"<"+strings.Join(attr.ContributedBy, ",")+">")
+	}
+	return strings.Join(texts, " ")
+}
+
+func TestObjectClassClosure_1(t *testing.T) {
+	store := makeObjectClassClosureSampleStore(t)
+	closure, err := store.ObjectClassClosure("person")
+	if nil != err {
+		t.Fatalf("failed on making closure: %v", err)
+	}
+	if v := strings.Join(closure.ObjectClasses, " "); v != "2.5.6.6 2.5.6.0" {
+		t.Errorf("unexpected object classes: %v", v)
+	}
+	if v := closureAttributesText(closure.Must); v != "sn<2.5.6.6> cn<2.5.6.6> objectClass<2.5.6.0>" {
+		t.Errorf("unexpected MUST: %v", v)
+	}
+	if v := closureAttributesText(closure.May); v != "description<2.5.6.6> sampleMissing<2.5.6.6>" {
+		t.Errorf("unexpected MAY: %v", v)
+	}
+	if v := closure.May[1].NumericOID; v != "" {
+		t.Errorf("expecting empty OID for missing attribute type: %v", v)
+	}
+}
+
+func TestObjectClassClosure_2(t *testing.T) {
+	store := makeObjectClassClosureSampleStore(t)
+	closure, err := store.ObjectClassClosure("person", "sampleMailbox")
+	if nil != err {
+		t.Fatalf("failed on making closure: %v", err)
+	}
+	if v := strings.Join(closure.ObjectClasses, " "); v != "2.5.6.6 2.5.6.0 1.3.6.1.4.1.99999.2.1" {
+		t.Errorf("unexpected object classes: %v", v)
+	}
+	if v := closureAttributesText(closure.Must); v != "sn<2.5.6.6> cn<2.5.6.6> objectClass<2.5.6.0> mail<1.3.6.1.4.1.99999.2.1>" {
+		t.Errorf("unexpected MUST: %v", v)
+	}
+	if v := closureAttributesText(closure.May); v != "description<2.5.6.6> sampleMissing<2.5.6.6>" {
+		t.Errorf("unexpected MAY: %v", v)
+	}
+	if _, err := store.ObjectClassClosure("sampleUnknown"); nil == err {
+		t.Error("expecting error for unknown object class")
+	}
+}
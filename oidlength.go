@@ -23,7 +23,7 @@ func parseOIDLength(oidLengthText string) (oid string, length int32) {
 	}
 	oid = string(d[0:leftPidx])
 	if rightPidx > leftPidx {
-		lenText := string(d[leftPidx:rightPidx])
+		lenText := string(d[leftPidx+1 : rightPidx])
 		if v, err := strconv.ParseInt(lenText, 10, 31); nil == err {
 			length = int32(v)
 		}
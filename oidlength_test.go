@@ -0,0 +1,31 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func TestParseOIDLength_1(t *testing.T) {
+	oid, length := parseOIDLength("1.3.6.1.4.1.1466.115.121.1.15{32768}")
+	if (oid != "1.3.6.1.4.1.1466.115.121.1.15") || (length != 32768) {
+		t.Errorf("expecting 1.3.6.1.4.1.1466.115.121.1.15 and 32768, got: %v, %v", oid, length)
+	}
+	oid, length = parseOIDLength("1.3.6.1.4.1.1466.115.121.1.15")
+	if (oid != "1.3.6.1.4.1.1466.115.121.1.15") || (length != 0) {
+		t.Errorf("expecting 1.3.6.1.4.1.1466.115.121.1.15 and 0, got: %v, %v", oid, length)
+	}
+}
+
+func TestParseOIDLength_2(t *testing.T) {
+	oid, length := parseOIDLength("1.3.6.1.4.1.1466.115.121.1.26{1}")
+	if (oid != "1.3.6.1.4.1.1466.115.121.1.26") || (length != 1) {
+		t.Errorf("expecting 1.3.6.1.4.1.1466.115.121.1.26 and 1, got: %v, %v", oid, length)
+	}
+	oid, length = parseOIDLength("1.3.6.1.4.1.1466.115.121.1.26{}")
+	if (oid != "1.3.6.1.4.1.1466.115.121.1.26") || (length != 0) {
+		t.Errorf("expecting 1.3.6.1.4.1.1466.115.121.1.26 and 0, got: %v, %v", oid, length)
+	}
+	oid, length = parseOIDLength("1.3.6.1.4.1.1466.115.121.1.26{x}")
+	if (oid != "1.3.6.1.4.1.1466.115.121.1.26") || (length != 0) {
+		t.Errorf("expecting 1.3.6.1.4.1.1466.115.121.1.26 and 0, got: %v, %v", oid, length)
+	}
+}
@@ -0,0 +1,198 @@
+package ldapschemaparser
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+)
+
+// OpenLDAPConfigLDIFOption configures output of OpenLDAP cn=config schema LDIF.
+type OpenLDAPConfigLDIFOption struct {
+	// SchemaName is the name of schema entry (eg. `myapp` of `cn={4}myapp,cn=schema,cn=config`).
+	SchemaName string
+
+	// SchemaIndex is the `{N}` ordering prefix of schema entry. Negative value omits the prefix.
+	SchemaIndex int
+
+	// OIDMacros are written as olcObjectIdentifier values when given.
+	OIDMacros []*OIDMacro
+
+	// IncludeLDAPSyntaxes enables output of olcLdapSyntaxes values.
+	IncludeLDAPSyntaxes bool
+
+	// Baseline store. Schema elements existed in baseline are not written when given.
+	Baseline *LDAPSchemaStore
+}
+
+func (option *OpenLDAPConfigLDIFOption) entryName() string {
+	if option.SchemaIndex < 0 {
+		return option.SchemaName
+	}
+	return "{" + strconv.Itoa(option.SchemaIndex) + "}" + option.SchemaName
+}
+
+func existedInBaseline(baselineIndex map[string]*GenericSchema, oid string) bool {
+	if nil == baselineIndex {
+		return false
+	}
+	_, ok := baselineIndex[oid]
+	return ok
+}
+
+func writeOrderedLDIFAttributeValues(w io.Writer, attrName string, values []string) (err error) {
+	for idx, value := range values {
+		if err = writeLDIFAttributeValue(w, attrName, "{"+strconv.Itoa(idx)+"}"+value); nil != err {
+			return
+		}
+	}
+	return nil
+}
+
+func (store *LDAPSchemaStore) collectOpenLDAPConfigLDAPSyntaxes(baseline *LDAPSchemaStore) (result []string, err error) {
+	var baselineIndex map[string]*GenericSchema
+	if nil != baseline {
+		baselineIndex = baseline.ldapSyntaxSchemaIndex
+	}
+	for _, oid := range sortedMapKey(store.ldapSyntaxSchemaIndex) {
+		if existedInBaseline(baselineIndex, oid) {
+			continue
+		}
+		ldapSyntaxSchema, err := NewLDAPSyntaxSchemaViaGenericSchema(store.ldapSyntaxSchemaIndex[oid])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, ldapSyntaxSchema.String())
+	}
+	return
+}
+
+func (store *LDAPSchemaStore) collectOpenLDAPConfigAttributeTypes(baseline *LDAPSchemaStore) (result []string, err error) {
+	var baselineIndex map[string]*GenericSchema
+	if nil != baseline {
+		baselineIndex = baseline.attributeTypeSchemaIndex
+	}
+	attributeTypeSchemas, err := store.makeDependencyOrderedAttributeTypeSchemas()
+	if nil != err {
+		return
+	}
+	for _, attributeTypeSchema := range attributeTypeSchemas {
+		if existedInBaseline(baselineIndex, attributeTypeSchema.NumericOID) {
+			continue
+		}
+		result = append(result, attributeTypeSchema.String())
+	}
+	return
+}
+
+func (store *LDAPSchemaStore) collectOpenLDAPConfigObjectClasses(baseline *LDAPSchemaStore) (result []string, err error) {
+	var baselineIndex map[string]*GenericSchema
+	if nil != baseline {
+		baselineIndex = baseline.objectClassSchemaIndex
+	}
+	objectClassSchemas, err := store.makeDependencyOrderedObjectClassSchemas()
+	if nil != err {
+		return
+	}
+	for _, objectClassSchema := range objectClassSchemas {
+		if existedInBaseline(baselineIndex, objectClassSchema.NumericOID) {
+			continue
+		}
+		result = append(result, objectClassSchema.String())
+	}
+	return
+}
+
+func (store *LDAPSchemaStore) collectOpenLDAPConfigDITContentRules(baseline *LDAPSchemaStore) (result []string, err error) {
+	var baselineIndex map[string]*GenericSchema
+	if nil != baseline {
+		baselineIndex = baseline.ditContentRuleSchemaIndex
+	}
+	for _, oid := range sortedMapKey(store.ditContentRuleSchemaIndex) {
+		if existedInBaseline(baselineIndex, oid) {
+			continue
+		}
+		ditContentRuleSchema, err := NewDITContentRuleSchemaViaGenericSchema(store.ditContentRuleSchemaIndex[oid])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, ditContentRuleSchema.String())
+	}
+	return
+}
+
+// WriteOpenLDAPConfigLDIF writes content of store as an OpenLDAP
+// `cn={N}name,cn=schema,cn=config` entry in LDIF form.
+// Attribute types and object classes are ordered so that super types are
+// written before the types depend on them.
+func (store *LDAPSchemaStore) WriteOpenLDAPConfigLDIF(w io.Writer, option *OpenLDAPConfigLDIFOption) (err error) {
+	if (nil == option) || ("" == option.SchemaName) {
+		return &ErrMissingField{
+			FieldName: "SchemaName",
+		}
+	}
+	var ldapSyntaxes []string
+	if option.IncludeLDAPSyntaxes {
+		if ldapSyntaxes, err = store.collectOpenLDAPConfigLDAPSyntaxes(option.Baseline); nil != err {
+			return
+		}
+	}
+	attributeTypes, err := store.collectOpenLDAPConfigAttributeTypes(option.Baseline)
+	if nil != err {
+		return
+	}
+	objectClasses, err := store.collectOpenLDAPConfigObjectClasses(option.Baseline)
+	if nil != err {
+		return
+	}
+	ditContentRules, err := store.collectOpenLDAPConfigDITContentRules(option.Baseline)
+	if nil != err {
+		return
+	}
+	var oidMacros []string
+	for _, macro := range option.OIDMacros {
+		oidMacros = append(oidMacros, macro.Name+" "+macro.OID)
+	}
+	entryName := option.entryName()
+	if err = writeLDIFAttributeValue(w, "dn", "cn="+entryName+",cn=schema,cn=config"); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValue(w, "objectClass", "olcSchemaConfig"); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValue(w, "cn", entryName); nil != err {
+		return
+	}
+	if err = writeOrderedLDIFAttributeValues(w, "olcObjectIdentifier", oidMacros); nil != err {
+		return
+	}
+	if err = writeOrderedLDIFAttributeValues(w, "olcLdapSyntaxes", ldapSyntaxes); nil != err {
+		return
+	}
+	if err = writeOrderedLDIFAttributeValues(w, "olcAttributeTypes", attributeTypes); nil != err {
+		return
+	}
+	if err = writeOrderedLDIFAttributeValues(w, "olcObjectClasses", objectClasses); nil != err {
+		return
+	}
+	if err = writeOrderedLDIFAttributeValues(w, "olcDitContentRules", ditContentRules); nil != err {
+		return
+	}
+	_, err = io.WriteString(w, "\n")
+	return
+}
+
+// WriteToOpenLDAPConfigLDIFFile write content of store into file at given path
+// as OpenLDAP cn=config schema LDIF.
+func (store *LDAPSchemaStore) WriteToOpenLDAPConfigLDIFFile(name string, option *OpenLDAPConfigLDIFOption) (err error) {
+	fp, err := os.Create(name)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	w := bufio.NewWriter(fp)
+	if err = store.WriteOpenLDAPConfigLDIF(w, option); nil != err {
+		return
+	}
+	return w.Flush()
+}
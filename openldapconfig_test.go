@@ -0,0 +1,86 @@
+package ldapschemaparser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func makeOpenLDAPConfigSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.1 NAME ( 'sampleSub' 'sampleSubAlias' ) SUP sampleBase )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleBase' EQUALITY caseIgnoreMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{256} )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.2.1 NAME 'sampleAccount' SUP samplePerson STRUCTURAL )",
+		"( 1.3.6.1.4.1.99999.2.10 NAME 'samplePerson' SUP top STRUCTURAL MUST sampleSub )",
+	} {
+		if err := store.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	return store
+}
+
+func TestWriteOpenLDAPConfigLDIF_1(t *testing.T) {
+	store := makeOpenLDAPConfigSampleStore(t)
+	var buf bytes.Buffer
+	err := store.WriteOpenLDAPConfigLDIF(&buf, &OpenLDAPConfigLDIFOption{
+		SchemaName:  "sample",
+		SchemaIndex: 4,
+		OIDMacros: []*OIDMacro{
+			{Name: "SampleRoot", OID: "1.3.6.1.4.1.99999"},
+		},
+	})
+	if nil != err {
+		t.Fatalf("failed on writing LDIF: %v", err)
+	}
+	expect := "dn: cn={4}sample,cn=schema,cn=config\n" +
+		"objectClass: olcSchemaConfig\n" +
+		"cn: {4}sample\n" +
+		"olcObjectIdentifier: {0}SampleRoot 1.3.6.1.4.1.99999\n" +
+		"olcAttributeTypes: {0}( 1.3.6.1.4.1.99999.1.2 NAME 'sampleBase' EQUALITY cas\n" +
+		" eIgnoreMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{256} )\n" +
+		"olcAttributeTypes: {1}( 1.3.6.1.4.1.99999.1.1 NAME ( 'sampleSub' 'sampleSubA\n" +
+		" lias' ) SUP sampleBase )\n" +
+		"olcObjectClasses: {0}( 1.3.6.1.4.1.99999.2.10 NAME 'samplePerson' SUP top ST\n" +
+		" RUCTURAL MUST sampleSub )\n" +
+		"olcObjectClasses: {1}( 1.3.6.1.4.1.99999.2.1 NAME 'sampleAccount' SUP sample\n" +
+		" Person STRUCTURAL )\n" +
+		"\n"
+	if v := buf.String(); v != expect {
+		t.Errorf("unexpected LDIF:\n%v\nexpecting:\n%v", v, expect)
+	}
+}
+
+func TestWriteOpenLDAPConfigLDIF_2(t *testing.T) {
+	store := makeOpenLDAPConfigSampleStore(t)
+	baseline := NewLDAPSchemaStore()
+	if err := baseline.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.2 NAME 'sampleBase' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )"); nil != err {
+		t.Fatalf("failed on adding attribute type to baseline: %v", err)
+	}
+	var buf bytes.Buffer
+	err := store.WriteOpenLDAPConfigLDIF(&buf, &OpenLDAPConfigLDIFOption{
+		SchemaName:  "sample",
+		SchemaIndex: -1,
+		Baseline:    baseline,
+	})
+	if nil != err {
+		t.Fatalf("failed on writing LDIF: %v", err)
+	}
+	v := buf.String()
+	if !strings.HasPrefix(v, "dn: cn=sample,cn=schema,cn=config\n") {
+		t.Errorf("unexpected DN: %v", v)
+	}
+	if strings.Contains(v, "1.3.6.1.4.1.99999.1.2 ") {
+		t.Errorf("expecting baseline attribute type excluded: %v", v)
+	}
+	if !strings.Contains(v, "olcAttributeTypes: {0}( 1.3.6.1.4.1.99999.1.1 ") {
+		t.Errorf("expecting non-baseline attribute type: %v", v)
+	}
+}
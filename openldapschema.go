@@ -0,0 +1,295 @@
+package ldapschemaparser
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// OIDMacro is an object identifier macro defined with `objectidentifier`
+// directive in OpenLDAP schema file.
+type OIDMacro struct {
+	Name string
+	OID  string
+}
+
+// OpenLDAPSchemaLoader loads OpenLDAP `.schema` files into LDAPSchemaStore.
+// Object identifier macros are kept across files loaded with the same loader.
+type OpenLDAPSchemaLoader struct {
+	store *LDAPSchemaStore
+
+	oidMacros     map[string]*OIDMacro
+	oidMacroNames []string
+}
+
+// NewOpenLDAPSchemaLoader create an instance of OpenLDAPSchemaLoader feeding given store.
+func NewOpenLDAPSchemaLoader(store *LDAPSchemaStore) *OpenLDAPSchemaLoader {
+	return &OpenLDAPSchemaLoader{
+		store:     store,
+		oidMacros: make(map[string]*OIDMacro),
+	}
+}
+
+// OIDMacros returns defined object identifier macros in order of definition.
+// Values of macros are resolved numeric OIDs.
+func (loader *OpenLDAPSchemaLoader) OIDMacros() (result []*OIDMacro) {
+	for _, lowercaseName := range loader.oidMacroNames {
+		result = append(result, loader.oidMacros[lowercaseName])
+	}
+	return
+}
+
+func isNumericOIDText(v string) bool {
+	if "" == v {
+		return false
+	}
+	for _, ch := range v {
+		if ((ch < '0') || (ch > '9')) && (ch != '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// ResolveOID resolves given OID text which might be a numeric OID, a macro
+// name or a `macro:suffix` form into numeric OID.
+func (loader *OpenLDAPSchemaLoader) ResolveOID(oidText string) (oid string, err error) {
+	if isNumericOIDText(oidText) {
+		return oidText, nil
+	}
+	macroName := oidText
+	suffix := ""
+	if idx := strings.Index(oidText, ":"); idx >= 0 {
+		macroName = oidText[:idx]
+		suffix = oidText[idx+1:]
+		if !isNumericOIDText(suffix) {
+			return "", errors.New("invalid suffix of OID macro: " + oidText)
+		}
+	}
+	macro := loader.oidMacros[strings.ToLower(macroName)]
+	if nil == macro {
+		return "", errors.New("unknown OID macro: " + macroName)
+	}
+	if "" == suffix {
+		return macro.OID, nil
+	}
+	return macro.OID + "." + suffix, nil
+}
+
+func (loader *OpenLDAPSchemaLoader) defineOIDMacro(name, oidText string) (err error) {
+	oid, err := loader.ResolveOID(oidText)
+	if nil != err {
+		return
+	}
+	lowercaseName := strings.ToLower(name)
+	if macro := loader.oidMacros[lowercaseName]; nil != macro {
+		macro.OID = oid
+		return nil
+	}
+	loader.oidMacros[lowercaseName] = &OIDMacro{
+		Name: name,
+		OID:  oid,
+	}
+	loader.oidMacroNames = append(loader.oidMacroNames, lowercaseName)
+	return nil
+}
+
+func isOIDMacroTokenRune(ch rune) bool {
+	return ((ch >= 'a') && (ch <= 'z')) || ((ch >= 'A') && (ch <= 'Z')) || ((ch >= '0') && (ch <= '9')) || (ch == '.') || (ch == ':') || (ch == '-') || (ch == '_')
+}
+
+// expandOIDMacros replace OID macros in given definition text.
+// Tokens in `macro:suffix` form are expanded anywhere outside of quoted
+// strings. Bare macro names are only expanded at the numeric OID and
+// SYNTAX positions. Index of source rune in definition is recorded in
+// sourceIndexes for each rune of result, runes of an expanded token are
+// mapped to the start of token.
+func (loader *OpenLDAPSchemaLoader) expandOIDMacros(definition []rune) (result []rune, sourceIndexes []int, err error) {
+	result = make([]rune, 0, len(definition))
+	sourceIndexes = make([]int, 0, len(definition))
+	var quoteChar rune
+	tokenIndex := 0
+	previousToken := ""
+	for idx := 0; idx < len(definition); {
+		ch := definition[idx]
+		if quoteChar != 0 {
+			if ch == quoteChar {
+				quoteChar = 0
+			}
+			result = append(result, ch)
+			sourceIndexes = append(sourceIndexes, idx)
+			idx++
+			continue
+		}
+		if (ch == '\'') || (ch == '"') {
+			quoteChar = ch
+			result = append(result, ch)
+			sourceIndexes = append(sourceIndexes, idx)
+			idx++
+			continue
+		}
+		if !isOIDMacroTokenRune(ch) {
+			result = append(result, ch)
+			sourceIndexes = append(sourceIndexes, idx)
+			idx++
+			continue
+		}
+		startIndex := idx
+		for (idx < len(definition)) && isOIDMacroTokenRune(definition[idx]) {
+			idx++
+		}
+		token := string(definition[startIndex:idx])
+		tokenIndex++
+		oidPosition := (tokenIndex == 1) || ("SYNTAX" == strings.ToUpper(previousToken))
+		if strings.Contains(token, ":") || (oidPosition && (nil != loader.oidMacros[strings.ToLower(token)])) {
+			if token, err = loader.ResolveOID(token); nil != err {
+				return nil, nil, err
+			}
+			for range token {
+				sourceIndexes = append(sourceIndexes, startIndex)
+			}
+		} else {
+			for sourceIdx := startIndex; sourceIdx < idx; sourceIdx++ {
+				sourceIndexes = append(sourceIndexes, sourceIdx)
+			}
+		}
+		previousToken = token
+		result = append(result, []rune(token)...)
+	}
+	return result, sourceIndexes, nil
+}
+
+// restoreParseErrorSource moves position of parse error from definition
+// with macros expanded back to the source definition. Token and snippet
+// are taken from the source definition.
+func restoreParseErrorSource(parseError *ParseError, source []rune, sourceIndexes []int) {
+	offset := len(source)
+	if parseError.Offset < len(sourceIndexes) {
+		offset = sourceIndexes[parseError.Offset]
+	}
+	boundIndex := len(source)
+	if tokenBound := parseError.Offset + len([]rune(parseError.Token)); tokenBound < len(sourceIndexes) {
+		boundIndex = sourceIndexes[tokenBound]
+	}
+	sourceLexer := newSchemaLexerWithRunes(source)
+	parseError.Offset = offset
+	parseError.Line, parseError.Column = sourceLexer.lineColumn(offset)
+	if ("" != parseError.Token) && (offset < boundIndex) {
+		parseError.Token = string(source[offset:boundIndex])
+	}
+	parseError.Snippet = sourceLexer.snippet(offset)
+}
+
+func (loader *OpenLDAPSchemaLoader) readDirectives(leading string) (definitionKeyword string, err error) {
+	for _, ln := range strings.Split(leading, "\n") {
+		fields := strings.Fields(ln)
+		if 0 == len(fields) {
+			continue
+		}
+		if definitionKeyword != "" {
+			return "", errors.New("unexpected text after definition keyword " + definitionKeyword + ": " + ln)
+		}
+		keyword := strings.ToLower(fields[0])
+		switch keyword {
+		case "objectidentifier":
+			if len(fields) != 3 {
+				return "", errors.New("expecting name and OID for objectidentifier: " + ln)
+			}
+			if err = loader.defineOIDMacro(fields[1], fields[2]); nil != err {
+				return "", err
+			}
+		default:
+			if len(fields) != 1 {
+				return "", errors.New("unknown directive: " + ln)
+			}
+			definitionKeyword = keyword
+		}
+	}
+	return definitionKeyword, nil
+}
+
+func (loader *OpenLDAPSchemaLoader) addDefinition(definitionKeyword string, definition []rune) (err error) {
+	genericSchema, err := parseRunes(definition)
+	if nil != err {
+		return
+	}
+	switch definitionKeyword {
+	case "ldapsyntax", "ldapsyntaxes":
+		err = loader.store.addLDAPSyntaxGenericSchema(genericSchema)
+	case "attributetype", "attributetypes":
+		err = loader.store.addAttributeTypeGenericSchema(genericSchema)
+	case "objectclass", "objectclasses":
+		err = loader.store.addObjectClassGenericSchema(genericSchema)
+	case "ditcontentrule", "ditcontentrules":
+		err = loader.store.addDITContentRuleGenericSchema(genericSchema)
+	case "":
+		err = errors.New("missing definition keyword")
+	default:
+		err = errors.New("unknown definition keyword: " + definitionKeyword)
+	}
+	return
+}
+
+// Read loads schema definitions from given reader.
+func (loader *OpenLDAPSchemaLoader) Read(r io.Reader) (err error) {
+	textReader := newSchemaTextReader(r)
+	for {
+		leading, definition, offset, line, column, err := textReader.readDefinition()
+		if nil != err {
+			if io.EOF == err {
+				_, err = loader.readDirectives(leading)
+				if nil == err {
+					return nil
+				}
+				return &ParseError{
+					Message: err.Error(),
+					Offset:  offset,
+					Line:    line,
+					Column:  column,
+				}
+			}
+			return err
+		}
+		definitionKeyword, err := loader.readDirectives(leading)
+		if nil == err {
+			var expanded []rune
+			var sourceIndexes []int
+			if expanded, sourceIndexes, err = loader.expandOIDMacros(definition); nil == err {
+				err = loader.addDefinition(definitionKeyword, expanded)
+			}
+			if parseError, ok := err.(*ParseError); ok {
+				restoreParseErrorSource(parseError, definition, sourceIndexes)
+			}
+		}
+		if nil != err {
+			if parseError, ok := err.(*ParseError); ok {
+				adjustParseErrorPosition(parseError, offset, line, column)
+				return parseError
+			}
+			return &ParseError{
+				Message: err.Error(),
+				Offset:  offset,
+				Line:    line,
+				Column:  column,
+				Snippet: string(definition),
+			}
+		}
+	}
+}
+
+// ReadFromFile loads schema definitions from OpenLDAP schema file at given path.
+func (loader *OpenLDAPSchemaLoader) ReadFromFile(name string) (err error) {
+	fp, err := os.Open(name)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	if err = loader.Read(fp); nil != err {
+		if parseError, ok := err.(*ParseError); ok {
+			parseError.FileName = name
+			parseError.FileLine = parseError.Line
+		}
+	}
+	return
+}
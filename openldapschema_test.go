@@ -0,0 +1,82 @@
+package ldapschemaparser
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleOpenLDAPSchema1 = `# sample schema
+objectidentifier SampleRoot 1.3.6.1.4.1.99999
+objectIdentifier SampleAttr SampleRoot:1
+objectidentifier SampleClass SampleRoot:2
+objectidentifier SampleSyntax 1.3.6.1.4.1.1466.115.121.1.15
+
+attributetype ( SampleAttr:1 NAME 'sampleName'
+	DESC 'keep SampleRoot:9 in text'
+	EQUALITY caseIgnoreMatch
+	SYNTAX SampleSyntax{64} SINGLE-VALUE )
+
+objectclass ( SampleClass:1 NAME 'sampleObject'
+	SUP top AUXILIARY
+	MAY sampleName )
+`
+
+func TestOpenLDAPSchemaLoader_1(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	loader := NewOpenLDAPSchemaLoader(store)
+	if err := loader.Read(strings.NewReader(sampleOpenLDAPSchema1)); nil != err {
+		t.Fatalf("failed on loading OpenLDAP schema: %v", err)
+	}
+	genericSchema := store.attributeTypeSchemaIndex["1.3.6.1.4.1.99999.1.1"]
+	if nil == genericSchema {
+		t.Fatalf("expecting attribute type with expanded OID: %v", store.attributeTypeSchemaIndex)
+	}
+	attributeTypeSchema, err := NewAttributeTypeSchemaViaGenericSchema(genericSchema)
+	if nil != err {
+		t.Fatalf("failed on converting attribute type: %v", err)
+	}
+	if attributeTypeSchema.Syntax != "1.3.6.1.4.1.1466.115.121.1.15{64}" {
+		t.Errorf("unexpected syntax: %v", attributeTypeSchema.Syntax)
+	}
+	if attributeTypeSchema.Description != "keep SampleRoot:9 in text" {
+		t.Errorf("unexpected description: %v", attributeTypeSchema.Description)
+	}
+	if nil == store.objectClassNameIndex["sampleobject"] {
+		t.Errorf("expecting object class sampleObject: %v", store.objectClassSchemaIndex)
+	}
+	macros := loader.OIDMacros()
+	if (len(macros) != 4) || (macros[1].Name != "SampleAttr") || (macros[1].OID != "1.3.6.1.4.1.99999.1") {
+		t.Errorf("unexpected OID macros: %v", macros)
+	}
+}
+
+func TestOpenLDAPSchemaLoader_2(t *testing.T) {
+	loader := NewOpenLDAPSchemaLoader(NewLDAPSchemaStore())
+	err := loader.Read(strings.NewReader("attributetype ( Unknown:1 NAME 'x' )"))
+	if nil == err {
+		t.Fatal("expecting error on unknown OID macro")
+	}
+	if _, ok := err.(*ParseError); !ok {
+		t.Errorf("expecting *ParseError but have %T: %v", err, err)
+	}
+}
+
+func TestOpenLDAPSchemaLoader_3(t *testing.T) {
+	loader := NewOpenLDAPSchemaLoader(NewLDAPSchemaStore())
+	err := loader.Read(strings.NewReader("objectidentifier SampleRoot 1.3.6.1.4.1.99999\n" +
+		"attributetype ( SampleRoot:1\n" +
+		"\tSUP ( ) )\n"))
+	if nil == err {
+		t.Fatal("expecting parse error")
+	}
+	parseError, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("expecting *ParseError but have %T: %v", err, err)
+	}
+	if (parseError.Offset != 82) || (parseError.Line != 3) || (parseError.Column != 8) || (parseError.Token != ")") {
+		t.Errorf("expecting error positioned in source text: %v", parseError)
+	}
+	if !strings.Contains(parseError.Snippet, "SampleRoot:1") {
+		t.Errorf("expecting snippet of source text: %q", parseError.Snippet)
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"strings"
 )
 
-// ErrParseFailed indicate parser stopped at failed state
+// ErrParseFailed indicate parser stopped at failed state without detail error
 var ErrParseFailed = errors.New("parsing LDAP schema failed with error parsing state")
 
 // ErrEmptyResult indicate parser resulted an empty result
@@ -156,11 +156,19 @@ func (schema *GenericSchema) HasFlagKeyword(keyword string) bool {
 	return false
 }
 
-// Parse parsing given schema text into generic schema structure
+// Parse parsing given schema text into generic schema structure.
+// A *ParseError is returned when the schema text is malformed.
 func Parse(schemaText string) (genericSchema *GenericSchema, err error) {
-	lexer := newSchemaLexer(schemaText)
+	return parseRunes([]rune(schemaText))
+}
+
+func parseRunes(schemaRunes []rune) (genericSchema *GenericSchema, err error) {
+	lexer := newSchemaLexerWithRunes(schemaRunes)
 	parser := yyNewParser()
 	if parser.Parse(lexer) != 0 {
+		if nil != lexer.parseError {
+			return nil, lexer.parseError
+		}
 		return nil, ErrParseFailed
 	}
 	genericSchema = lexer.result // it's a little bit hacky: https://github.com/golang/go/issues/20861
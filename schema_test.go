@@ -0,0 +1,38 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func TestParse_ParseError_1(t *testing.T) {
+	_, err := Parse("( 2.5.4.3 NAME 'cn'\n SYNTAX 1.2{x} )")
+	if nil == err {
+		t.Fatal("expecting parse error")
+	}
+	parseError, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("expecting *ParseError but have %T: %v", err, err)
+	}
+	if parseError.Offset != 32 {
+		t.Errorf("expecting offset 32 but have %d", parseError.Offset)
+	}
+	if (parseError.Line != 2) || (parseError.Column != 13) {
+		t.Errorf("expecting line 2 column 13 but have line %d column %d", parseError.Line, parseError.Column)
+	}
+	if parseError.Token != "x" {
+		t.Errorf("expecting offending token `x` but have %q", parseError.Token)
+	}
+	if (len(parseError.Expected) != 1) || (parseError.Expected[0] != "NUMBER") {
+		t.Errorf("expecting expected-token list [NUMBER] but have %v (%v)", parseError.Expected, parseError.Message)
+	}
+	if parseError.Snippet == "" {
+		t.Error("expecting non-empty snippet")
+	}
+}
+
+func TestParse_ParseError_2(t *testing.T) {
+	_, err := ParseAttributeTypeSchema("( 2.5.4.3 NAME )")
+	if _, ok := err.(*ParseError); !ok {
+		t.Errorf("expecting *ParseError from ParseAttributeTypeSchema but have %T: %v", err, err)
+	}
+}
@@ -0,0 +1,646 @@
+package ldapschemaparser
+
+import (
+	"errors"
+	"html"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaDocFormat is the output format of schema reference documentation.
+type SchemaDocFormat string
+
+// Formats of schema reference documentation.
+const (
+	SchemaDocMarkdown SchemaDocFormat = "markdown"
+	SchemaDocHTML     SchemaDocFormat = "html"
+)
+
+func (format SchemaDocFormat) fileExtension() string {
+	if SchemaDocHTML == format {
+		return ".html"
+	}
+	return ".md"
+}
+
+// SchemaDocPage is a rendered page of schema reference documentation.
+type SchemaDocPage struct {
+	FileName string
+	Title    string
+	Content  string
+}
+
+// Base names of index pages.
+const (
+	schemaDocIndexPage          = "index"
+	schemaDocObjectClassesPage  = "object-classes"
+	schemaDocAttributeTypesPage = "attribute-types"
+)
+
+// schemaDocText is a fragment of inline text. Page is the base name of
+// linked page, empty for plain text.
+type schemaDocText struct {
+	text string
+	page string
+}
+
+type schemaDocInline []schemaDocText
+
+func plainSchemaDocInline(text string) schemaDocInline {
+	return schemaDocInline{{text: text}}
+}
+
+// joinSchemaDocInline concatenates inline texts with separator.
+func joinSchemaDocInline(inlines []schemaDocInline, separator string) (result schemaDocInline) {
+	for idx, inline := range inlines {
+		if idx > 0 {
+			result = append(result, schemaDocText{text: separator})
+		}
+		result = append(result, inline...)
+	}
+	return
+}
+
+type schemaDocField struct {
+	name  string
+	value schemaDocInline
+}
+
+// schemaDocSection holds fields or table of a page. Empty is shown when
+// table has no row.
+type schemaDocSection struct {
+	heading string
+	fields  []*schemaDocField
+	columns []string
+	rows    [][]schemaDocInline
+	empty   string
+}
+
+func (section *schemaDocSection) addField(name string, value schemaDocInline) {
+	if 0 == len(value) {
+		return
+	}
+	section.fields = append(section.fields, &schemaDocField{
+		name:  name,
+		value: value,
+	})
+}
+
+func (section *schemaDocSection) addTextField(name, value string) {
+	if "" == value {
+		return
+	}
+	section.addField(name, plainSchemaDocInline(value))
+}
+
+type schemaDocContent struct {
+	page     string
+	title    string
+	sections []*schemaDocSection
+}
+
+// schemaDocNavigation links to index pages on top of each page.
+var schemaDocNavigation = joinSchemaDocInline([]schemaDocInline{
+	{{text: "Index", page: schemaDocIndexPage}},
+	{{text: "Object Classes", page: schemaDocObjectClassesPage}},
+	{{text: "Attribute Types", page: schemaDocAttributeTypesPage}},
+}, " · ")
+
+var markdownTextEscaper = strings.NewReplacer(
+	"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]",
+	"<", "&lt;", ">", "&gt;", "|", "\\|", "\r", "", "\n", " ")
+
+func writeMarkdownInline(b *strings.Builder, inline schemaDocInline) {
+	for _, fragment := range inline {
+		if "" == fragment.page {
+			b.WriteString(markdownTextEscaper.Replace(fragment.text))
+			continue
+		}
+		b.WriteString("[" + markdownTextEscaper.Replace(fragment.text) + "](" + fragment.page + SchemaDocMarkdown.fileExtension() + ")")
+	}
+}
+
+func (content *schemaDocContent) renderMarkdown() string {
+	var b strings.Builder
+	writeMarkdownInline(&b, schemaDocNavigation)
+	b.WriteString("\n\n# " + markdownTextEscaper.Replace(content.title) + "\n")
+	for _, section := range content.sections {
+		if "" != section.heading {
+			b.WriteString("\n## " + markdownTextEscaper.Replace(section.heading) + "\n")
+		}
+		if len(section.fields) > 0 {
+			b.WriteString("\n")
+			for _, field := range section.fields {
+				b.WriteString("- **" + markdownTextEscaper.Replace(field.name) + "**: ")
+				writeMarkdownInline(&b, field.value)
+				b.WriteString("\n")
+			}
+		}
+		if 0 == len(section.columns) {
+			continue
+		}
+		b.WriteString("\n")
+		if 0 == len(section.rows) {
+			b.WriteString(markdownTextEscaper.Replace(section.empty) + "\n")
+			continue
+		}
+		b.WriteString("|")
+		for _, column := range section.columns {
+			b.WriteString(" " + markdownTextEscaper.Replace(column) + " |")
+		}
+		b.WriteString("\n|")
+		for range section.columns {
+			b.WriteString(" --- |")
+		}
+		b.WriteString("\n")
+		for _, row := range section.rows {
+			b.WriteString("|")
+			for _, cell := range row {
+				b.WriteString(" ")
+				writeMarkdownInline(&b, cell)
+				b.WriteString(" |")
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+func writeHTMLInline(b *strings.Builder, inline schemaDocInline) {
+	for _, fragment := range inline {
+		if "" == fragment.page {
+			b.WriteString(html.EscapeString(fragment.text))
+			continue
+		}
+		b.WriteString("<a href=\"" + html.EscapeString(fragment.page+SchemaDocHTML.fileExtension()) + "\">" + html.EscapeString(fragment.text) + "</a>")
+	}
+}
+
+func (content *schemaDocContent) renderHTML() string {
+	var b strings.Builder
+	title := html.EscapeString(content.title)
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>" + title + "</title>\n</head>\n<body>\n<nav>")
+	writeHTMLInline(&b, schemaDocNavigation)
+	b.WriteString("</nav>\n<h1>" + title + "</h1>\n")
+	for _, section := range content.sections {
+		if "" != section.heading {
+			b.WriteString("<h2>" + html.EscapeString(section.heading) + "</h2>\n")
+		}
+		if len(section.fields) > 0 {
+			b.WriteString("<dl>\n")
+			for _, field := range section.fields {
+				b.WriteString("<dt>" + html.EscapeString(field.name) + "</dt><dd>")
+				writeHTMLInline(&b, field.value)
+				b.WriteString("</dd>\n")
+			}
+			b.WriteString("</dl>\n")
+		}
+		if 0 == len(section.columns) {
+			continue
+		}
+		if 0 == len(section.rows) {
+			b.WriteString("<p>" + html.EscapeString(section.empty) + "</p>\n")
+			continue
+		}
+		b.WriteString("<table>\n<tr>")
+		for _, column := range section.columns {
+			b.WriteString("<th>" + html.EscapeString(column) + "</th>")
+		}
+		b.WriteString("</tr>\n")
+		for _, row := range section.rows {
+			b.WriteString("<tr>")
+			for _, cell := range row {
+				b.WriteString("<td>")
+				writeHTMLInline(&b, cell)
+				b.WriteString("</td>")
+			}
+			b.WriteString("</tr>\n")
+		}
+		b.WriteString("</table>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// schemaDocAttributeUse is an object class listing attribute type in MUST
+// or MAY.
+type schemaDocAttributeUse struct {
+	objectClass *ObjectClassSchema
+	required    bool
+}
+
+type schemaDocGenerator struct {
+	store          *LDAPSchemaStore
+	objectClasses  []*ObjectClassSchema
+	attributeTypes []*AttributeTypeSchema
+	effectives     map[string]*EffectiveAttributeType
+	subClasses     map[string][]*ObjectClassSchema
+	subTypes       map[string][]*AttributeTypeSchema
+	attributeUses  map[string][]*schemaDocAttributeUse
+}
+
+func objectClassDocPage(objectClassSchema *ObjectClassSchema) string {
+	return "objectclass-" + objectClassSchema.ShortIdentifier()
+}
+
+func attributeTypeDocPage(attributeTypeSchema *AttributeTypeSchema) string {
+	return "attributetype-" + attributeTypeSchema.ShortIdentifier()
+}
+
+// prepare loads object classes and attribute types ordered by name and
+// collects reverse references.
+func (generator *schemaDocGenerator) prepare() (err error) {
+	store := generator.store
+	if generator.objectClasses, err = store.ObjectClasses(); nil != err {
+		return
+	}
+	if generator.attributeTypes, err = store.AttributeTypes(); nil != err {
+		return
+	}
+	objectClasses := generator.objectClasses
+	sort.SliceStable(objectClasses, func(i, j int) bool {
+		return strings.ToLower(objectClasses[i].ShortIdentifier()) < strings.ToLower(objectClasses[j].ShortIdentifier())
+	})
+	attributeTypes := generator.attributeTypes
+	sort.SliceStable(attributeTypes, func(i, j int) bool {
+		return strings.ToLower(attributeTypes[i].ShortIdentifier()) < strings.ToLower(attributeTypes[j].ShortIdentifier())
+	})
+	generator.effectives = make(map[string]*EffectiveAttributeType)
+	generator.subTypes = make(map[string][]*AttributeTypeSchema)
+	for _, attributeTypeSchema := range attributeTypes {
+		effective, err := store.resolveEffectiveAttributeType(attributeTypeSchema)
+		if nil != err {
+			return err
+		}
+		generator.effectives[attributeTypeSchema.NumericOID] = effective
+		if "" == attributeTypeSchema.SuperType {
+			continue
+		}
+		if genericSchema := store.findAttributeTypeGenericSchema(attributeTypeSchema.SuperType); nil != genericSchema {
+			generator.subTypes[genericSchema.NumericOID] = append(generator.subTypes[genericSchema.NumericOID], attributeTypeSchema)
+		}
+	}
+	generator.subClasses = make(map[string][]*ObjectClassSchema)
+	generator.attributeUses = make(map[string][]*schemaDocAttributeUse)
+	for _, objectClassSchema := range objectClasses {
+		for _, superClassName := range objectClassSchema.SuperClasses {
+			if genericSchema := store.findObjectClassGenericSchema(superClassName); nil != genericSchema {
+				generator.subClasses[genericSchema.NumericOID] = append(generator.subClasses[genericSchema.NumericOID], objectClassSchema)
+			}
+		}
+		generator.addAttributeUses(objectClassSchema, objectClassSchema.Must, true)
+		generator.addAttributeUses(objectClassSchema, objectClassSchema.May, false)
+	}
+	return nil
+}
+
+func (generator *schemaDocGenerator) addAttributeUses(objectClassSchema *ObjectClassSchema, attrNames []string, required bool) {
+	for _, attrName := range attrNames {
+		genericSchema := generator.store.findAttributeTypeGenericSchema(attrName)
+		if nil == genericSchema {
+			continue
+		}
+		generator.attributeUses[genericSchema.NumericOID] = append(generator.attributeUses[genericSchema.NumericOID], &schemaDocAttributeUse{
+			objectClass: objectClassSchema,
+			required:    required,
+		})
+	}
+}
+
+// objectClassLink links to page of object class of given name or numeric
+// OID. Plain text is returned when object class is not in store.
+func (generator *schemaDocGenerator) objectClassLink(identifier string) schemaDocInline {
+	genericSchema := generator.store.findObjectClassGenericSchema(identifier)
+	if nil == genericSchema {
+		return plainSchemaDocInline(identifier)
+	}
+	objectClassSchema, err := NewObjectClassSchemaViaGenericSchema(genericSchema)
+	if nil != err {
+		return plainSchemaDocInline(identifier)
+	}
+	return schemaDocInline{{text: objectClassSchema.ShortIdentifier(), page: objectClassDocPage(objectClassSchema)}}
+}
+
+// attributeTypeLink links to page of attribute type of given name or
+// numeric OID. Plain text is returned when attribute type is not in store.
+func (generator *schemaDocGenerator) attributeTypeLink(identifier string) schemaDocInline {
+	genericSchema := generator.store.findAttributeTypeGenericSchema(identifier)
+	if nil == genericSchema {
+		return plainSchemaDocInline(identifier)
+	}
+	attributeTypeSchema, err := NewAttributeTypeSchemaViaGenericSchema(genericSchema)
+	if nil != err {
+		return plainSchemaDocInline(identifier)
+	}
+	return schemaDocInline{{text: attributeTypeSchema.ShortIdentifier(), page: attributeTypeDocPage(attributeTypeSchema)}}
+}
+
+func (generator *schemaDocGenerator) objectClassLinks(identifiers []string) schemaDocInline {
+	inlines := make([]schemaDocInline, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		inlines = append(inlines, generator.objectClassLink(identifier))
+	}
+	return joinSchemaDocInline(inlines, ", ")
+}
+
+// matchingRuleText gives name and numeric OID of matching rule.
+func (generator *schemaDocGenerator) matchingRuleText(identifier string) string {
+	if "" == identifier {
+		return ""
+	}
+	genericSchema := generator.store.findMatchingRuleGenericSchema(identifier)
+	if nil == genericSchema {
+		return identifier
+	}
+	if names := genericSchema.getValuesOfParameterizedKeyword("NAME"); len(names) > 0 {
+		return names[0] + " (" + genericSchema.NumericOID + ")"
+	}
+	return genericSchema.NumericOID
+}
+
+// syntaxText gives description, numeric OID and length bound of syntax.
+func (generator *schemaDocGenerator) syntaxText(syntax string) string {
+	if "" == syntax {
+		return ""
+	}
+	syntaxOID, syntaxLength := parseOIDLength(syntax)
+	result := syntaxOID
+	if genericSchema := generator.store.ldapSyntaxSchemaIndex[syntaxOID]; nil != genericSchema {
+		if description := genericSchema.getValueOfParameterizedKeyword("DESC"); "" != description {
+			result = description + " (" + syntaxOID + ")"
+		}
+	}
+	if syntaxLength > 0 {
+		result += ", length " + strconv.FormatInt(int64(syntaxLength), 10)
+	}
+	return result
+}
+
+// effectiveFieldInline renders value of effective field with the attribute
+// type it is inherited from.
+func (generator *schemaDocGenerator) effectiveFieldInline(field *EffectiveAttributeTypeField, text string) schemaDocInline {
+	if "" == text {
+		return nil
+	}
+	result := plainSchemaDocInline(text)
+	if field.Inherited() {
+		result = append(result, schemaDocText{text: " (inherited from "})
+		result = append(result, generator.attributeTypeLink(field.Source)...)
+		result = append(result, schemaDocText{text: ")"})
+	}
+	return result
+}
+
+func schemaDocOrigin(extensions map[string][]string) string {
+	return strings.Join(extensions[extensionKeywordOrigin], ", ")
+}
+
+func (generator *schemaDocGenerator) indexContent() *schemaDocContent {
+	section := &schemaDocSection{}
+	section.addField("Object Classes", schemaDocInline{{
+		text: strconv.Itoa(len(generator.objectClasses)) + " object classes",
+		page: schemaDocObjectClassesPage,
+	}})
+	section.addField("Attribute Types", schemaDocInline{{
+		text: strconv.Itoa(len(generator.attributeTypes)) + " attribute types",
+		page: schemaDocAttributeTypesPage,
+	}})
+	return &schemaDocContent{
+		page:     schemaDocIndexPage,
+		title:    "LDAP Schema Reference",
+		sections: []*schemaDocSection{section},
+	}
+}
+
+func (generator *schemaDocGenerator) objectClassesContent() *schemaDocContent {
+	section := &schemaDocSection{
+		columns: []string{"Name", "OID", "Kind", "Description"},
+		empty:   "No object class.",
+	}
+	for _, objectClassSchema := range generator.objectClasses {
+		section.rows = append(section.rows, []schemaDocInline{
+			{{text: objectClassSchema.ShortIdentifier(), page: objectClassDocPage(objectClassSchema)}},
+			plainSchemaDocInline(objectClassSchema.NumericOID),
+			plainSchemaDocInline(objectClassSchema.ClassKind),
+			plainSchemaDocInline(objectClassSchema.Description),
+		})
+	}
+	return &schemaDocContent{
+		page:     schemaDocObjectClassesPage,
+		title:    "Object Classes",
+		sections: []*schemaDocSection{section},
+	}
+}
+
+func (generator *schemaDocGenerator) attributeTypesContent() *schemaDocContent {
+	section := &schemaDocSection{
+		columns: []string{"Name", "OID", "Syntax", "Description"},
+		empty:   "No attribute type.",
+	}
+	for _, attributeTypeSchema := range generator.attributeTypes {
+		effective := generator.effectives[attributeTypeSchema.NumericOID]
+		section.rows = append(section.rows, []schemaDocInline{
+			{{text: attributeTypeSchema.ShortIdentifier(), page: attributeTypeDocPage(attributeTypeSchema)}},
+			plainSchemaDocInline(attributeTypeSchema.NumericOID),
+			plainSchemaDocInline(generator.syntaxText(effective.Syntax.Value)),
+			plainSchemaDocInline(attributeTypeSchema.Description),
+		})
+	}
+	return &schemaDocContent{
+		page:     schemaDocAttributeTypesPage,
+		title:    "Attribute Types",
+		sections: []*schemaDocSection{section},
+	}
+}
+
+// objectClassContent renders object class with attribute types of the
+// class and all its superclasses. Superclasses not in store are listed as
+// text and attribute types of resolvable classes are still rendered.
+func (generator *schemaDocGenerator) objectClassContent(objectClassSchema *ObjectClassSchema) (content *schemaDocContent, err error) {
+	fields := &schemaDocSection{}
+	fields.addTextField("OID", objectClassSchema.NumericOID)
+	fields.addTextField("Names", strings.Join(objectClassSchema.Name, ", "))
+	fields.addTextField("Description", objectClassSchema.Description)
+	fields.addTextField("Kind", objectClassSchema.ClassKind)
+	if objectClassSchema.Obsolete {
+		fields.addTextField("Flags", "OBSOLETE")
+	}
+	fields.addField("Superior Classes", generator.objectClassLinks(objectClassSchema.SuperClasses))
+	var subClasses []string
+	for _, subClass := range generator.subClasses[objectClassSchema.NumericOID] {
+		subClasses = append(subClasses, subClass.NumericOID)
+	}
+	fields.addField("Subclasses", generator.objectClassLinks(subClasses))
+	fields.addTextField("Origin", schemaDocOrigin(objectClassSchema.Extensions))
+	closure, err := generator.store.partialObjectClassClosure(objectClassSchema.NumericOID)
+	if nil != err {
+		return
+	}
+	fields.addTextField("Missing Classes", strings.Join(closure.missingObjectClasses, ", "))
+	attributes := &schemaDocSection{
+		heading: "Attributes",
+		columns: []string{"Attribute", "Required", "Syntax", "Single Value", "Declared In"},
+		empty:   "No attribute type.",
+	}
+	addAttribute := func(attr *ObjectClassClosureAttribute, required string) {
+		row := []schemaDocInline{
+			plainSchemaDocInline(attr.Name),
+			plainSchemaDocInline(required),
+			nil,
+			nil,
+			generator.objectClassLinks(attr.ContributedBy),
+		}
+		if effective := generator.effectives[attr.NumericOID]; nil != effective {
+			row[0] = generator.attributeTypeLink(attr.NumericOID)
+			row[2] = plainSchemaDocInline(generator.syntaxText(effective.Syntax.Value))
+			if effective.Schema.SingleValue {
+				row[3] = plainSchemaDocInline("yes")
+			}
+		}
+		attributes.rows = append(attributes.rows, row)
+	}
+	for _, attr := range closure.Must {
+		addAttribute(attr, "yes")
+	}
+	for _, attr := range closure.May {
+		addAttribute(attr, "")
+	}
+	return &schemaDocContent{
+		page:     objectClassDocPage(objectClassSchema),
+		title:    "Object Class " + objectClassSchema.ShortIdentifier(),
+		sections: []*schemaDocSection{fields, attributes},
+	}, nil
+}
+
+// attributeTypeContent renders attribute type with effective syntax and
+// matching rules, and object classes listing the attribute type.
+func (generator *schemaDocGenerator) attributeTypeContent(attributeTypeSchema *AttributeTypeSchema) *schemaDocContent {
+	effective := generator.effectives[attributeTypeSchema.NumericOID]
+	fields := &schemaDocSection{}
+	fields.addTextField("OID", attributeTypeSchema.NumericOID)
+	fields.addTextField("Names", strings.Join(attributeTypeSchema.Name, ", "))
+	fields.addTextField("Description", attributeTypeSchema.Description)
+	if "" != attributeTypeSchema.SuperType {
+		fields.addField("Superior Type", generator.attributeTypeLink(attributeTypeSchema.SuperType))
+	}
+	var subTypes []schemaDocInline
+	for _, subType := range generator.subTypes[attributeTypeSchema.NumericOID] {
+		subTypes = append(subTypes, generator.attributeTypeLink(subType.NumericOID))
+	}
+	fields.addField("Subtypes", joinSchemaDocInline(subTypes, ", "))
+	fields.addField("Syntax", generator.effectiveFieldInline(&effective.Syntax, generator.syntaxText(effective.Syntax.Value)))
+	fields.addField("Equality", generator.effectiveFieldInline(&effective.Equality, generator.matchingRuleText(effective.Equality.Value)))
+	fields.addField("Ordering", generator.effectiveFieldInline(&effective.Ordering, generator.matchingRuleText(effective.Ordering.Value)))
+	fields.addField("Substring", generator.effectiveFieldInline(&effective.SubString, generator.matchingRuleText(effective.SubString.Value)))
+	var flags []string
+	if attributeTypeSchema.Obsolete {
+		flags = append(flags, "OBSOLETE")
+	}
+	if attributeTypeSchema.SingleValue {
+		flags = append(flags, "SINGLE-VALUE")
+	}
+	if attributeTypeSchema.Collective {
+		flags = append(flags, "COLLECTIVE")
+	}
+	if attributeTypeSchema.NoUserModification {
+		flags = append(flags, "NO-USER-MODIFICATION")
+	}
+	fields.addTextField("Flags", strings.Join(flags, ", "))
+	fields.addTextField("Usage", attributeTypeSchema.Usage)
+	fields.addTextField("Origin", schemaDocOrigin(attributeTypeSchema.Extensions))
+	usedBy := &schemaDocSection{
+		heading: "Used By",
+		columns: []string{"Object Class", "Required", "Kind"},
+		empty:   "Not used by any object class.",
+	}
+	for _, attributeUse := range generator.attributeUses[attributeTypeSchema.NumericOID] {
+		required := ""
+		if attributeUse.required {
+			required = "yes"
+		}
+		usedBy.rows = append(usedBy.rows, []schemaDocInline{
+			{{text: attributeUse.objectClass.ShortIdentifier(), page: objectClassDocPage(attributeUse.objectClass)}},
+			plainSchemaDocInline(required),
+			plainSchemaDocInline(attributeUse.objectClass.ClassKind),
+		})
+	}
+	return &schemaDocContent{
+		page:     attributeTypeDocPage(attributeTypeSchema),
+		title:    "Attribute Type " + attributeTypeSchema.ShortIdentifier(),
+		sections: []*schemaDocSection{fields, usedBy},
+	}
+}
+
+func (generator *schemaDocGenerator) contents() (contents []*schemaDocContent, err error) {
+	contents = []*schemaDocContent{
+		generator.indexContent(),
+		generator.objectClassesContent(),
+		generator.attributeTypesContent(),
+	}
+	for _, objectClassSchema := range generator.objectClasses {
+		content, err := generator.objectClassContent(objectClassSchema)
+		if nil != err {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+	for _, attributeTypeSchema := range generator.attributeTypes {
+		contents = append(contents, generator.attributeTypeContent(attributeTypeSchema))
+	}
+	return contents, nil
+}
+
+// GenerateSchemaDoc renders object classes and attribute types in store as
+// schema reference documentation in given format. One page is made for
+// each object class and attribute type in addition to index pages. Pages
+// are cross-linked with the first name of schema elements.
+func (store *LDAPSchemaStore) GenerateSchemaDoc(format SchemaDocFormat) (pages []*SchemaDocPage, err error) {
+	if (SchemaDocMarkdown != format) && (SchemaDocHTML != format) {
+		return nil, errors.New("unknown schema document format: " + string(format))
+	}
+	generator := &schemaDocGenerator{
+		store: store,
+	}
+	if err = generator.prepare(); nil != err {
+		return
+	}
+	contents, err := generator.contents()
+	if nil != err {
+		return
+	}
+	for _, content := range contents {
+		page := &SchemaDocPage{
+			FileName: content.page + format.fileExtension(),
+			Title:    content.title,
+		}
+		if SchemaDocHTML == format {
+			page.Content = content.renderHTML()
+		} else {
+			page.Content = content.renderMarkdown()
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+// WriteSchemaDoc writes schema reference documentation into folder at given
+// path. The folder is created when not exist.
+func (store *LDAPSchemaStore) WriteSchemaDoc(dirPath string, format SchemaDocFormat) (err error) {
+	pages, err := store.GenerateSchemaDoc(format)
+	if nil != err {
+		return
+	}
+	if err = os.MkdirAll(dirPath, 0755); nil != err {
+		return
+	}
+	for _, page := range pages {
+		if err = ioutil.WriteFile(filepath.Join(dirPath, page.FileName), []byte(page.Content), 0644); nil != err {
+			return
+		}
+	}
+	return nil
+}
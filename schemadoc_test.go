@@ -0,0 +1,158 @@
+package ldapschemaparser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func findSchemaDocPage(t *testing.T, pages []*SchemaDocPage, fileName string) *SchemaDocPage {
+	for _, page := range pages {
+		if page.FileName == fileName {
+			return page
+		}
+	}
+	t.Fatalf("cannot find page %s", fileName)
+	return nil
+}
+
+func TestLDAPSchemaStoreGenerateSchemaDoc_1(t *testing.T) {
+	store := makeSchemaGraphSampleStore(t)
+	if err := store.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.9 NAME 'sampleSerial' DESC 'serial | number' SUP name SINGLE-VALUE X-ORIGIN 'sample' )"); nil != err {
+		t.Fatalf("failed on adding attribute type: %v", err)
+	}
+	if err := store.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.3 NAME 'sampleSerialDevice' SUP sampleDevice MUST sampleSerial X-ORIGIN ( 'sample' 'RFC 0000' ) )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	pages, err := store.GenerateSchemaDoc(SchemaDocMarkdown)
+	if nil != err {
+		t.Fatalf("failed on generating schema document: %v", err)
+	}
+	if len(pages) != 11 {
+		t.Errorf("unexpected page count: %d", len(pages))
+	}
+	expect := `[Index](index.md) · [Object Classes](object-classes.md) · [Attribute Types](attribute-types.md)
+
+# Attribute Type sampleSerial
+
+- **OID**: 1.3.6.1.4.1.99999.1.9
+- **Names**: sampleSerial
+- **Description**: serial \| number
+- **Superior Type**: [name](attributetype-name.md)
+- **Syntax**: Directory String (1.3.6.1.4.1.1466.115.121.1.15) (inherited from [name](attributetype-name.md))
+- **Equality**: caseIgnoreMatch (2.5.13.2) (inherited from [name](attributetype-name.md))
+- **Flags**: SINGLE-VALUE
+- **Usage**: userApplications
+- **Origin**: sample
+
+## Used By
+
+| Object Class | Required | Kind |
+| --- | --- | --- |
+| [sampleSerialDevice](objectclass-sampleSerialDevice.md) | yes | STRUCTURAL |
+`
+	if v := findSchemaDocPage(t, pages, "attributetype-sampleSerial.md").Content; v != expect {
+		t.Errorf("expecting:\n%v\nbut have:\n%v", expect, v)
+	}
+	expect = `[Index](index.md) · [Object Classes](object-classes.md) · [Attribute Types](attribute-types.md)
+
+# Object Class sampleSerialDevice
+
+- **OID**: 1.3.6.1.4.1.99999.2.3
+- **Names**: sampleSerialDevice
+- **Kind**: STRUCTURAL
+- **Superior Classes**: [sampleDevice](objectclass-sampleDevice.md)
+- **Origin**: sample, RFC 0000
+
+## Attributes
+
+| Attribute | Required | Syntax | Single Value | Declared In |
+| --- | --- | --- | --- | --- |
+| [sampleSerial](attributetype-sampleSerial.md) | yes | Directory String (1.3.6.1.4.1.1466.115.121.1.15) | yes | [sampleSerialDevice](objectclass-sampleSerialDevice.md) |
+| [cn](attributetype-cn.md) | yes | Directory String (1.3.6.1.4.1.1466.115.121.1.15) |  | [sampleDevice](objectclass-sampleDevice.md) |
+| [description](attributetype-description.md) |  | Directory String (1.3.6.1.4.1.1466.115.121.1.15) |  | [sampleDevice](objectclass-sampleDevice.md) |
+`
+	if v := findSchemaDocPage(t, pages, "objectclass-sampleSerialDevice.md").Content; v != expect {
+		t.Errorf("expecting:\n%v\nbut have:\n%v", expect, v)
+	}
+	if v := findSchemaDocPage(t, pages, "attributetype-name.md").Content; !strings.Contains(v, "- **Subtypes**: [cn](attributetype-cn.md), [sampleSerial](attributetype-sampleSerial.md)\n") ||
+		!strings.Contains(v, "Not used by any object class.\n") {
+		t.Errorf("unexpected page of attribute type name:\n%v", v)
+	}
+	if v := findSchemaDocPage(t, pages, "object-classes.md").Content; !strings.Contains(v, "| [sampleDevice](objectclass-sampleDevice.md) | 1.3.6.1.4.1.99999.2.1 | STRUCTURAL |  |\n") {
+		t.Errorf("unexpected object class index:\n%v", v)
+	}
+}
+
+func TestLDAPSchemaStoreGenerateSchemaDoc_2(t *testing.T) {
+	store := makeSchemaGraphSampleStore(t)
+	pages, err := store.GenerateSchemaDoc(SchemaDocHTML)
+	if nil != err {
+		t.Fatalf("failed on generating schema document: %v", err)
+	}
+	content := findSchemaDocPage(t, pages, "attributetype-cn.html").Content
+	for _, expect := range []string{
+		"<title>Attribute Type cn</title>",
+		"<dt>Superior Type</dt><dd><a href=\"attributetype-name.html\">name</a></dd>",
+		"<tr><td><a href=\"objectclass-sampleDevice.html\">sampleDevice</a></td><td>yes</td><td>STRUCTURAL</td></tr>",
+	} {
+		if !strings.Contains(content, expect) {
+			t.Errorf("expecting %q in page:\n%v", expect, content)
+		}
+	}
+	if _, err = store.GenerateSchemaDoc(SchemaDocFormat("pdf")); nil == err {
+		t.Error("expecting error for unknown format")
+	}
+	dirPath, err := ioutil.TempDir("", "schemadoc")
+	if nil != err {
+		t.Fatalf("cannot create temporary folder: %v", err)
+	}
+	defer os.RemoveAll(dirPath)
+	if err = store.WriteSchemaDoc(dirPath, SchemaDocHTML); nil != err {
+		t.Fatalf("failed on writing schema document: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(dirPath, "index.html")); nil != err {
+		t.Errorf("expecting index page: %v", err)
+	}
+}
+
+func TestLDAPSchemaStoreGenerateSchemaDoc_3(t *testing.T) {
+	store := makeSchemaGraphSampleStore(t)
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.2.5 NAME 'sampleEmployee' SUP person STRUCTURAL MUST cn )",
+		"( 1.3.6.1.4.1.99999.2.6 NAME 'sampleManager' SUP sampleEmployee STRUCTURAL MAY description )",
+	} {
+		if err := store.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	pages, err := store.GenerateSchemaDoc(SchemaDocMarkdown)
+	if nil != err {
+		t.Fatalf("failed on generating schema document: %v", err)
+	}
+	expect := `[Index](index.md) · [Object Classes](object-classes.md) · [Attribute Types](attribute-types.md)
+
+# Object Class sampleManager
+
+- **OID**: 1.3.6.1.4.1.99999.2.6
+- **Names**: sampleManager
+- **Kind**: STRUCTURAL
+- **Superior Classes**: [sampleEmployee](objectclass-sampleEmployee.md)
+- **Missing Classes**: person
+
+## Attributes
+
+| Attribute | Required | Syntax | Single Value | Declared In |
+| --- | --- | --- | --- | --- |
+| [cn](attributetype-cn.md) | yes | Directory String (1.3.6.1.4.1.1466.115.121.1.15) |  | [sampleEmployee](objectclass-sampleEmployee.md) |
+| [description](attributetype-description.md) |  | Directory String (1.3.6.1.4.1.1466.115.121.1.15) |  | [sampleManager](objectclass-sampleManager.md) |
+`
+	if v := findSchemaDocPage(t, pages, "objectclass-sampleManager.md").Content; v != expect {
+		t.Errorf("expecting:\n%v\nbut have:\n%v", expect, v)
+	}
+	if v := findSchemaDocPage(t, pages, "objectclass-sampleEmployee.md").Content; !strings.Contains(v, "- **Superior Classes**: person\n") {
+		t.Errorf("expecting missing superclass as text:\n%v", v)
+	}
+}
@@ -0,0 +1,367 @@
+package ldapschemaparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaGraphEdgeType indicates the type of reference between schema elements
+type SchemaGraphEdgeType string
+
+// Types of edges in schema dependency graph.
+const (
+	SchemaGraphEdgeSuperClass        SchemaGraphEdgeType = "sup"
+	SchemaGraphEdgeMust              SchemaGraphEdgeType = "must"
+	SchemaGraphEdgeMay               SchemaGraphEdgeType = "may"
+	SchemaGraphEdgeSuperType         SchemaGraphEdgeType = "sup-type"
+	SchemaGraphEdgeEquality          SchemaGraphEdgeType = "equality"
+	SchemaGraphEdgeOrdering          SchemaGraphEdgeType = "ordering"
+	SchemaGraphEdgeSubstring         SchemaGraphEdgeType = "substr"
+	SchemaGraphEdgeSyntax            SchemaGraphEdgeType = "syntax"
+	SchemaGraphEdgeNameFormClass     SchemaGraphEdgeType = "oc"
+	SchemaGraphEdgeStructureRuleForm SchemaGraphEdgeType = "form"
+	SchemaGraphEdgeContentRuleAux    SchemaGraphEdgeType = "aux"
+)
+
+// SchemaGraphNode is a schema element in dependency graph. ID is the kind
+// and numeric OID (or rule ID) of element joined with colon. Missing is set
+// for referenced element not in store, which has the referenced name as
+// identifier.
+type SchemaGraphNode struct {
+	ID         string     `json:"id"`
+	Kind       SchemaKind `json:"kind"`
+	Identifier string     `json:"identifier"`
+	Name       string     `json:"name,omitempty"`
+	Missing    bool       `json:"missing,omitempty"`
+}
+
+// Label returns name of node or identifier when node has no name.
+func (node *SchemaGraphNode) Label() string {
+	if "" != node.Name {
+		return node.Name
+	}
+	return node.Identifier
+}
+
+// SchemaGraphEdge is a reference from one schema element to another.
+type SchemaGraphEdge struct {
+	From string              `json:"from"`
+	To   string              `json:"to"`
+	Type SchemaGraphEdgeType `json:"type"`
+}
+
+// SchemaGraph is the dependency graph of schema elements in store.
+// Nodes are ordered by ID and edges are ordered as added.
+type SchemaGraph struct {
+	Nodes []*SchemaGraphNode `json:"nodes"`
+	Edges []*SchemaGraphEdge `json:"edges"`
+}
+
+type schemaGraphBuilder struct {
+	store     *LDAPSchemaStore
+	nodeIndex map[string]*SchemaGraphNode
+	edges     []*SchemaGraphEdge
+	edgeIndex map[SchemaGraphEdge]bool
+}
+
+func schemaGraphNodeID(kind SchemaKind, identifier string) string {
+	return string(kind) + ":" + identifier
+}
+
+func (builder *schemaGraphBuilder) addNode(kind SchemaKind, identifier string, names []string, missing bool) *SchemaGraphNode {
+	nodeID := schemaGraphNodeID(kind, identifier)
+	if node := builder.nodeIndex[nodeID]; nil != node {
+		return node
+	}
+	node := &SchemaGraphNode{
+		ID:         nodeID,
+		Kind:       kind,
+		Identifier: identifier,
+		Missing:    missing,
+	}
+	if len(names) > 0 {
+		node.Name = names[0]
+	}
+	builder.nodeIndex[nodeID] = node
+	return node
+}
+
+// addGenericNode adds node of schema element in store.
+func (builder *schemaGraphBuilder) addGenericNode(kind SchemaKind, genericSchema *GenericSchema) *SchemaGraphNode {
+	return builder.addNode(kind, genericSchema.NumericOID, genericSchema.getValuesOfParameterizedKeyword("NAME"), false)
+}
+
+// addEdge adds edge from node to referenced element. The referenced element
+// is resolved with find and added as missing node when not found.
+func (builder *schemaGraphBuilder) addEdge(from *SchemaGraphNode, edgeType SchemaGraphEdgeType, kind SchemaKind, find func(identifier string) *GenericSchema, targets ...string) {
+	for _, target := range targets {
+		if "" == target {
+			continue
+		}
+		var to *SchemaGraphNode
+		if genericSchema := find(target); nil != genericSchema {
+			to = builder.addGenericNode(kind, genericSchema)
+		} else {
+			to = builder.addNode(kind, target, nil, true)
+		}
+		edge := SchemaGraphEdge{
+			From: from.ID,
+			To:   to.ID,
+			Type: edgeType,
+		}
+		if builder.edgeIndex[edge] {
+			continue
+		}
+		builder.edgeIndex[edge] = true
+		builder.edges = append(builder.edges, &edge)
+	}
+}
+
+func (builder *schemaGraphBuilder) findLDAPSyntax(identifier string) *GenericSchema {
+	return builder.store.ldapSyntaxSchemaIndex[identifier]
+}
+
+func (builder *schemaGraphBuilder) build() (err error) {
+	store := builder.store
+	for _, oid := range sortedMapKey(store.ldapSyntaxSchemaIndex) {
+		builder.addNode(SchemaKindLDAPSyntax, oid, nil, false)
+	}
+	for _, oid := range sortedMapKey(store.matchingRuleSchemaIndex) {
+		builder.addGenericNode(SchemaKindMatchingRule, store.matchingRuleSchemaIndex[oid])
+	}
+	attributeTypeSchemas, err := store.AttributeTypes()
+	if nil != err {
+		return
+	}
+	for _, s := range attributeTypeSchemas {
+		node := builder.addGenericNode(SchemaKindAttributeType, store.attributeTypeSchemaIndex[s.NumericOID])
+		builder.addEdge(node, SchemaGraphEdgeSuperType, SchemaKindAttributeType, store.findAttributeTypeGenericSchema, s.SuperType)
+		builder.addEdge(node, SchemaGraphEdgeEquality, SchemaKindMatchingRule, store.findMatchingRuleGenericSchema, s.Equality)
+		builder.addEdge(node, SchemaGraphEdgeOrdering, SchemaKindMatchingRule, store.findMatchingRuleGenericSchema, s.Ordering)
+		builder.addEdge(node, SchemaGraphEdgeSubstring, SchemaKindMatchingRule, store.findMatchingRuleGenericSchema, s.SubString)
+		builder.addEdge(node, SchemaGraphEdgeSyntax, SchemaKindLDAPSyntax, builder.findLDAPSyntax, s.SyntaxOID)
+	}
+	objectClassSchemas, err := store.ObjectClasses()
+	if nil != err {
+		return
+	}
+	for _, s := range objectClassSchemas {
+		node := builder.addGenericNode(SchemaKindObjectClass, store.objectClassSchemaIndex[s.NumericOID])
+		builder.addEdge(node, SchemaGraphEdgeSuperClass, SchemaKindObjectClass, store.findObjectClassGenericSchema, s.SuperClasses...)
+		builder.addEdge(node, SchemaGraphEdgeMust, SchemaKindAttributeType, store.findAttributeTypeGenericSchema, s.Must...)
+		builder.addEdge(node, SchemaGraphEdgeMay, SchemaKindAttributeType, store.findAttributeTypeGenericSchema, s.May...)
+	}
+	nameFormSchemas, err := store.NameForms()
+	if nil != err {
+		return
+	}
+	for _, s := range nameFormSchemas {
+		node := builder.addGenericNode(SchemaKindNameForm, store.nameFormSchemaIndex[s.NumericOID])
+		builder.addEdge(node, SchemaGraphEdgeNameFormClass, SchemaKindObjectClass, store.findObjectClassGenericSchema, s.ObjectClass)
+	}
+	ditStructureRuleSchemas, err := store.DITStructureRules()
+	if nil != err {
+		return
+	}
+	for _, s := range ditStructureRuleSchemas {
+		node := builder.addGenericNode(SchemaKindDITStructureRule, store.ditStructureRuleSchemaIndex[s.RuleID])
+		builder.addEdge(node, SchemaGraphEdgeStructureRuleForm, SchemaKindNameForm, store.findNameFormGenericSchema, s.NameForm)
+	}
+	ditContentRuleSchemas, err := store.DITContentRules()
+	if nil != err {
+		return
+	}
+	for _, s := range ditContentRuleSchemas {
+		node := builder.addGenericNode(SchemaKindDITContentRule, store.ditContentRuleSchemaIndex[s.NumericOID])
+		builder.addEdge(node, SchemaGraphEdgeContentRuleAux, SchemaKindObjectClass, store.findObjectClassGenericSchema, s.Aux...)
+	}
+	return nil
+}
+
+// newSchemaGraph creates graph with nodes sorted by ID.
+func newSchemaGraph(nodes []*SchemaGraphNode, edges []*SchemaGraphEdge) *SchemaGraph {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID < nodes[j].ID
+	})
+	return &SchemaGraph{
+		Nodes: nodes,
+		Edges: edges,
+	}
+}
+
+// BuildSchemaGraph builds dependency graph of schema elements in store. Edges
+// are object class SUP, MUST and MAY, attribute type SUP, matching rules
+// and syntax, name form OC, DIT structure rule FORM and DIT content rule
+// AUX. Referenced elements not in store are included as missing nodes.
+func (store *LDAPSchemaStore) BuildSchemaGraph() (graph *SchemaGraph, err error) {
+	builder := &schemaGraphBuilder{
+		store:     store,
+		nodeIndex: make(map[string]*SchemaGraphNode),
+		edgeIndex: make(map[SchemaGraphEdge]bool),
+	}
+	if err = builder.build(); nil != err {
+		return
+	}
+	nodes := make([]*SchemaGraphNode, 0, len(builder.nodeIndex))
+	for _, node := range builder.nodeIndex {
+		nodes = append(nodes, node)
+	}
+	return newSchemaGraph(nodes, builder.edges), nil
+}
+
+// findNodes returns IDs of nodes matching given identifier by ID, numeric
+// OID or name. Names are matched case-insensitively.
+func (graph *SchemaGraph) findNodes(identifier string) (result map[string]bool) {
+	result = make(map[string]bool)
+	for _, node := range graph.Nodes {
+		if (node.ID == identifier) || (node.Identifier == identifier) || strings.EqualFold(node.Name, identifier) {
+			result[node.ID] = true
+		}
+	}
+	return
+}
+
+// subGraph creates graph of given nodes and edges between them.
+func (graph *SchemaGraph) subGraph(nodeIDs map[string]bool, keepEdge func(edge *SchemaGraphEdge) bool) *SchemaGraph {
+	nodes := make([]*SchemaGraphNode, 0, len(nodeIDs))
+	for _, node := range graph.Nodes {
+		if nodeIDs[node.ID] {
+			nodes = append(nodes, node)
+		}
+	}
+	edges := make([]*SchemaGraphEdge, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		if nodeIDs[edge.From] && nodeIDs[edge.To] && keepEdge(edge) {
+			edges = append(edges, edge)
+		}
+	}
+	return newSchemaGraph(nodes, edges)
+}
+
+// Neighbourhood returns graph of nodes reachable within depth edges from
+// nodes of given identifier, in either direction. Identifier is matched
+// against node ID, numeric OID or name. Negative depth means unlimited.
+func (graph *SchemaGraph) Neighbourhood(identifier string, depth int) *SchemaGraph {
+	adjacent := make(map[string][]string)
+	for _, edge := range graph.Edges {
+		adjacent[edge.From] = append(adjacent[edge.From], edge.To)
+		adjacent[edge.To] = append(adjacent[edge.To], edge.From)
+	}
+	reached := graph.findNodes(identifier)
+	frontier := make([]string, 0, len(reached))
+	for nodeID := range reached {
+		frontier = append(frontier, nodeID)
+	}
+	for distance := 0; (len(frontier) > 0) && ((depth < 0) || (distance < depth)); distance++ {
+		var next []string
+		for _, nodeID := range frontier {
+			for _, adjacentID := range adjacent[nodeID] {
+				if !reached[adjacentID] {
+					reached[adjacentID] = true
+					next = append(next, adjacentID)
+				}
+			}
+		}
+		frontier = next
+	}
+	return graph.subGraph(reached, func(edge *SchemaGraphEdge) bool { return true })
+}
+
+// DropEdgesTo returns graph without edges pointing to nodes of given
+// identifiers (eg. `top`). Such nodes are removed when no edge remains.
+func (graph *SchemaGraph) DropEdgesTo(identifiers ...string) *SchemaGraph {
+	dropped := make(map[string]bool)
+	for _, identifier := range identifiers {
+		for nodeID := range graph.findNodes(identifier) {
+			dropped[nodeID] = true
+		}
+	}
+	connected := make(map[string]bool)
+	for _, edge := range graph.Edges {
+		if !dropped[edge.To] {
+			connected[edge.From] = true
+			connected[edge.To] = true
+		}
+	}
+	nodeIDs := make(map[string]bool)
+	for _, node := range graph.Nodes {
+		if !dropped[node.ID] || connected[node.ID] {
+			nodeIDs[node.ID] = true
+		}
+	}
+	return graph.subGraph(nodeIDs, func(edge *SchemaGraphEdge) bool { return !dropped[edge.To] })
+}
+
+var schemaGraphDOTNodeShapes = map[SchemaKind]string{
+	SchemaKindLDAPSyntax:       "note",
+	SchemaKindMatchingRule:     "diamond",
+	SchemaKindAttributeType:    "ellipse",
+	SchemaKindObjectClass:      "box",
+	SchemaKindDITContentRule:   "component",
+	SchemaKindDITStructureRule: "hexagon",
+	SchemaKindNameForm:         "parallelogram",
+}
+
+// WriteDOT writes graph in Graphviz DOT language.
+func (graph *SchemaGraph) WriteDOT(w io.Writer) (err error) {
+	var b strings.Builder
+	b.WriteString("digraph \"ldap-schema\" {\n\trankdir=LR;\n")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&b, "\t%s [label=%s, shape=%s", strconv.Quote(node.ID), strconv.Quote(node.Label()), schemaGraphDOTNodeShapes[node.Kind])
+		if node.Missing {
+			b.WriteString(", style=dashed")
+		}
+		b.WriteString("];\n")
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", strconv.Quote(edge.From), strconv.Quote(edge.To), strconv.Quote(string(edge.Type)))
+	}
+	b.WriteString("}\n")
+	_, err = io.WriteString(w, b.String())
+	return
+}
+
+// escapeMermaidLabel replaces double quote which cannot be escaped in
+// quoted Mermaid label.
+func escapeMermaidLabel(label string) string {
+	return strings.Replace(label, "\"", "#quot;", -1)
+}
+
+// WriteMermaid writes graph as Mermaid flowchart. Nodes are named with
+// sequence numbers as node IDs of graph are not valid Mermaid identifiers.
+func (graph *SchemaGraph) WriteMermaid(w io.Writer) (err error) {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	mermaidIDs := make(map[string]string)
+	for idx, node := range graph.Nodes {
+		mermaidID := "n" + strconv.Itoa(idx)
+		mermaidIDs[node.ID] = mermaidID
+		label := escapeMermaidLabel(node.Label())
+		switch node.Kind {
+		case SchemaKindAttributeType:
+			fmt.Fprintf(&b, "    %s([\"%s\"])\n", mermaidID, label)
+		case SchemaKindMatchingRule:
+			fmt.Fprintf(&b, "    %s{\"%s\"}\n", mermaidID, label)
+		case SchemaKindLDAPSyntax:
+			fmt.Fprintf(&b, "    %s[/\"%s\"/]\n", mermaidID, label)
+		default:
+			fmt.Fprintf(&b, "    %s[\"%s\"]\n", mermaidID, label)
+		}
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&b, "    %s -->|%s| %s\n", mermaidIDs[edge.From], edge.Type, mermaidIDs[edge.To])
+	}
+	_, err = io.WriteString(w, b.String())
+	return
+}
+
+// WriteJSON writes nodes and edges of graph in JSON.
+func (graph *SchemaGraph) WriteJSON(w io.Writer) (err error) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(graph)
+}
@@ -0,0 +1,145 @@
+package ldapschemaparser
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func makeSchemaGraphSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := NewLDAPSchemaStore()
+	if err := store.AddLDAPSyntaxSchemaText("( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )"); nil != err {
+		t.Fatalf("failed on adding LDAP syntax: %v", err)
+	}
+	if err := store.AddMatchingRuleSchemaText("( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )"); nil != err {
+		t.Fatalf("failed on adding matching rule: %v", err)
+	}
+	for _, schemaText := range []string{
+		"( 2.5.4.41 NAME 'name' EQUALITY caseIgnoreMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 2.5.4.3 NAME 'cn' SUP name )",
+		"( 2.5.4.13 NAME 'description' EQUALITY caseIgnoreMatch SUBSTR caseIgnoreSubstringsMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 2.5.6.0 NAME 'top' ABSTRACT )",
+		"( 1.3.6.1.4.1.99999.2.1 NAME 'sampleDevice' SUP top STRUCTURAL MUST cn MAY description )",
+		"( 1.3.6.1.4.1.99999.2.2 NAME 'sampleExtra' SUP top AUXILIARY MAY description )",
+	} {
+		if err := store.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	if err := store.AddNameFormSchemaText("( 1.3.6.1.4.1.99999.3.1 NAME 'sampleDeviceNameForm' OC sampleDevice MUST cn )"); nil != err {
+		t.Fatalf("failed on adding name form: %v", err)
+	}
+	if err := store.AddDITStructureRuleSchemaText("( 1 NAME 'sampleDeviceRule' FORM sampleDeviceNameForm )"); nil != err {
+		t.Fatalf("failed on adding DIT structure rule: %v", err)
+	}
+	if err := store.AddDITContentRuleSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleDeviceContentRule' AUX sampleExtra )"); nil != err {
+		t.Fatalf("failed on adding DIT content rule: %v", err)
+	}
+	return store
+}
+
+func schemaGraphEdgesText(graph *SchemaGraph) string {
+	labels := make(map[string]string)
+	for _, node := range graph.Nodes {
+		labels[node.ID] = node.Label()
+	}
+	texts := make([]string, 0, len(graph.Edges))
+	for _, edge := range graph.Edges {
+		texts = append(texts, labels[edge.From]+"-"+string(edge.Type)+"->"+labels[edge.To])
+	}
+	return strings.Join(texts, " ")
+}
+
+func TestLDAPSchemaStoreBuildSchemaGraph_1(t *testing.T) {
+	store := makeSchemaGraphSampleStore(t)
+	graph, err := store.BuildSchemaGraph()
+	if nil != err {
+		t.Fatalf("failed on building schema graph: %v", err)
+	}
+	if len(graph.Nodes) != 12 {
+		t.Errorf("unexpected node count %d: %v", len(graph.Nodes), graph.Nodes)
+	}
+	expect := "cn-sup-type->name description-equality->caseIgnoreMatch description-substr->caseIgnoreSubstringsMatch description-syntax->1.3.6.1.4.1.1466.115.121.1.15 " +
+		"name-equality->caseIgnoreMatch name-syntax->1.3.6.1.4.1.1466.115.121.1.15 " +
+		"sampleDevice-sup->top sampleDevice-must->cn sampleDevice-may->description sampleExtra-sup->top sampleExtra-may->description " +
+		"sampleDeviceNameForm-oc->sampleDevice sampleDeviceRule-form->sampleDeviceNameForm sampleDeviceContentRule-aux->sampleExtra"
+	if v := schemaGraphEdgesText(graph); v != expect {
+		t.Errorf("expecting edges %v but have %v", expect, v)
+	}
+	for _, node := range graph.Nodes {
+		if node.Missing != (node.Identifier == "caseIgnoreSubstringsMatch") {
+			t.Errorf("unexpected missing flag of node %v", node)
+		}
+	}
+	if v := schemaGraphEdgesText(graph.Neighbourhood("SAMPLEEXTRA", 1)); v != "sampleExtra-sup->top sampleExtra-may->description sampleDeviceContentRule-aux->sampleExtra" {
+		t.Errorf("unexpected neighbourhood: %v", v)
+	}
+	neighbourhood := graph.Neighbourhood("2.5.4.3", 1)
+	if (len(neighbourhood.Nodes) != 3) || (schemaGraphEdgesText(neighbourhood) != "cn-sup-type->name sampleDevice-must->cn") {
+		t.Errorf("unexpected neighbourhood: %v %v", neighbourhood.Nodes, schemaGraphEdgesText(neighbourhood))
+	}
+	if v := graph.Neighbourhood("cn", -1); len(v.Nodes) != 12 {
+		t.Errorf("expecting all nodes connected: %v", v.Nodes)
+	}
+	dropped := graph.DropEdgesTo("top")
+	if strings.Contains(schemaGraphEdgesText(dropped), "->top") || (len(dropped.Nodes) != 11) {
+		t.Errorf("unexpected graph after dropping edges to top: %v", schemaGraphEdgesText(dropped))
+	}
+}
+
+func TestSchemaGraphWrite_1(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	store.AddAttributeTypeSchemaText("( 2.5.4.3 NAME 'cn' SUP sampleMissing )")
+	store.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleDevice' STRUCTURAL MUST cn )")
+	graph, err := store.BuildSchemaGraph()
+	if nil != err {
+		t.Fatalf("failed on building schema graph: %v", err)
+	}
+	var b strings.Builder
+	if err = graph.WriteDOT(&b); nil != err {
+		t.Fatalf("failed on writing DOT: %v", err)
+	}
+	expect := `digraph "ldap-schema" {
+	rankdir=LR;
+	"attribute-type:2.5.4.3" [label="cn", shape=ellipse];
+	"attribute-type:sampleMissing" [label="sampleMissing", shape=ellipse, style=dashed];
+	"object-class:1.3.6.1.4.1.99999.2.1" [label="sampleDevice", shape=box];
+	"attribute-type:2.5.4.3" -> "attribute-type:sampleMissing" [label="sup-type"];
+	"object-class:1.3.6.1.4.1.99999.2.1" -> "attribute-type:2.5.4.3" [label="must"];
+}
+`
+	if v := b.String(); v != expect {
+		t.Errorf("expecting DOT:\n%v\nbut have:\n%v", expect, v)
+	}
+	b.Reset()
+	if err = graph.WriteMermaid(&b); nil != err {
+		t.Fatalf("failed on writing Mermaid: %v", err)
+	}
+	expect = `flowchart LR
+    n0(["cn"])
+    n1(["sampleMissing"])
+    n2["sampleDevice"]
+    n0 -->|sup-type| n1
+    n2 -->|must| n0
+`
+	if v := b.String(); v != expect {
+		t.Errorf("expecting Mermaid:\n%v\nbut have:\n%v", expect, v)
+	}
+	b.Reset()
+	if err = graph.WriteJSON(&b); nil != err {
+		t.Fatalf("failed on writing JSON: %v", err)
+	}
+	var loaded SchemaGraph
+	if err = json.Unmarshal([]byte(b.String()), &loaded); nil != err {
+		t.Fatalf("failed on loading JSON: %v", err)
+	}
+	if (len(loaded.Nodes) != 3) || (len(loaded.Edges) != 2) || !loaded.Nodes[1].Missing || (loaded.Edges[1].Type != SchemaGraphEdgeMust) {
+		t.Errorf("unexpected graph loaded from JSON: %v", b.String())
+	}
+}
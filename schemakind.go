@@ -0,0 +1,149 @@
+package ldapschemaparser
+
+// SchemaKind identifies the kind of schema element
+type SchemaKind string
+
+// Kinds of schema elements
+const (
+	SchemaKindLDAPSyntax       SchemaKind = SchemaKind(recordTypeLDAPSyntaxSchema)
+	SchemaKindMatchingRule     SchemaKind = SchemaKind(recordTypeMatchingRuleSchema)
+	SchemaKindMatchingRuleUse  SchemaKind = SchemaKind(recordTypeMatchingRuleUseSchema)
+	SchemaKindAttributeType    SchemaKind = SchemaKind(recordTypeAttributeTypeSchema)
+	SchemaKindObjectClass      SchemaKind = SchemaKind(recordTypeObjectClassSchema)
+	SchemaKindDITContentRule   SchemaKind = SchemaKind(recordTypeDITContentRuleSchema)
+	SchemaKindDITStructureRule SchemaKind = SchemaKind(recordTypeDITStructureRuleSchema)
+	SchemaKindNameForm         SchemaKind = SchemaKind(recordTypeNameFormSchema)
+)
+
+type schemaKindDescriptor struct {
+	kind        SchemaKind
+	attrName    string
+	index       func(store *LDAPSchemaStore) map[string]*GenericSchema
+	typedSchema func(generic *GenericSchema) (interface{}, error)
+
+	// collect returns function collecting schema texts in sorted key order.
+	collect func(store *LDAPSchemaStore) func(stopOnError bool) ([]string, error)
+
+	// dependencies returns keys of elements of the same kind the element
+	// of given key depends on. Nil when elements do not refer each other.
+	dependencies func(store *LDAPSchemaStore, key string) []string
+
+	// serverMaintained is set for kind maintained by directory server which
+	// is not written into schema modification LDIF.
+	serverMaintained bool
+}
+
+// schemaKindDescriptors describes all kinds of schema elements held in
+// LDAPSchemaStore in the order of dependency.
+var schemaKindDescriptors = []*schemaKindDescriptor{
+	{
+		kind:     SchemaKindLDAPSyntax,
+		attrName: "ldapSyntaxes",
+		index:    func(store *LDAPSchemaStore) map[string]*GenericSchema { return store.ldapSyntaxSchemaIndex },
+		typedSchema: func(generic *GenericSchema) (interface{}, error) {
+			return NewLDAPSyntaxSchemaViaGenericSchema(generic)
+		},
+		collect: func(store *LDAPSchemaStore) func(bool) ([]string, error) {
+			return store.collectLDAPSyntaxSchemaTexts
+		},
+	},
+	{
+		kind:     SchemaKindMatchingRule,
+		attrName: "matchingRules",
+		index:    func(store *LDAPSchemaStore) map[string]*GenericSchema { return store.matchingRuleSchemaIndex },
+		typedSchema: func(generic *GenericSchema) (interface{}, error) {
+			return NewMatchingRuleSchemaViaGenericSchema(generic)
+		},
+		collect: func(store *LDAPSchemaStore) func(bool) ([]string, error) {
+			return store.collectMatchingRuleSchemaTexts
+		},
+	},
+	{
+		kind:     SchemaKindMatchingRuleUse,
+		attrName: "matchingRuleUse",
+		index:    func(store *LDAPSchemaStore) map[string]*GenericSchema { return store.matchingRuleUseSchemaIndex },
+		typedSchema: func(generic *GenericSchema) (interface{}, error) {
+			return NewMatchingRuleUseSchemaViaGenericSchema(generic)
+		},
+		collect: func(store *LDAPSchemaStore) func(bool) ([]string, error) {
+			return store.collectMatchingRuleUseSchemaTexts
+		},
+		serverMaintained: true,
+	},
+	{
+		kind:     SchemaKindAttributeType,
+		attrName: "attributeTypes",
+		index:    func(store *LDAPSchemaStore) map[string]*GenericSchema { return store.attributeTypeSchemaIndex },
+		typedSchema: func(generic *GenericSchema) (interface{}, error) {
+			return NewAttributeTypeSchemaViaGenericSchema(generic)
+		},
+		collect: func(store *LDAPSchemaStore) func(bool) ([]string, error) {
+			return store.collectAttributeTypeSchemaTexts
+		},
+		dependencies: func(store *LDAPSchemaStore, oid string) []string {
+			attributeTypeSchema, err := NewAttributeTypeSchemaViaGenericSchema(store.attributeTypeSchemaIndex[oid])
+			if nil != err {
+				return nil
+			}
+			return store.attributeTypeDependencies(attributeTypeSchema)
+		},
+	},
+	{
+		kind:     SchemaKindObjectClass,
+		attrName: "objectClasses",
+		index:    func(store *LDAPSchemaStore) map[string]*GenericSchema { return store.objectClassSchemaIndex },
+		typedSchema: func(generic *GenericSchema) (interface{}, error) {
+			return NewObjectClassSchemaViaGenericSchema(generic)
+		},
+		collect: func(store *LDAPSchemaStore) func(bool) ([]string, error) {
+			return store.collectObjectClassSchemaTexts
+		},
+		dependencies: func(store *LDAPSchemaStore, oid string) []string {
+			objectClassSchema, err := NewObjectClassSchemaViaGenericSchema(store.objectClassSchemaIndex[oid])
+			if nil != err {
+				return nil
+			}
+			return store.objectClassDependencies(objectClassSchema)
+		},
+	},
+	{
+		kind:     SchemaKindDITContentRule,
+		attrName: "dITContentRules",
+		index:    func(store *LDAPSchemaStore) map[string]*GenericSchema { return store.ditContentRuleSchemaIndex },
+		typedSchema: func(generic *GenericSchema) (interface{}, error) {
+			return NewDITContentRuleSchemaViaGenericSchema(generic)
+		},
+		collect: func(store *LDAPSchemaStore) func(bool) ([]string, error) {
+			return store.collectDITContentRuleSchemaTexts
+		},
+	},
+	{
+		kind:     SchemaKindNameForm,
+		attrName: "nameForms",
+		index:    func(store *LDAPSchemaStore) map[string]*GenericSchema { return store.nameFormSchemaIndex },
+		typedSchema: func(generic *GenericSchema) (interface{}, error) {
+			return NewNameFormSchemaViaGenericSchema(generic)
+		},
+		collect: func(store *LDAPSchemaStore) func(bool) ([]string, error) {
+			return store.collectNameFormSchemaTexts
+		},
+	},
+	{
+		kind:     SchemaKindDITStructureRule,
+		attrName: "dITStructureRules",
+		index:    func(store *LDAPSchemaStore) map[string]*GenericSchema { return store.ditStructureRuleSchemaIndex },
+		typedSchema: func(generic *GenericSchema) (interface{}, error) {
+			return NewDITStructureRuleSchemaViaGenericSchema(generic)
+		},
+		collect: func(store *LDAPSchemaStore) func(bool) ([]string, error) {
+			return store.collectDITStructureRuleSchemaTexts
+		},
+		dependencies: func(store *LDAPSchemaStore, ruleID string) []string {
+			ditStructureRuleSchema, err := NewDITStructureRuleSchemaViaGenericSchema(store.ditStructureRuleSchemaIndex[ruleID])
+			if nil != err {
+				return nil
+			}
+			return ditStructureRuleSchema.SuperRules
+		},
+	},
+}
@@ -0,0 +1,196 @@
+package ldapschemaparser
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+)
+
+// SchemaModificationLDIFOption configures output of schema modification LDIF.
+type SchemaModificationLDIFOption struct {
+	// DN of subschema entry. Default to `cn=subschema` when empty.
+	DN string
+}
+
+func (option *SchemaModificationLDIFOption) entryDN() string {
+	if (nil == option) || ("" == option.DN) {
+		return "cn=subschema"
+	}
+	return option.DN
+}
+
+// schemaTextMap maps OID (or rule ID) to schema text.
+type schemaTextMap map[string]string
+
+// makeSchemaTextMap pairs result of collect function with keys of index.
+// Collect functions iterate index in sorted key order and do not skip
+// elements when stopOnError is set, so i-th text belongs to i-th key.
+func makeSchemaTextMap(index map[string]*GenericSchema, collect func(stopOnError bool) ([]string, error)) (result schemaTextMap, err error) {
+	schemaTexts, err := collect(true)
+	if nil != err {
+		return
+	}
+	result = make(schemaTextMap)
+	for idx, k := range sortedMapKey(index) {
+		result[k] = schemaTexts[idx]
+	}
+	return
+}
+
+type schemaTextMapDiff struct {
+	deletes []string
+	adds    []string
+}
+
+// diffSchemaTextMap compare texts of current and target.
+// Keys of modified elements are placed into both deletes and adds.
+func diffSchemaTextMap(current, target schemaTextMap) (diff schemaTextMapDiff) {
+	for _, k := range sortedStringMapKey(current) {
+		if targetText, ok := target[k]; !ok || (targetText != current[k]) {
+			diff.deletes = append(diff.deletes, k)
+		}
+	}
+	for _, k := range sortedStringMapKey(target) {
+		if currentText, ok := current[k]; !ok || (currentText != target[k]) {
+			diff.adds = append(diff.adds, k)
+		}
+	}
+	return
+}
+
+func sortedStringMapKey(m schemaTextMap) (result []string) {
+	result = make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return
+}
+
+func reverseStrings(s []string) []string {
+	result := make([]string, len(s))
+	for idx, v := range s {
+		result[len(s)-1-idx] = v
+	}
+	return result
+}
+
+// orderKeys sorts keys of elements in dependency order.
+func (descriptor *schemaKindDescriptor) orderKeys(store *LDAPSchemaStore, keys []string) (result []string, err error) {
+	if nil == descriptor.dependencies {
+		return keys, nil
+	}
+	result, cycle := dependencyOrder(keys, func(k string) []string {
+		return descriptor.dependencies(store, k)
+	})
+	if nil != cycle {
+		return nil, &ErrCyclicSuperior{
+			Kind:  descriptor.kind,
+			Chain: cycle,
+		}
+	}
+	return result, nil
+}
+
+type schemaModification struct {
+	operation string
+	attrName  string
+	values    []string
+}
+
+func makeSchemaModifications(current, target *LDAPSchemaStore) (deletes, adds []*schemaModification, err error) {
+	// Kinds are added in the order of descriptors and deleted in reverse order.
+	for _, descriptor := range schemaKindDescriptors {
+		if descriptor.serverMaintained {
+			continue
+		}
+		currentTexts, err := makeSchemaTextMap(descriptor.index(current), descriptor.collect(current))
+		if nil != err {
+			return nil, nil, err
+		}
+		targetTexts, err := makeSchemaTextMap(descriptor.index(target), descriptor.collect(target))
+		if nil != err {
+			return nil, nil, err
+		}
+		diff := diffSchemaTextMap(currentTexts, targetTexts)
+		if len(diff.deletes) > 0 {
+			mod := &schemaModification{
+				operation: "delete",
+				attrName:  descriptor.attrName,
+			}
+			orderedKeys, err := descriptor.orderKeys(current, diff.deletes)
+			if nil != err {
+				return nil, nil, err
+			}
+			for _, k := range reverseStrings(orderedKeys) {
+				mod.values = append(mod.values, currentTexts[k])
+			}
+			deletes = append([]*schemaModification{mod}, deletes...)
+		}
+		if len(diff.adds) > 0 {
+			mod := &schemaModification{
+				operation: "add",
+				attrName:  descriptor.attrName,
+			}
+			orderedKeys, err := descriptor.orderKeys(target, diff.adds)
+			if nil != err {
+				return nil, nil, err
+			}
+			for _, k := range orderedKeys {
+				mod.values = append(mod.values, targetTexts[k])
+			}
+			adds = append(adds, mod)
+		}
+	}
+	return
+}
+
+// WriteSchemaModificationLDIF writes a `changetype: modify` LDIF record which
+// moves subschema of a server from current store to target store.
+// Elements are deleted in reverse dependency order and then added in
+// dependency order. Modified elements are deleted and added again.
+// Nothing is written when two stores have the same content.
+func WriteSchemaModificationLDIF(w io.Writer, current, target *LDAPSchemaStore, option *SchemaModificationLDIFOption) (err error) {
+	deletes, adds, err := makeSchemaModifications(current, target)
+	if nil != err {
+		return
+	}
+	if (0 == len(deletes)) && (0 == len(adds)) {
+		return nil
+	}
+	if err = writeLDIFAttributeValue(w, "dn", option.entryDN()); nil != err {
+		return
+	}
+	if err = writeLDIFLine(w, "changetype: modify"); nil != err {
+		return
+	}
+	for _, mod := range append(deletes, adds...) {
+		if err = writeLDIFLine(w, mod.operation+": "+mod.attrName); nil != err {
+			return
+		}
+		if err = writeLDIFAttributeValues(w, mod.attrName, mod.values); nil != err {
+			return
+		}
+		if err = writeLDIFLine(w, "-"); nil != err {
+			return
+		}
+	}
+	_, err = io.WriteString(w, "\n")
+	return
+}
+
+// WriteToSchemaModificationLDIFFile write schema modification LDIF which
+// moves subschema from current store to target store into file at given path.
+func WriteToSchemaModificationLDIFFile(name string, current, target *LDAPSchemaStore, option *SchemaModificationLDIFOption) (err error) {
+	fp, err := os.Create(name)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	w := bufio.NewWriter(fp)
+	if err = WriteSchemaModificationLDIF(w, current, target, option); nil != err {
+		return
+	}
+	return w.Flush()
+}
@@ -0,0 +1,68 @@
+package ldapschemaparser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteSchemaModificationLDIF_1(t *testing.T) {
+	current := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleBase' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 1.3.6.1.4.1.99999.1.3 NAME 'sampleObsolete' SUP sampleBase )",
+		"( 1.3.6.1.4.1.99999.1.4 NAME 'sampleObsoleteSub' SUP sampleObsolete )",
+	} {
+		if err := current.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	target := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleSub' SUP sampleBase )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleBase' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 1.3.6.1.4.1.99999.1.5 NAME 'sampleLeaf' SUP sampleSub )",
+	} {
+		if err := target.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	if err := target.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleObject' AUXILIARY MAY sampleLeaf )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteSchemaModificationLDIF(&buf, current, target, nil); nil != err {
+		t.Fatalf("failed on writing LDIF: %v", err)
+	}
+	expect := "dn: cn=subschema\n" +
+		"changetype: modify\n" +
+		"delete: attributeTypes\n" +
+		"attributeTypes: ( 1.3.6.1.4.1.99999.1.4 NAME 'sampleObsoleteSub' SUP sampleO\n" +
+		" bsolete )\n" +
+		"attributeTypes: ( 1.3.6.1.4.1.99999.1.3 NAME 'sampleObsolete' SUP sampleBase\n" +
+		"  )\n" +
+		"-\n" +
+		"add: attributeTypes\n" +
+		"attributeTypes: ( 1.3.6.1.4.1.99999.1.1 NAME 'sampleSub' SUP sampleBase )\n" +
+		"attributeTypes: ( 1.3.6.1.4.1.99999.1.5 NAME 'sampleLeaf' SUP sampleSub )\n" +
+		"-\n" +
+		"add: objectClasses\n" +
+		"objectClasses: ( 1.3.6.1.4.1.99999.2.1 NAME 'sampleObject' AUXILIARY MAY sam\n" +
+		" pleLeaf )\n" +
+		"-\n" +
+		"\n"
+	if v := buf.String(); v != expect {
+		t.Errorf("unexpected LDIF:\n%v\nexpecting:\n%v", v, expect)
+	}
+}
+
+func TestWriteSchemaModificationLDIF_2(t *testing.T) {
+	current := makeOpenLDAPConfigSampleStore(t)
+	target := makeOpenLDAPConfigSampleStore(t)
+	var buf bytes.Buffer
+	if err := WriteSchemaModificationLDIF(&buf, current, target, nil); nil != err {
+		t.Fatalf("failed on writing LDIF: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expecting empty output for identical stores: %v", buf.String())
+	}
+}
@@ -0,0 +1,240 @@
+package ldapschemaparser
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// schemaTextReader splits parenthesized schema definitions out of a rune stream.
+type schemaTextReader struct {
+	reader *bufio.Reader
+
+	offset      int
+	line        int
+	column      int
+	atLineStart bool
+}
+
+func newSchemaTextReader(r io.Reader) *schemaTextReader {
+	return &schemaTextReader{
+		reader:      bufio.NewReader(r),
+		line:        1,
+		column:      1,
+		atLineStart: true,
+	}
+}
+
+func (textReader *schemaTextReader) next() (ch rune, err error) {
+	if ch, _, err = textReader.reader.ReadRune(); nil != err {
+		return
+	}
+	textReader.offset++
+	if ch == '\n' {
+		textReader.line++
+		textReader.column = 1
+		textReader.atLineStart = true
+	} else {
+		textReader.column++
+		if !unicode.IsSpace(ch) {
+			textReader.atLineStart = false
+		}
+	}
+	return
+}
+
+// skipComment drops runes till end of line. Count of dropped runes is returned.
+func (textReader *schemaTextReader) skipComment() (dropped int, err error) {
+	for {
+		ch, err := textReader.next()
+		if nil != err {
+			return dropped, err
+		}
+		if ch == '\n' {
+			return dropped, nil
+		}
+		dropped++
+	}
+}
+
+// readDefinition fetch next parenthesized definition.
+// Text outside definitions which is not comment is returned as leading text.
+func (textReader *schemaTextReader) readDefinition() (leading string, definition []rune, offset, line, column int, err error) {
+	var leadingRunes []rune
+	for {
+		lineStart := textReader.atLineStart
+		ch, err := textReader.next()
+		if nil != err {
+			return strings.TrimSpace(string(leadingRunes)), nil, textReader.offset, textReader.line, textReader.column, err
+		}
+		if (ch == '#') && lineStart {
+			if _, err = textReader.skipComment(); nil != err {
+				return strings.TrimSpace(string(leadingRunes)), nil, textReader.offset, textReader.line, textReader.column, err
+			}
+			leadingRunes = append(leadingRunes, '\n')
+			continue
+		}
+		if ch == '(' {
+			offset = textReader.offset - 1
+			line = textReader.line
+			column = textReader.column - 1
+			break
+		}
+		leadingRunes = append(leadingRunes, ch)
+	}
+	leading = strings.TrimSpace(string(leadingRunes))
+	definition = append(definition, '(')
+	depth := 1
+	var quoteChar rune
+	for depth > 0 {
+		lineStart := textReader.atLineStart
+		ch, err := textReader.next()
+		if nil != err {
+			if io.EOF == err {
+				err = &ParseError{
+					Message: "unexpected end of input within schema definition",
+					Offset:  offset,
+					Line:    line,
+					Column:  column,
+					Token:   "(",
+					Snippet: string(definition),
+				}
+			}
+			return leading, nil, offset, line, column, err
+		}
+		if quoteChar != 0 {
+			if ch == quoteChar {
+				quoteChar = 0
+			}
+			definition = append(definition, ch)
+			continue
+		}
+		switch ch {
+		case '#':
+			if lineStart {
+				// keep rune offsets and columns for position of parse error
+				dropped, err := textReader.skipComment()
+				if (nil != err) && (io.EOF != err) {
+					return leading, nil, offset, line, column, err
+				}
+				for idx := 0; idx <= dropped; idx++ {
+					definition = append(definition, ' ')
+				}
+				definition = append(definition, '\n')
+				continue
+			}
+		case '\'', '"':
+			quoteChar = ch
+		case '(':
+			depth++
+		case ')':
+			depth--
+		}
+		definition = append(definition, ch)
+	}
+	return leading, definition, offset, line, column, nil
+}
+
+// SchemaScanner reads schema definitions from io.Reader one at a time.
+//
+// Definitions may span several lines. Blank lines and lines start with `#`
+// are skipped. Text outside of parenthesized definitions is available via
+// LeadingText().
+type SchemaScanner struct {
+	textReader *schemaTextReader
+
+	schema  *GenericSchema
+	text    string
+	leading string
+	offset  int
+	line    int
+	column  int
+	err     error
+}
+
+// NewSchemaScanner create an instance of SchemaScanner reading from given reader.
+func NewSchemaScanner(r io.Reader) *SchemaScanner {
+	return &SchemaScanner{
+		textReader: newSchemaTextReader(r),
+	}
+}
+
+func adjustParseErrorPosition(parseError *ParseError, offset, line, column int) {
+	if parseError.Line == 1 {
+		parseError.Column += column - 1
+	}
+	parseError.Offset += offset
+	parseError.Line += line - 1
+}
+
+// Scan advances to next schema definition.
+// It returns false when scanning stopped by end of input or error. Text
+// after the last definition is kept in LeadingText().
+func (scanner *SchemaScanner) Scan() bool {
+	if nil != scanner.err {
+		return false
+	}
+	leading, definition, offset, line, column, err := scanner.textReader.readDefinition()
+	scanner.schema = nil
+	scanner.text = ""
+	scanner.leading = leading
+	scanner.offset = offset
+	scanner.line = line
+	scanner.column = column
+	if nil != err {
+		scanner.err = err
+		return false
+	}
+	scanner.text = string(definition)
+	genericSchema, err := parseRunes(definition)
+	if nil != err {
+		if parseError, ok := err.(*ParseError); ok {
+			adjustParseErrorPosition(parseError, offset, line, column)
+		}
+		scanner.err = err
+		return false
+	}
+	scanner.schema = genericSchema
+	return true
+}
+
+// Schema returns generic schema of current definition.
+func (scanner *SchemaScanner) Schema() *GenericSchema {
+	return scanner.schema
+}
+
+// Text returns source text of current definition.
+func (scanner *SchemaScanner) Text() string {
+	return scanner.text
+}
+
+// LeadingText returns trimmed non-comment text between previous definition
+// and current definition. After Scan returns false at end of input, it
+// returns text after the last definition.
+func (scanner *SchemaScanner) LeadingText() string {
+	return scanner.leading
+}
+
+// Offset returns rune offset of current definition in input.
+func (scanner *SchemaScanner) Offset() int {
+	return scanner.offset
+}
+
+// Line returns line number of current definition in input.
+func (scanner *SchemaScanner) Line() int {
+	return scanner.line
+}
+
+// Column returns column of current definition in input.
+func (scanner *SchemaScanner) Column() int {
+	return scanner.column
+}
+
+// Err returns the error stopped scanning. Reaching end of input is not an error.
+func (scanner *SchemaScanner) Err() error {
+	if io.EOF == scanner.err {
+		return nil
+	}
+	return scanner.err
+}
@@ -0,0 +1,90 @@
+package ldapschemaparser
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleSchemaScannerInput1 = `# leading comment
+( 2.5.4.41 NAME 'name'
+  EQUALITY caseIgnoreMatch
+# comment inside definition
+  SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32768} )
+
+attributetype ( 2.5.4.3 NAME ( 'cn' 'commonName' ) DESC 'has ( paren'
+  SUP name )
+`
+
+func TestSchemaScanner_1(t *testing.T) {
+	scanner := NewSchemaScanner(strings.NewReader(sampleSchemaScannerInput1))
+	if !scanner.Scan() {
+		t.Fatalf("expecting first definition: %v", scanner.Err())
+	}
+	if s := scanner.Schema(); (s.NumericOID != "2.5.4.41") || (s.getValueOfParameterizedKeyword("SYNTAX") != "1.3.6.1.4.1.1466.115.121.1.15{32768}") {
+		t.Errorf("unexpected first definition: %#v", s)
+	}
+	if (scanner.Offset() != 18) || (scanner.Line() != 2) || (scanner.Column() != 1) {
+		t.Errorf("unexpected position of first definition: offset=%d, line=%d, column=%d", scanner.Offset(), scanner.Line(), scanner.Column())
+	}
+	if !scanner.Scan() {
+		t.Fatalf("expecting second definition: %v", scanner.Err())
+	}
+	if s := scanner.Schema(); (s.NumericOID != "2.5.4.3") || (s.getValueOfParameterizedKeyword("DESC") != "has ( paren") {
+		t.Errorf("unexpected second definition: %#v", s)
+	}
+	if scanner.LeadingText() != "attributetype" {
+		t.Errorf("unexpected leading text: %q", scanner.LeadingText())
+	}
+	if scanner.Line() != 7 {
+		t.Errorf("expecting second definition at line 7 but have %d", scanner.Line())
+	}
+	if scanner.Scan() {
+		t.Errorf("expecting end of input but have %v", scanner.Text())
+	}
+	if err := scanner.Err(); nil != err {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSchemaScanner_2(t *testing.T) {
+	scanner := NewSchemaScanner(strings.NewReader("( 1.2.3 NAME 'a' )\n\n( 1.2.4\n  NAME )\n"))
+	if !scanner.Scan() {
+		t.Fatalf("expecting first definition: %v", scanner.Err())
+	}
+	if scanner.Scan() {
+		t.Fatal("expecting parse error on second definition")
+	}
+	parseError, ok := scanner.Err().(*ParseError)
+	if !ok {
+		t.Fatalf("expecting *ParseError but have %T: %v", scanner.Err(), scanner.Err())
+	}
+	if (parseError.Line != 4) || (parseError.Column != 8) {
+		t.Errorf("expecting line 4 column 8 but have line %d column %d", parseError.Line, parseError.Column)
+	}
+}
+
+func TestSchemaScanner_3(t *testing.T) {
+	scanner := NewSchemaScanner(strings.NewReader("( 1.2.3 NAME 'a'"))
+	if scanner.Scan() {
+		t.Fatal("expecting unterminated definition error")
+	}
+	if _, ok := scanner.Err().(*ParseError); !ok {
+		t.Errorf("expecting *ParseError but have %T: %v", scanner.Err(), scanner.Err())
+	}
+}
+
+func TestSchemaScanner_4(t *testing.T) {
+	scanner := NewSchemaScanner(strings.NewReader("( 1.2.3 NAME 'a' )\n# comment\ntrailing text\n# comment"))
+	if !scanner.Scan() {
+		t.Fatalf("expecting first definition: %v", scanner.Err())
+	}
+	if scanner.Scan() {
+		t.Fatalf("expecting end of input but have %v", scanner.Text())
+	}
+	if err := scanner.Err(); nil != err {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if scanner.LeadingText() != "trailing text" {
+		t.Errorf("expecting trailing text but have %q", scanner.LeadingText())
+	}
+}
@@ -1,7 +1,6 @@
 package ldapschemaparser
 
 import (
-	"bufio"
 	"encoding/json"
 	"errors"
 	"io"
@@ -33,19 +32,77 @@ func sortedMapKey(m map[string]*GenericSchema) (result []string) {
 	return
 }
 
+// sortedRuleIDMapKey returns keys of map in numeric order of rule ID.
+func sortedRuleIDMapKey(m map[string]*GenericSchema) (result []string) {
+	result = sortedMapKey(m)
+	sort.SliceStable(result, func(i, j int) bool {
+		return len(result[i]) < len(result[j])
+	})
+	return
+}
+
+// lessNumericOID compares numeric OIDs arc by arc in numeric order.
+func lessNumericOID(a, b string) bool {
+	arcsA := strings.Split(a, ".")
+	arcsB := strings.Split(b, ".")
+	for idx := 0; (idx < len(arcsA)) && (idx < len(arcsB)); idx++ {
+		if arcA, arcB := arcsA[idx], arcsB[idx]; arcA != arcB {
+			if len(arcA) != len(arcB) {
+				return len(arcA) < len(arcB)
+			}
+			return arcA < arcB
+		}
+	}
+	return len(arcsA) < len(arcsB)
+}
+
+// sortedOIDMapKey returns keys of map in numeric order of OID arcs.
+func sortedOIDMapKey(m map[string]*GenericSchema) (result []string) {
+	result = sortedMapKey(m)
+	sort.SliceStable(result, func(i, j int) bool {
+		return lessNumericOID(result[i], result[j])
+	})
+	return
+}
+
+// indexSchemaNames puts names of schema into name index in lowercase.
+// Name used by another schema is over-written with warning.
+func indexSchemaNames(indexName string, nameIndex map[string]*GenericSchema, names []string, genericSchema *GenericSchema) {
+	for _, name := range names {
+		lowercaseName := strings.ToLower(name)
+		if existedSchema := nameIndex[lowercaseName]; nil != existedSchema {
+			if existedSchema == genericSchema {
+				continue
+			}
+			log.Printf("WARN: over-writing %s (name=%v): %v <= %v", indexName, name, existedSchema, genericSchema)
+		}
+		nameIndex[lowercaseName] = genericSchema
+	}
+}
+
 // LDAPSchemaStore is a container of LDAP schemas
 type LDAPSchemaStore struct {
 	ldapSyntaxSchemaIndex       map[string]*GenericSchema
 	matchingRuleSchemaIndex     map[string]*GenericSchema
 	matchingRuleNameIndex       map[string]*GenericSchema
 	matchingRuleUseSchemaIndex  map[string]*GenericSchema
+	matchingRuleUseNameIndex    map[string]*GenericSchema
 	attributeTypeSchemaIndex    map[string]*GenericSchema
 	attributeTypeNameIndex      map[string]*GenericSchema
 	objectClassSchemaIndex      map[string]*GenericSchema
 	objectClassNameIndex        map[string]*GenericSchema
 	ditContentRuleSchemaIndex   map[string]*GenericSchema
+	ditContentRuleNameIndex     map[string]*GenericSchema
 	ditStructureRuleSchemaIndex map[string]*GenericSchema
+	ditStructureRuleNameIndex   map[string]*GenericSchema
 	nameFormSchemaIndex         map[string]*GenericSchema
+	nameFormNameIndex           map[string]*GenericSchema
+
+	syntaxValidators   *SyntaxValidatorRegistry
+	matchingRuleEngine *MatchingRuleEngine
+
+	// Verbose enables logging of schemas pulled by ExtractClosure.
+	Verbose bool
 }
 
 // NewLDAPSchemaStore create an instance of LDAPSchemaStore
@@ -55,13 +112,17 @@ func NewLDAPSchemaStore() (store *LDAPSchemaStore) {
 		matchingRuleSchemaIndex:     make(map[string]*GenericSchema),
 		matchingRuleNameIndex:       make(map[string]*GenericSchema),
 		matchingRuleUseSchemaIndex:  make(map[string]*GenericSchema),
+		matchingRuleUseNameIndex:    make(map[string]*GenericSchema),
 		attributeTypeSchemaIndex:    make(map[string]*GenericSchema),
 		attributeTypeNameIndex:      make(map[string]*GenericSchema),
 		objectClassSchemaIndex:      make(map[string]*GenericSchema),
 		objectClassNameIndex:        make(map[string]*GenericSchema),
 		ditContentRuleSchemaIndex:   make(map[string]*GenericSchema),
+		ditContentRuleNameIndex:     make(map[string]*GenericSchema),
 		ditStructureRuleSchemaIndex: make(map[string]*GenericSchema),
+		ditStructureRuleNameIndex:   make(map[string]*GenericSchema),
 		nameFormSchemaIndex:         make(map[string]*GenericSchema),
+		nameFormNameIndex:           make(map[string]*GenericSchema),
 	}
 }
 
@@ -100,16 +161,7 @@ func (store *LDAPSchemaStore) addMatchingRuleGenericSchema(genericSchema *Generi
 	} else {
 		store.matchingRuleSchemaIndex[matchingRuleSchema.NumericOID] = genericSchema
 	}
-	for _, name := range matchingRuleSchema.Name {
-		lowercaseName := strings.ToLower(name)
-		if existedSchema = store.matchingRuleNameIndex[lowercaseName]; nil != existedSchema {
-			if existedSchema == genericSchema {
-				return nil
-			}
-			log.Printf("WARN: over-writing matchingRuleNameIndex (name=%v): %v <= %v", name, existedSchema, genericSchema)
-		}
-		store.matchingRuleNameIndex[lowercaseName] = genericSchema
-	}
+	indexSchemaNames("matchingRuleNameIndex", store.matchingRuleNameIndex, matchingRuleSchema.Name, genericSchema)
 	return nil
 }
 
@@ -130,9 +182,11 @@ func (store *LDAPSchemaStore) addMatchingRuleUseGenericSchema(genericSchema *Gen
 	existedSchema := store.matchingRuleUseSchemaIndex[matchingRuleUseSchema.NumericOID]
 	if nil != existedSchema {
 		existedSchema.add(genericSchema)
+		genericSchema = existedSchema
 	} else {
 		store.matchingRuleUseSchemaIndex[matchingRuleUseSchema.NumericOID] = genericSchema
 	}
+	indexSchemaNames("matchingRuleUseNameIndex", store.matchingRuleUseNameIndex, matchingRuleUseSchema.Name, genericSchema)
 	return nil
 }
 
@@ -157,16 +211,7 @@ func (store *LDAPSchemaStore) addAttributeTypeGenericSchema(genericSchema *Gener
 	} else {
 		store.attributeTypeSchemaIndex[attributeTypeSchema.NumericOID] = genericSchema
 	}
-	for _, name := range attributeTypeSchema.Name {
-		lowercaseName := strings.ToLower(name)
-		if existedSchema = store.attributeTypeNameIndex[lowercaseName]; nil != existedSchema {
-			if existedSchema == genericSchema {
-				return nil
-			}
-			log.Printf("WARN: over-writing attributeTypeNameIndex (name=%v): %v <= %v", name, existedSchema, genericSchema)
-		}
-		store.attributeTypeNameIndex[lowercaseName] = genericSchema
-	}
+	indexSchemaNames("attributeTypeNameIndex", store.attributeTypeNameIndex, attributeTypeSchema.Name, genericSchema)
 	return nil
 }
 
@@ -191,16 +236,7 @@ func (store *LDAPSchemaStore) addObjectClassGenericSchema(genericSchema *Generic
 	} else {
 		store.objectClassSchemaIndex[objectClassSchema.NumericOID] = genericSchema
 	}
-	for _, name := range objectClassSchema.Name {
-		lowercaseName := strings.ToLower(name)
-		if existedSchema = store.objectClassNameIndex[lowercaseName]; nil != existedSchema {
-			if existedSchema == genericSchema {
-				return nil
-			}
-			log.Printf("WARN: over-writing objectClassNameIndex (name=%v): %v <= %v", name, existedSchema, genericSchema)
-		}
-		store.objectClassNameIndex[lowercaseName] = genericSchema
-	}
+	indexSchemaNames("objectClassNameIndex", store.objectClassNameIndex, objectClassSchema.Name, genericSchema)
 	return nil
 }
 
@@ -213,12 +249,7 @@ func (store *LDAPSchemaStore) AddObjectClassSchemaText(schemaText string) (err e
 	return store.addObjectClassGenericSchema(genericSchema)
 }
 
-// AddDITContentRuleSchemaText add DIT content rule schema in text form
-func (store *LDAPSchemaStore) AddDITContentRuleSchemaText(schemaText string) (err error) {
-	genericSchema, err := Parse(schemaText)
-	if nil != err {
-		return
-	}
+func (store *LDAPSchemaStore) addDITContentRuleGenericSchema(genericSchema *GenericSchema) (err error) {
 	ditContentRuleSchema, err := NewDITContentRuleSchemaViaGenericSchema(genericSchema)
 	if nil != err {
 		return
@@ -226,18 +257,24 @@ func (store *LDAPSchemaStore) AddDITContentRuleSchemaText(schemaText string) (er
 	existedSchema := store.ditContentRuleSchemaIndex[ditContentRuleSchema.NumericOID]
 	if nil != existedSchema {
 		existedSchema.add(genericSchema)
+		genericSchema = existedSchema
 	} else {
 		store.ditContentRuleSchemaIndex[ditContentRuleSchema.NumericOID] = genericSchema
 	}
+	indexSchemaNames("ditContentRuleNameIndex", store.ditContentRuleNameIndex, ditContentRuleSchema.Name, genericSchema)
 	return nil
 }
 
-// AddDITStructureRuleSchemaText add DIT structure rule schema in text form
-func (store *LDAPSchemaStore) AddDITStructureRuleSchemaText(schemaText string) (err error) {
+// AddDITContentRuleSchemaText add DIT content rule schema in text form
+func (store *LDAPSchemaStore) AddDITContentRuleSchemaText(schemaText string) (err error) {
 	genericSchema, err := Parse(schemaText)
 	if nil != err {
 		return
 	}
+	return store.addDITContentRuleGenericSchema(genericSchema)
+}
+
+func (store *LDAPSchemaStore) addDITStructureRuleGenericSchema(genericSchema *GenericSchema) (err error) {
 	ditStructureRuleSchema, err := NewDITStructureRuleSchemaViaGenericSchema(genericSchema)
 	if nil != err {
 		return
@@ -245,18 +282,24 @@ func (store *LDAPSchemaStore) AddDITStructureRuleSchemaText(schemaText string) (
 	existedSchema := store.ditStructureRuleSchemaIndex[ditStructureRuleSchema.RuleID]
 	if nil != existedSchema {
 		existedSchema.add(genericSchema)
+		genericSchema = existedSchema
 	} else {
 		store.ditStructureRuleSchemaIndex[ditStructureRuleSchema.RuleID] = genericSchema
 	}
+	indexSchemaNames("ditStructureRuleNameIndex", store.ditStructureRuleNameIndex, ditStructureRuleSchema.Name, genericSchema)
 	return nil
 }
 
-// AddNameFormSchemaText add name form schema in text form
-func (store *LDAPSchemaStore) AddNameFormSchemaText(schemaText string) (err error) {
+// AddDITStructureRuleSchemaText add DIT structure rule schema in text form
+func (store *LDAPSchemaStore) AddDITStructureRuleSchemaText(schemaText string) (err error) {
 	genericSchema, err := Parse(schemaText)
 	if nil != err {
 		return
 	}
+	return store.addDITStructureRuleGenericSchema(genericSchema)
+}
+
+func (store *LDAPSchemaStore) addNameFormGenericSchema(genericSchema *GenericSchema) (err error) {
 	nameFormSchema, err := NewNameFormSchemaViaGenericSchema(genericSchema)
 	if nil != err {
 		return
@@ -264,12 +307,23 @@ func (store *LDAPSchemaStore) AddNameFormSchemaText(schemaText string) (err erro
 	existedSchema := store.nameFormSchemaIndex[nameFormSchema.NumericOID]
 	if nil != existedSchema {
 		existedSchema.add(genericSchema)
+		genericSchema = existedSchema
 	} else {
 		store.nameFormSchemaIndex[nameFormSchema.NumericOID] = genericSchema
 	}
+	indexSchemaNames("nameFormNameIndex", store.nameFormNameIndex, nameFormSchema.Name, genericSchema)
 	return nil
 }
 
+// AddNameFormSchemaText add name form schema in text form
+func (store *LDAPSchemaStore) AddNameFormSchemaText(schemaText string) (err error) {
+	genericSchema, err := Parse(schemaText)
+	if nil != err {
+		return
+	}
+	return store.addNameFormGenericSchema(genericSchema)
+}
+
 func (store *LDAPSchemaStore) makeOIDOrderedAttributeTypeSchemas() (result []*AttributeTypeSchema, err error) {
 	for _, oid := range sortedMapKey(store.attributeTypeSchemaIndex) {
 		genericSchema := store.attributeTypeSchemaIndex[oid]
@@ -415,7 +469,7 @@ func (store *LDAPSchemaStore) writeObjectClassSchema(fp *os.File) (err error) {
 
 func (store *LDAPSchemaStore) collectDITContentRuleSchemaTexts(stopOnError bool) (result []string, err error) {
 	for _, oid := range sortedMapKey(store.ditContentRuleSchemaIndex) {
-		genericSchema := store.objectClassSchemaIndex[oid]
+		genericSchema := store.ditContentRuleSchemaIndex[oid]
 		ditContentRuleSchema, err := NewDITContentRuleSchemaViaGenericSchema(genericSchema)
 		if nil != err {
 			log.Printf("ERROR: cannot create DIT content rule schema object from generic schema [%v]: %v", oid, err)
@@ -439,7 +493,7 @@ func (store *LDAPSchemaStore) writeDITContentRuleSchema(fp *os.File) (err error)
 
 func (store *LDAPSchemaStore) collectDITStructureRuleSchemaTexts(stopOnError bool) (result []string, err error) {
 	for _, ruleID := range sortedMapKey(store.ditStructureRuleSchemaIndex) {
-		genericSchema := store.objectClassSchemaIndex[ruleID]
+		genericSchema := store.ditStructureRuleSchemaIndex[ruleID]
 		ditStructureRuleSchema, err := NewDITStructureRuleSchemaViaGenericSchema(genericSchema)
 		if nil != err {
 			log.Printf("ERROR: cannot create DIT structure rule schema object from generic schema [%v]: %v", ruleID, err)
@@ -519,18 +573,22 @@ func (store *LDAPSchemaStore) WriteToFile(name string) (err error) {
 	return nil
 }
 
-// WriteToJSONFile write content of store into given path in JSON form.
-func (store *LDAPSchemaStore) WriteToJSONFile(name string) (err error) {
-	var aux struct {
-		LDAPSyntax       []string `json:"ldap_syntax,omitempty"`
-		MatchingRule     []string `json:"matching_rule,omitempty"`
-		MatchingRuleUse  []string `json:"matching_rule_use,omitempty"`
-		AttributeType    []string `json:"attribute_type,omitempty"`
-		ObjectClass      []string `json:"object_class,omitempty"`
-		DITContentRule   []string `json:"dit_content_rule,omitempty"`
-		DITStructureRule []string `json:"dit_structure_rule,omitempty"`
-		NameForm         []string `json:"name_form,omitempty"`
-	}
+// storeJSONTexts is JSON form of store with schema elements in schema text.
+type storeJSONTexts struct {
+	LDAPSyntax       []string `json:"ldap_syntax,omitempty"`
+	MatchingRule     []string `json:"matching_rule,omitempty"`
+	MatchingRuleUse  []string `json:"matching_rule_use,omitempty"`
+	AttributeType    []string `json:"attribute_type,omitempty"`
+	ObjectClass      []string `json:"object_class,omitempty"`
+	DITContentRule   []string `json:"dit_content_rule,omitempty"`
+	DITStructureRule []string `json:"dit_structure_rule,omitempty"`
+	NameForm         []string `json:"name_form,omitempty"`
+}
+
+// WriteJSON write content of store in JSON form with schema elements in
+// schema text.
+func (store *LDAPSchemaStore) WriteJSON(w io.Writer) (err error) {
+	var aux storeJSONTexts
 	if aux.LDAPSyntax, err = store.collectLDAPSyntaxSchemaTexts(true); nil != err {
 		return
 	}
@@ -559,83 +617,103 @@ func (store *LDAPSchemaStore) WriteToJSONFile(name string) (err error) {
 	if nil != err {
 		return
 	}
+	_, err = w.Write(buf)
+	return
+}
+
+// WriteToJSONFile write content of store into given path in JSON form.
+func (store *LDAPSchemaStore) WriteToJSONFile(name string) (err error) {
 	fp, err := os.Create(name)
 	if nil != err {
 		return
 	}
 	defer fp.Close()
-	_, err = fp.Write(buf)
-	return err
+	return store.WriteJSON(fp)
 }
 
-func (store *LDAPSchemaStore) readLine(ln string) (err error) {
-	ln = strings.TrimSpace(ln)
-	idx := strings.Index(ln, lineFieldSeparator)
-	if idx < 0 {
-		if len(ln) > 0 {
-			log.Printf("WARN: dropping line - [%v]", ln)
-		}
-		return nil
-	}
-	k := ln[0:idx]
-	v := strings.TrimSpace(ln[idx+len(lineFieldSeparator):])
-	switch k {
+// AddGenericSchema add parsed schema of given kind, eg. Schema() of
+// SchemaScanner.
+func (store *LDAPSchemaStore) AddGenericSchema(kind SchemaKind, genericSchema *GenericSchema) (err error) {
+	return store.addRecordGenericSchema(string(kind), genericSchema)
+}
+
+func (store *LDAPSchemaStore) addRecordGenericSchema(recordType string, genericSchema *GenericSchema) (err error) {
+	switch recordType {
 	case recordTypeLDAPSyntaxSchema:
-		err = store.AddLDAPSyntaxSchemaText(v)
+		err = store.addLDAPSyntaxGenericSchema(genericSchema)
 	case recordTypeMatchingRuleSchema:
-		err = store.AddMatchingRuleSchemaText(v)
+		err = store.addMatchingRuleGenericSchema(genericSchema)
 	case recordTypeMatchingRuleUseSchema:
-		err = store.AddMatchingRuleUseSchemaText(v)
+		err = store.addMatchingRuleUseGenericSchema(genericSchema)
 	case recordTypeAttributeTypeSchema:
-		err = store.AddAttributeTypeSchemaText(v)
+		err = store.addAttributeTypeGenericSchema(genericSchema)
 	case recordTypeObjectClassSchema:
-		err = store.AddObjectClassSchemaText(v)
+		err = store.addObjectClassGenericSchema(genericSchema)
 	case recordTypeDITContentRuleSchema:
-		err = store.AddDITContentRuleSchemaText(v)
+		err = store.addDITContentRuleGenericSchema(genericSchema)
 	case recordTypeDITStructureRuleSchema:
-		err = store.AddDITStructureRuleSchemaText(v)
+		err = store.addDITStructureRuleGenericSchema(genericSchema)
 	case recordTypeNameFormSchema:
-		err = store.AddNameFormSchemaText(v)
+		err = store.addNameFormGenericSchema(genericSchema)
 	default:
-		err = errors.New("unknown record type key: " + k)
+		err = errors.New("unknown record type key: " + recordType)
 	}
 	return
 }
 
-// ReadFromFile read content into store from file at given path
+// recordTypeOfLeadingText takes record type key from the last line of text
+// before schema definition. Lines before it are dropped.
+func recordTypeOfLeadingText(leading string) string {
+	if idx := strings.LastIndexByte(leading, '\n'); idx >= 0 {
+		log.Printf("WARN: dropping text - [%v]", strings.TrimSpace(leading[:idx]))
+		leading = strings.TrimSpace(leading[idx+1:])
+	}
+	return strings.TrimSuffix(leading, strings.TrimSpace(lineFieldSeparator))
+}
+
+// ReadFromFile read content into store from file at given path.
+// Schema definitions are streamed with SchemaScanner.
 func (store *LDAPSchemaStore) ReadFromFile(name string) (err error) {
 	fp, err := os.Open(name)
 	if nil != err {
 		return
 	}
 	defer fp.Close()
-	reader := bufio.NewReader(fp)
-	num := 0
-	for {
-		ln, err := reader.ReadString('\n')
-		num++
-		errParse := store.readLine(ln)
-		if nil != err {
-			if io.EOF == err {
-				break
-			}
-			log.Printf("ERROR: failed on reading from file (file=%v, line=%d, err=%v)", name, num, err)
-			return err
+	scanner := NewSchemaScanner(fp)
+	for scanner.Scan() {
+		recordType := recordTypeOfLeadingText(scanner.LeadingText())
+		if err = store.addRecordGenericSchema(recordType, scanner.Schema()); nil != err {
+			log.Printf("ERROR: failed on adding schema from file (file=%v, line=%d, err=%v)", name, scanner.Line(), err)
+			return
 		}
-		if nil != errParse {
-			log.Printf("ERROR: failed on parsing schema text from file (file=%v, line=%d, err=%v)", name, num, errParse)
-			return errParse
+	}
+	if err = scanner.Err(); nil != err {
+		if parseError, ok := err.(*ParseError); ok {
+			parseError.FileName = name
+			parseError.FileLine = scanner.Line()
 		}
+		log.Printf("ERROR: failed on reading schema from file (file=%v, line=%d, err=%v)", name, scanner.Line(), err)
 	}
-	return nil
+	return
 }
 
 func (store *LDAPSchemaStore) rebuildMatchingRuleUses(verbose bool) (err error) {
-	attributeTypeSchemas, err := store.makeOIDOrderedAttributeTypeSchemas()
+	literalAttributeTypeSchemas, err := store.makeOIDOrderedAttributeTypeSchemas()
 	if nil != err {
 		return err
 	}
+	attributeTypeSchemas := make([]*AttributeTypeSchema, 0, len(literalAttributeTypeSchemas))
+	for _, attributeTypeSchema := range literalAttributeTypeSchemas {
+		effective, err := store.resolveEffectiveAttributeType(attributeTypeSchema)
+		if nil != err {
+			log.Printf("WARN: use literal matching rules of attribute type %s: %v", attributeTypeSchema.NumericOID, err)
+			attributeTypeSchemas = append(attributeTypeSchemas, attributeTypeSchema)
+			continue
+		}
+		attributeTypeSchemas = append(attributeTypeSchemas, effective.Resolved())
+	}
 	store.matchingRuleUseSchemaIndex = make(map[string]*GenericSchema)
+	store.matchingRuleUseNameIndex = make(map[string]*GenericSchema)
 	for _, matchingRuleGenericSchema := range store.matchingRuleSchemaIndex {
 		matchingRuleSchema, err := NewMatchingRuleSchemaViaGenericSchema(matchingRuleGenericSchema)
 		if nil != err {
@@ -669,13 +747,13 @@ func (store *LDAPSchemaStore) rebuildMatchingRuleUses(verbose bool) (err error)
 }
 
 func (store *LDAPSchemaStore) pullObjectClassWhenNotExist(source *LDAPSchemaStore, verbose bool, dependentRefName string, objectClassName string) (err error) {
-	if _, ok := store.objectClassNameIndex[objectClassName]; ok {
+	if nil != store.findObjectClassGenericSchema(objectClassName) {
 		if verbose {
 			log.Printf("INFO: reach object class for %s via name: %s", dependentRefName, objectClassName)
 		}
 		return nil
 	}
-	if remoteGenericSchema, ok := source.objectClassNameIndex[objectClassName]; ok {
+	if remoteGenericSchema := source.findObjectClassGenericSchema(objectClassName); nil != remoteGenericSchema {
 		if err = store.addObjectClassGenericSchema(remoteGenericSchema); nil != err {
 			log.Printf("ERROR: failed on adding dependent object class schema %s for %s from source: %v", objectClassName, dependentRefName, err)
 			return err
@@ -691,14 +769,13 @@ func (store *LDAPSchemaStore) pullObjectClassWhenNotExist(source *LDAPSchemaStor
 }
 
 func (store *LDAPSchemaStore) pullAttributeTypeWhenNotExist(source *LDAPSchemaStore, verbose bool, dependentRefName string, attributeTypeName string) (err error) {
-	lowercaseAttributeTypeName := strings.ToLower(attributeTypeName)
-	if _, ok := store.attributeTypeNameIndex[lowercaseAttributeTypeName]; ok {
+	if nil != store.findAttributeTypeGenericSchema(attributeTypeName) {
 		if verbose {
 			log.Printf("INFO: reach attribute type for %s via name: %s", dependentRefName, attributeTypeName)
 		}
 		return nil
 	}
-	if remoteGenericSchema, ok := source.attributeTypeNameIndex[lowercaseAttributeTypeName]; ok {
+	if remoteGenericSchema := source.findAttributeTypeGenericSchema(attributeTypeName); nil != remoteGenericSchema {
 		if err = store.addAttributeTypeGenericSchema(remoteGenericSchema); nil != err {
 			log.Printf("ERROR: failed on adding dependent attribute type schema %s for %s from source: %v", attributeTypeName, dependentRefName, err)
 			return err
@@ -714,14 +791,13 @@ func (store *LDAPSchemaStore) pullAttributeTypeWhenNotExist(source *LDAPSchemaSt
 }
 
 func (store *LDAPSchemaStore) pullMatchingRuleWhenNotExist(source *LDAPSchemaStore, verbose bool, dependentRefName string, matchingRuleName string) (err error) {
-	lowercaseMatchingRuleName := strings.ToLower(matchingRuleName)
-	if _, ok := store.matchingRuleNameIndex[lowercaseMatchingRuleName]; ok {
+	if nil != store.findMatchingRuleGenericSchema(matchingRuleName) {
 		if verbose {
 			log.Printf("INFO: reach matching rule for %s via name: %s", dependentRefName, matchingRuleName)
 		}
 		return nil
 	}
-	if remoteGenericSchema, ok := source.matchingRuleNameIndex[lowercaseMatchingRuleName]; ok {
+	if remoteGenericSchema := source.findMatchingRuleGenericSchema(matchingRuleName); nil != remoteGenericSchema {
 		if err = store.addMatchingRuleGenericSchema(remoteGenericSchema); nil != err {
 			log.Printf("ERROR: failed on adding dependent matching rule schema %s for %s from source: %v", matchingRuleName, dependentRefName, err)
 			return err
@@ -855,8 +931,132 @@ func (store *LDAPSchemaStore) pullMatchingRulesDependencies(source *LDAPSchemaSt
 	return nil
 }
 
+func (store *LDAPSchemaStore) pullNameFormWhenNotExist(source *LDAPSchemaStore, verbose bool, dependentRefName string, nameFormName string) (err error) {
+	if nil != store.findNameFormGenericSchema(nameFormName) {
+		if verbose {
+			log.Printf("INFO: reach name form for %s via name: %s", dependentRefName, nameFormName)
+		}
+		return nil
+	}
+	if remoteGenericSchema := source.findNameFormGenericSchema(nameFormName); nil != remoteGenericSchema {
+		if err = store.addNameFormGenericSchema(remoteGenericSchema); nil != err {
+			log.Printf("ERROR: failed on adding dependent name form schema %s for %s from source: %v", nameFormName, dependentRefName, err)
+			return err
+		} else if verbose {
+			log.Printf("INFO: reach name form for %s via name at remote store: %s", dependentRefName, nameFormName)
+		}
+		return nil
+	}
+	if verbose {
+		log.Printf("ERROR: failed on reach name form for %s: %v", dependentRefName, nameFormName)
+	}
+	return errors.New("needed name form for " + dependentRefName + " not found: " + nameFormName)
+}
+
+func (store *LDAPSchemaStore) pullDITStructureRuleWhenNotExist(source *LDAPSchemaStore, verbose bool, dependentRefName string, ruleID string) (err error) {
+	if nil != store.findDITStructureRuleGenericSchema(ruleID) {
+		if verbose {
+			log.Printf("INFO: reach DIT structure rule for %s via rule ID: %s", dependentRefName, ruleID)
+		}
+		return nil
+	}
+	if remoteGenericSchema := source.findDITStructureRuleGenericSchema(ruleID); nil != remoteGenericSchema {
+		if err = store.addDITStructureRuleGenericSchema(remoteGenericSchema); nil != err {
+			log.Printf("ERROR: failed on adding dependent DIT structure rule schema %s for %s from source: %v", ruleID, dependentRefName, err)
+			return err
+		} else if verbose {
+			log.Printf("INFO: reach DIT structure rule for %s via rule ID at remote store: %s", dependentRefName, ruleID)
+		}
+		return nil
+	}
+	if verbose {
+		log.Printf("ERROR: failed on reach DIT structure rule for %s: %v", dependentRefName, ruleID)
+	}
+	return errors.New("needed DIT structure rule for " + dependentRefName + " not found: " + ruleID)
+}
+
+func (store *LDAPSchemaStore) pullDITStructureRulesDependencies(source *LDAPSchemaStore, verbose bool) (err error) {
+	previousCount := 0
+	for len(store.ditStructureRuleSchemaIndex) != previousCount {
+		previousCount = len(store.ditStructureRuleSchemaIndex)
+		for _, ruleID := range sortedRuleIDMapKey(store.ditStructureRuleSchemaIndex) {
+			ditStructureRuleSchema, err := NewDITStructureRuleSchemaViaGenericSchema(store.ditStructureRuleSchemaIndex[ruleID])
+			if nil != err {
+				log.Printf("ERROR: cannot create DIT structure rule schema object from generic schema for pull dependent schema [%v]: %v", ruleID, err)
+				return err
+			}
+			if err = store.pullNameFormWhenNotExist(source, verbose, ruleID, ditStructureRuleSchema.NameForm); nil != err {
+				return err
+			}
+			for _, superRuleID := range ditStructureRuleSchema.SuperRules {
+				if err = store.pullDITStructureRuleWhenNotExist(source, verbose, ruleID, superRuleID); nil != err {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (store *LDAPSchemaStore) pullNameFormsDependencies(source *LDAPSchemaStore, verbose bool) (err error) {
+	for _, oid := range sortedMapKey(store.nameFormSchemaIndex) {
+		nameFormSchema, err := NewNameFormSchemaViaGenericSchema(store.nameFormSchemaIndex[oid])
+		if nil != err {
+			log.Printf("ERROR: cannot create name form schema object from generic schema for pull dependent schema [%v]: %v", oid, err)
+			return err
+		}
+		if err = store.pullObjectClassWhenNotExist(source, verbose, oid, nameFormSchema.ObjectClass); nil != err {
+			return err
+		}
+		for _, attributeName := range append(append([]string{}, nameFormSchema.Must...), nameFormSchema.May...) {
+			if err = store.pullAttributeTypeWhenNotExist(source, verbose, oid, attributeName); nil != err {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pullDITContentRulesDependencies pulls structural object class identified
+// by numeric OID of DIT content rule and classes and attribute types
+// referenced by the rule.
+func (store *LDAPSchemaStore) pullDITContentRulesDependencies(source *LDAPSchemaStore, verbose bool) (err error) {
+	for _, oid := range sortedMapKey(store.ditContentRuleSchemaIndex) {
+		ditContentRuleSchema, err := NewDITContentRuleSchemaViaGenericSchema(store.ditContentRuleSchemaIndex[oid])
+		if nil != err {
+			log.Printf("ERROR: cannot create DIT content rule schema object from generic schema for pull dependent schema [%v]: %v", oid, err)
+			return err
+		}
+		for _, objectClassName := range append([]string{oid}, ditContentRuleSchema.Aux...) {
+			if err = store.pullObjectClassWhenNotExist(source, verbose, oid, objectClassName); nil != err {
+				return err
+			}
+		}
+		for _, attributeName := range append(append(append([]string{}, ditContentRuleSchema.Must...), ditContentRuleSchema.May...), ditContentRuleSchema.Not...) {
+			if err = store.pullAttributeTypeWhenNotExist(source, verbose, oid, attributeName); nil != err {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // PullDependentSchema pull schemas used by contained schemas from source store into this store.
+// References of DIT structure rules (FORM, SUP), name forms (OC, MUST, MAY) and
+// DIT content rules (structural class, AUX, MUST, MAY, NOT) are followed as well.
 func (store *LDAPSchemaStore) PullDependentSchema(source *LDAPSchemaStore, verbose bool) (err error) {
+	if err = store.pullDITStructureRulesDependencies(source, verbose); nil != err {
+		log.Printf("ERROR: failed on pull dependecies for DIT structure rules: %v", err)
+		return
+	}
+	if err = store.pullNameFormsDependencies(source, verbose); nil != err {
+		log.Printf("ERROR: failed on pull dependecies for name forms: %v", err)
+		return
+	}
+	if err = store.pullDITContentRulesDependencies(source, verbose); nil != err {
+		log.Printf("ERROR: failed on pull dependecies for DIT content rules: %v", err)
+		return
+	}
 	if err = store.pullObjectClassesDependencies(source, verbose); nil != err {
 		log.Printf("ERROR: failed on pull dependecies for object classes: %v", err)
 		return
@@ -875,3 +1075,90 @@ func (store *LDAPSchemaStore) PullDependentSchema(source *LDAPSchemaStore, verbo
 	}
 	return nil
 }
+
+// ExtractClosure pulls object classes, attribute types or matching rules of
+// given names or numeric OIDs together with schemas they depend on from
+// source store into this store. Names are looked up as object class first,
+// then attribute type and matching rule. Give numeric OID to pick schema
+// of other kind sharing the same name.
+func (store *LDAPSchemaStore) ExtractClosure(source *LDAPSchemaStore, names ...string) (err error) {
+	verbose := store.Verbose
+	for _, name := range names {
+		switch {
+		case nil != source.findObjectClassGenericSchema(name):
+			err = store.pullObjectClassWhenNotExist(source, verbose, "closure root", name)
+		case nil != source.findAttributeTypeGenericSchema(name):
+			err = store.pullAttributeTypeWhenNotExist(source, verbose, "closure root", name)
+		case nil != source.findMatchingRuleGenericSchema(name):
+			err = store.pullMatchingRuleWhenNotExist(source, verbose, "closure root", name)
+		default:
+			err = errors.New("closure root not found: " + name)
+		}
+		if nil != err {
+			return
+		}
+	}
+	return store.PullDependentSchema(source, verbose)
+}
+
+func (store *LDAPSchemaStore) findAttributeTypeGenericSchema(identifier string) *GenericSchema {
+	if genericSchema := store.attributeTypeNameIndex[strings.ToLower(identifier)]; nil != genericSchema {
+		return genericSchema
+	}
+	return store.attributeTypeSchemaIndex[identifier]
+}
+
+func (store *LDAPSchemaStore) findObjectClassGenericSchema(identifier string) *GenericSchema {
+	if genericSchema := store.objectClassNameIndex[strings.ToLower(identifier)]; nil != genericSchema {
+		return genericSchema
+	}
+	return store.objectClassSchemaIndex[identifier]
+}
+
+func (store *LDAPSchemaStore) findMatchingRuleGenericSchema(identifier string) *GenericSchema {
+	if genericSchema := store.matchingRuleNameIndex[strings.ToLower(identifier)]; nil != genericSchema {
+		return genericSchema
+	}
+	return store.matchingRuleSchemaIndex[identifier]
+}
+
+// findMatchingRuleUseGenericSchema finds matching rule use by its name or
+// by name or numeric OID of the matching rule.
+func (store *LDAPSchemaStore) findMatchingRuleUseGenericSchema(identifier string) *GenericSchema {
+	if genericSchema := store.matchingRuleUseNameIndex[strings.ToLower(identifier)]; nil != genericSchema {
+		return genericSchema
+	}
+	if matchingRuleGenericSchema := store.findMatchingRuleGenericSchema(identifier); nil != matchingRuleGenericSchema {
+		return store.matchingRuleUseSchemaIndex[matchingRuleGenericSchema.NumericOID]
+	}
+	return nil
+}
+
+// findDITContentRuleGenericSchema finds DIT content rule by its name or
+// by name or numeric OID of the structural object class.
+func (store *LDAPSchemaStore) findDITContentRuleGenericSchema(identifier string) *GenericSchema {
+	if genericSchema := store.ditContentRuleNameIndex[strings.ToLower(identifier)]; nil != genericSchema {
+		return genericSchema
+	}
+	if genericSchema := store.ditContentRuleSchemaIndex[identifier]; nil != genericSchema {
+		return genericSchema
+	}
+	if objectClassGenericSchema := store.findObjectClassGenericSchema(identifier); nil != objectClassGenericSchema {
+		return store.ditContentRuleSchemaIndex[objectClassGenericSchema.NumericOID]
+	}
+	return nil
+}
+
+func (store *LDAPSchemaStore) findDITStructureRuleGenericSchema(identifier string) *GenericSchema {
+	if genericSchema := store.ditStructureRuleNameIndex[strings.ToLower(identifier)]; nil != genericSchema {
+		return genericSchema
+	}
+	return store.ditStructureRuleSchemaIndex[identifier]
+}
+
+func (store *LDAPSchemaStore) findNameFormGenericSchema(identifier string) *GenericSchema {
+	if genericSchema := store.nameFormNameIndex[strings.ToLower(identifier)]; nil != genericSchema {
+		return genericSchema
+	}
+	return store.nameFormSchemaIndex[identifier]
+}
@@ -0,0 +1,204 @@
+package ldapschemaparser
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestLDAPSchemaStorePullDependentSchema_1(t *testing.T) {
+	source := makeDITStructureSampleStore(t)
+	addDITStructureSampleRules(t, source)
+	for _, syntaxOID := range []string{"1.3.6.1.4.1.1466.115.121.1.15", "1.3.6.1.4.1.1466.115.121.1.26", "1.3.6.1.4.1.1466.115.121.1.38"} {
+		if err := source.AddLDAPSyntaxSchemaText("( " + syntaxOID + " DESC 'sample' )"); nil != err {
+			t.Fatalf("failed on adding LDAP syntax: %v", err)
+		}
+	}
+	if err := source.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.9 NAME 'sampleMissing' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )"); nil != err {
+		t.Fatalf("failed on adding attribute type: %v", err)
+	}
+	if err := source.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.5 NAME 'sampleRoom' SUP top STRUCTURAL MUST cn )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	if err := source.AddDITContentRuleSchemaText("( 1.3.6.1.4.1.99999.2.5 NAME 'sampleRoomContentRule' AUX sampleMailbox MAY description NOT sn )"); nil != err {
+		t.Fatalf("failed on adding DIT content rule: %v", err)
+	}
+	store := NewLDAPSchemaStore()
+	if err := store.AddDITStructureRuleSchemaText("( 3 NAME 'samplePersonRule' FORM samplePersonNameForm SUP 2 )"); nil != err {
+		t.Fatalf("failed on adding DIT structure rule: %v", err)
+	}
+	if err := store.AddDITContentRuleSchemaText("( 1.3.6.1.4.1.99999.2.5 NAME 'sampleRoomContentRule' AUX sampleMailbox MAY description NOT sn )"); nil != err {
+		t.Fatalf("failed on adding DIT content rule: %v", err)
+	}
+	if err := store.PullDependentSchema(source, false); nil != err {
+		t.Fatalf("failed on pulling dependent schema: %v", err)
+	}
+	if v := sortedRuleIDMapKey(store.ditStructureRuleSchemaIndex); len(v) != 3 {
+		t.Errorf("expecting all DIT structure rules in SUP chain pulled: %v", v)
+	}
+	if v := sortedMapKey(store.nameFormSchemaIndex); len(v) != 3 {
+		t.Errorf("expecting name forms of pulled rules: %v", v)
+	}
+	for _, objectClassName := range []string{"top", "person", "organization", "organizationalUnit", "sampleRoom", "sampleMailbox"} {
+		if nil == store.findObjectClassGenericSchema(objectClassName) {
+			t.Errorf("expecting object class %s pulled", objectClassName)
+		}
+	}
+	for _, attributeTypeName := range []string{"o", "ou", "uid", "cn", "sn", "description", "mail", "objectClass"} {
+		if nil == store.findAttributeTypeGenericSchema(attributeTypeName) {
+			t.Errorf("expecting attribute type %s pulled", attributeTypeName)
+		}
+	}
+	if nil == store.ldapSyntaxSchemaIndex["1.3.6.1.4.1.1466.115.121.1.15"] {
+		t.Error("expecting LDAP syntax pulled")
+	}
+}
+
+func TestLDAPSchemaStorePullDependentSchema_2(t *testing.T) {
+	source := makeDITStructureSampleStore(t)
+	store := NewLDAPSchemaStore()
+	if err := store.AddDITStructureRuleSchemaText("( 3 NAME 'samplePersonRule' FORM samplePersonNameForm SUP 2 )"); nil != err {
+		t.Fatalf("failed on adding DIT structure rule: %v", err)
+	}
+	if err := store.PullDependentSchema(source, false); nil == err {
+		t.Error("expecting error for missing superior DIT structure rule")
+	}
+}
+
+func TestLDAPSchemaStoreExtractClosure_1(t *testing.T) {
+	source := makeFilterSampleStore(t)
+	for _, schemaText := range []string{
+		"( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 2.5.13.3 NAME 'caseIgnoreOrderingMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 2.5.13.4 NAME 'caseIgnoreSubstringsMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.58 )",
+		"( 2.5.13.15 NAME 'integerOrderingMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 )",
+	} {
+		if err := source.AddMatchingRuleSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding matching rule: %v", err)
+		}
+	}
+	for _, syntaxOID := range []string{"1.3.6.1.4.1.1466.115.121.1.15", "1.3.6.1.4.1.1466.115.121.1.27", "1.3.6.1.4.1.1466.115.121.1.58"} {
+		if err := source.AddLDAPSyntaxSchemaText("( " + syntaxOID + " DESC 'sample' )"); nil != err {
+			t.Fatalf("failed on adding LDAP syntax: %v", err)
+		}
+	}
+	store := NewLDAPSchemaStore()
+	if err := store.ExtractClosure(source, "SAMPLECN", "integerOrderingMatch"); nil != err {
+		t.Fatalf("failed on extracting closure: %v", err)
+	}
+	if v := sortedMapKey(store.attributeTypeSchemaIndex); len(v) != 3 {
+		t.Errorf("expecting sampleCN with its super types: %v", v)
+	}
+	if v := sortedMapKey(store.matchingRuleSchemaIndex); len(v) != 4 {
+		t.Errorf("expecting matching rules of attribute types and root: %v", v)
+	}
+	if v := sortedMapKey(store.ldapSyntaxSchemaIndex); len(v) != 3 {
+		t.Errorf("expecting LDAP syntaxes of matching rules: %v", v)
+	}
+	if nil != store.findAttributeTypeGenericSchema("sampleAge") {
+		t.Error("unexpected attribute type out of closure")
+	}
+	if err := store.ExtractClosure(source, "sampleMissing"); nil == err {
+		t.Error("expecting error for missing closure root")
+	}
+}
+
+func TestLDAPSchemaStoreExtractClosure_2(t *testing.T) {
+	source := NewLDAPSchemaStore()
+	if err := source.AddLDAPSyntaxSchemaText("( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )"); nil != err {
+		t.Fatalf("failed on adding LDAP syntax: %v", err)
+	}
+	if err := source.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.1 NAME 'sampleDevice' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )"); nil != err {
+		t.Fatalf("failed on adding attribute type: %v", err)
+	}
+	if err := source.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleDevice' AUXILIARY MAY sampleDevice )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	store := NewLDAPSchemaStore()
+	if err := store.ExtractClosure(source, "1.3.6.1.4.1.99999.1.1"); nil != err {
+		t.Fatalf("failed on extracting closure: %v", err)
+	}
+	if nil == store.findAttributeTypeGenericSchema("sampleDevice") {
+		t.Error("expecting attribute type pulled")
+	}
+	if nil != store.findObjectClassGenericSchema("sampleDevice") {
+		t.Error("unexpected object class of the same name pulled")
+	}
+}
+
+func TestLDAPSchemaStoreReadFromFile_1(t *testing.T) {
+	fp, err := ioutil.TempFile("", "store")
+	if nil != err {
+		t.Fatalf("failed on creating temporary file: %v", err)
+	}
+	defer os.Remove(fp.Name())
+	content := recordTypeLDAPSyntaxSchema + lineFieldSeparator + "( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )\n" +
+		recordTypeAttributeTypeSchema + lineFieldSeparator + "( 2.5.4.3 NAME 'cn' SYNTAX"
+	if _, err = fp.WriteString(content); nil != err {
+		t.Fatalf("failed on writing temporary file: %v", err)
+	}
+	fp.Close()
+	store := NewLDAPSchemaStore()
+	err = store.ReadFromFile(fp.Name())
+	parseError, ok := err.(*ParseError)
+	if !ok {
+		t.Fatalf("expecting parse error of last line without trailing newline: %v", err)
+	}
+	if parseError.FileLine != 2 {
+		t.Errorf("unexpected line of parse error: %d", parseError.FileLine)
+	}
+}
+
+func TestLDAPSchemaStoreReadFromFile_2(t *testing.T) {
+	fp, err := ioutil.TempFile("", "store")
+	if nil != err {
+		t.Fatalf("failed on creating temporary file: %v", err)
+	}
+	defer os.Remove(fp.Name())
+	content := "# sample store\n" +
+		recordTypeLDAPSyntaxSchema + lineFieldSeparator + "( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )\n\n" +
+		recordTypeAttributeTypeSchema + lineFieldSeparator + "( 2.5.4.41 NAME 'name'\n" +
+		"  SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )\n" +
+		recordTypeAttributeTypeSchema + lineFieldSeparator + "( 2.5.4.3 NAME 'cn' SUP name )"
+	if _, err = fp.WriteString(content); nil != err {
+		t.Fatalf("failed on writing temporary file: %v", err)
+	}
+	fp.Close()
+	store := NewLDAPSchemaStore()
+	if err = store.ReadFromFile(fp.Name()); nil != err {
+		t.Fatalf("failed on reading store file: %v", err)
+	}
+	if v := sortedMapKey(store.attributeTypeSchemaIndex); len(v) != 2 {
+		t.Errorf("expecting definitions span lines read: %v", v)
+	}
+	if nil == store.ldapSyntaxSchemaIndex["1.3.6.1.4.1.1466.115.121.1.15"] {
+		t.Error("expecting LDAP syntax read")
+	}
+}
+
+func TestLDAPSchemaStoreCollectSchemaTexts_1(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	if err := store.AddObjectClassSchemaText("( 2.5.6.5 NAME 'organizationalUnit' SUP top STRUCTURAL MUST ou )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	if err := store.AddDITContentRuleSchemaText("( 2.5.6.5 NAME 'organizationalUnitContentRule' MAY description )"); nil != err {
+		t.Fatalf("failed on adding DIT content rule: %v", err)
+	}
+	if err := store.AddDITStructureRuleSchemaText("( 1 NAME 'organizationalUnitRule' FORM organizationalUnitNameForm )"); nil != err {
+		t.Fatalf("failed on adding DIT structure rule: %v", err)
+	}
+	schemaTexts, err := store.collectDITContentRuleSchemaTexts(true)
+	if nil != err {
+		t.Fatalf("failed on collecting DIT content rules: %v", err)
+	}
+	if (len(schemaTexts) != 1) || (schemaTexts[0] != "( 2.5.6.5 NAME 'organizationalUnitContentRule' MAY description )") {
+		t.Errorf("unexpected DIT content rule texts: %v", schemaTexts)
+	}
+	schemaTexts, err = store.collectDITStructureRuleSchemaTexts(true)
+	if nil != err {
+		t.Fatalf("failed on collecting DIT structure rules: %v", err)
+	}
+	if (len(schemaTexts) != 1) || (schemaTexts[0] != "( 1 NAME 'organizationalUnitRule' FORM organizationalUnitNameForm )") {
+		t.Errorf("unexpected DIT structure rule texts: %v", schemaTexts)
+	}
+}
@@ -0,0 +1,266 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// FieldChange represents a changed field of schema element.
+// For list fields other than Name, Added and Removed hold the changed items.
+type FieldChange struct {
+	Field   string      `json:"field"`
+	Old     interface{} `json:"old,omitempty"`
+	New     interface{} `json:"new,omitempty"`
+	Added   []string    `json:"added,omitempty"`
+	Removed []string    `json:"removed,omitempty"`
+}
+
+func (c *FieldChange) String() string {
+	if (nil != c.Added) || (nil != c.Removed) {
+		return fmt.Sprintf("%s: added %v, removed %v", c.Field, c.Added, c.Removed)
+	}
+	return fmt.Sprintf("%s: %v -> %v", c.Field, c.Old, c.New)
+}
+
+// ElementChange represents an added, removed or modified schema element.
+type ElementChange struct {
+	Kind       SchemaKind     `json:"kind"`
+	Identifier string         `json:"identifier"`
+	Name       string         `json:"name,omitempty"`
+	Changes    []*FieldChange `json:"changes,omitempty"`
+}
+
+// StoreDiff is the semantic difference between two LDAPSchemaStore instances.
+type StoreDiff struct {
+	Added    []*ElementChange `json:"added,omitempty"`
+	Removed  []*ElementChange `json:"removed,omitempty"`
+	Modified []*ElementChange `json:"modified,omitempty"`
+}
+
+// Empty check if there is no difference.
+func (d *StoreDiff) Empty() bool {
+	return (0 == len(d.Added)) && (0 == len(d.Removed)) && (0 == len(d.Modified))
+}
+
+func schemaElementName(typedSchema interface{}) string {
+	v := reflect.ValueOf(typedSchema).Elem()
+	nameField := v.FieldByName("Name")
+	if !nameField.IsValid() || (nameField.Len() == 0) {
+		return ""
+	}
+	return nameField.Index(0).String()
+}
+
+// derivedSchemaFields are fields computed from other fields. Changes of
+// them are reported with the field they are derived from, except that
+// SyntaxLength is reported alone when only the length bound of Syntax
+// changed.
+var derivedSchemaFields = map[string]bool{
+	"SyntaxOID":    true,
+	"SyntaxLength": true,
+}
+
+// schemaReferenceFields maps fields referencing other schema elements to
+// the find function of the referenced kind.
+var schemaReferenceFields = map[string]func(store *LDAPSchemaStore, identifier string) *GenericSchema{
+	"SuperType":    (*LDAPSchemaStore).findAttributeTypeGenericSchema,
+	"Must":         (*LDAPSchemaStore).findAttributeTypeGenericSchema,
+	"May":          (*LDAPSchemaStore).findAttributeTypeGenericSchema,
+	"Not":          (*LDAPSchemaStore).findAttributeTypeGenericSchema,
+	"AppliesTo":    (*LDAPSchemaStore).findAttributeTypeGenericSchema,
+	"SuperClasses": (*LDAPSchemaStore).findObjectClassGenericSchema,
+	"Aux":          (*LDAPSchemaStore).findObjectClassGenericSchema,
+	"ObjectClass":  (*LDAPSchemaStore).findObjectClassGenericSchema,
+	"Equality":     (*LDAPSchemaStore).findMatchingRuleGenericSchema,
+	"Ordering":     (*LDAPSchemaStore).findMatchingRuleGenericSchema,
+	"SubString":    (*LDAPSchemaStore).findMatchingRuleGenericSchema,
+	"NameForm":     (*LDAPSchemaStore).findNameFormGenericSchema,
+}
+
+// referenceNormalizer returns function which maps reference of given field
+// to numeric OID of referenced element in store. References not found in
+// store are compared case-insensitively.
+func referenceNormalizer(store *LDAPSchemaStore, fieldName string) func(identifier string) string {
+	find := schemaReferenceFields[fieldName]
+	return func(identifier string) string {
+		if nil != find {
+			if genericSchema := find(store, identifier); nil != genericSchema {
+				return genericSchema.NumericOID
+			}
+		}
+		return strings.ToLower(identifier)
+	}
+}
+
+// syntaxLengthChange returns change of SyntaxLength when Syntax of two
+// typed schema differ only in length bound. Nil is returned otherwise.
+func syntaxLengthChange(va, vb reflect.Value) *FieldChange {
+	oidA := va.FieldByName("SyntaxOID")
+	lengthA := va.FieldByName("SyntaxLength")
+	if !oidA.IsValid() || !lengthA.IsValid() {
+		return nil
+	}
+	if oidA.String() != vb.FieldByName("SyntaxOID").String() {
+		return nil
+	}
+	return &FieldChange{
+		Field: "SyntaxLength",
+		Old:   lengthA.Interface(),
+		New:   vb.FieldByName("SyntaxLength").Interface(),
+	}
+}
+
+func stringSetDifference(a, b []string, normalizeA, normalizeB func(string) string) (result []string) {
+	aux := make(map[string]bool)
+	for _, v := range b {
+		aux[normalizeB(v)] = true
+	}
+	for _, v := range a {
+		if !aux[normalizeA(v)] {
+			result = append(result, v)
+		}
+	}
+	return
+}
+
+func diffExtensions(a, b map[string][]string) (changes []*FieldChange) {
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+	for _, k := range sortedKeys {
+		if !reflect.DeepEqual(a[k], b[k]) {
+			changes = append(changes, &FieldChange{
+				Field: "Extensions." + k,
+				Old:   a[k],
+				New:   b[k],
+			})
+		}
+	}
+	return
+}
+
+// diffSchemaFields compares fields of two typed schema of the same type.
+// Name is compared as ordered list as the first name is the short
+// identifier. Other lists are compared as sets. References to other schema
+// elements are compared by numeric OID of the element in respective store.
+func diffSchemaFields(a, b interface{}, storeA, storeB *LDAPSchemaStore) (changes []*FieldChange) {
+	va := reflect.ValueOf(a).Elem()
+	vb := reflect.ValueOf(b).Elem()
+	t := va.Type()
+	for idx := 0; idx < t.NumField(); idx++ {
+		fieldName := t.Field(idx).Name
+		if derivedSchemaFields[fieldName] {
+			continue
+		}
+		fa := va.Field(idx).Interface()
+		fb := vb.Field(idx).Interface()
+		normalizeA := referenceNormalizer(storeA, fieldName)
+		normalizeB := referenceNormalizer(storeB, fieldName)
+		switch av := fa.(type) {
+		case []string:
+			bv := fb.([]string)
+			if fieldName == "Name" {
+				if !reflect.DeepEqual(av, bv) {
+					changes = append(changes, &FieldChange{Field: fieldName, Old: av, New: bv})
+				}
+				continue
+			}
+			added := stringSetDifference(bv, av, normalizeB, normalizeA)
+			removed := stringSetDifference(av, bv, normalizeA, normalizeB)
+			if (len(added) > 0) || (len(removed) > 0) {
+				changes = append(changes, &FieldChange{Field: fieldName, Added: added, Removed: removed})
+			}
+		case map[string][]string:
+			changes = append(changes, diffExtensions(av, fb.(map[string][]string))...)
+		case string:
+			if _, ok := schemaReferenceFields[fieldName]; ok && (normalizeA(av) == normalizeB(fb.(string))) {
+				continue
+			}
+			if fa == fb {
+				continue
+			}
+			if fieldName == "Syntax" {
+				if c := syntaxLengthChange(va, vb); nil != c {
+					changes = append(changes, c)
+					continue
+				}
+			}
+			changes = append(changes, &FieldChange{Field: fieldName, Old: fa, New: fb})
+		default:
+			if fa != fb {
+				changes = append(changes, &FieldChange{Field: fieldName, Old: fa, New: fb})
+			}
+		}
+	}
+	return
+}
+
+func diffSchemaKind(descriptor *schemaKindDescriptor, a, b *LDAPSchemaStore, diff *StoreDiff) (err error) {
+	indexA := descriptor.index(a)
+	indexB := descriptor.index(b)
+	for _, k := range sortedMapKey(indexA) {
+		typedA, err := descriptor.typedSchema(indexA[k])
+		if nil != err {
+			return err
+		}
+		genericB, ok := indexB[k]
+		if !ok {
+			diff.Removed = append(diff.Removed, &ElementChange{
+				Kind:       descriptor.kind,
+				Identifier: k,
+				Name:       schemaElementName(typedA),
+			})
+			continue
+		}
+		typedB, err := descriptor.typedSchema(genericB)
+		if nil != err {
+			return err
+		}
+		if changes := diffSchemaFields(typedA, typedB, a, b); len(changes) > 0 {
+			diff.Modified = append(diff.Modified, &ElementChange{
+				Kind:       descriptor.kind,
+				Identifier: k,
+				Name:       schemaElementName(typedB),
+				Changes:    changes,
+			})
+		}
+	}
+	for _, k := range sortedMapKey(indexB) {
+		if _, ok := indexA[k]; ok {
+			continue
+		}
+		typedB, err := descriptor.typedSchema(indexB[k])
+		if nil != err {
+			return err
+		}
+		diff.Added = append(diff.Added, &ElementChange{
+			Kind:       descriptor.kind,
+			Identifier: k,
+			Name:       schemaElementName(typedB),
+		})
+	}
+	return nil
+}
+
+// DiffStores compares schema elements of two stores. Elements are paired by
+// numeric OID (rule ID for DIT structure rules) and compared field by field.
+func DiffStores(a, b *LDAPSchemaStore) (diff *StoreDiff, err error) {
+	diff = &StoreDiff{}
+	for _, descriptor := range schemaKindDescriptors {
+		if err = diffSchemaKind(descriptor, a, b, diff); nil != err {
+			return nil, err
+		}
+	}
+	return diff, nil
+}
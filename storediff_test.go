@@ -0,0 +1,143 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func TestDiffStores_1(t *testing.T) {
+	a := NewLDAPSchemaStore()
+	b := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleA' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{64} )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleB' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+	} {
+		if err := a.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleA' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{128} SINGLE-VALUE )",
+		"( 1.3.6.1.4.1.99999.1.3 NAME 'sampleC' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+	} {
+		if err := b.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	if err := a.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleObject' MUST sampleA MAY ( sampleB $ sampleC ) )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	if err := b.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleObject' MUST ( sampleC $ sampleA ) MAY sampleB )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	diff, err := DiffStores(a, b)
+	if nil != err {
+		t.Fatalf("failed on diff stores: %v", err)
+	}
+	if (len(diff.Added) != 1) || (diff.Added[0].Identifier != "1.3.6.1.4.1.99999.1.3") || (diff.Added[0].Kind != SchemaKindAttributeType) {
+		t.Errorf("unexpected added elements: %v", diff.Added)
+	}
+	if (len(diff.Removed) != 1) || (diff.Removed[0].Name != "sampleB") {
+		t.Errorf("unexpected removed elements: %v", diff.Removed)
+	}
+	if len(diff.Modified) != 2 {
+		t.Fatalf("expecting 2 modified elements: %v", diff.Modified)
+	}
+	changes := make(map[string]*FieldChange)
+	for _, c := range diff.Modified[0].Changes {
+		changes[c.Field] = c
+	}
+	if c := changes["SingleValue"]; (nil == c) || (c.Old != false) || (c.New != true) {
+		t.Errorf("expecting SingleValue flipped: %v", diff.Modified[0].Changes)
+	}
+	if c := changes["SyntaxLength"]; (nil == c) || (c.Old != int32(64)) || (c.New != int32(128)) {
+		t.Errorf("expecting SyntaxLength changed: %v", diff.Modified[0].Changes)
+	}
+	if len(diff.Modified[0].Changes) != 2 {
+		t.Errorf("unexpected changes of derived fields: %v", diff.Modified[0].Changes)
+	}
+	objectClassChanges := diff.Modified[1].Changes
+	if (len(objectClassChanges) != 2) || (objectClassChanges[0].Field != "Must") || (len(objectClassChanges[0].Added) != 1) || (objectClassChanges[0].Added[0] != "sampleC") {
+		t.Errorf("expecting object class gained MUST sampleC: %v", objectClassChanges)
+	}
+}
+
+func TestDiffStores_2(t *testing.T) {
+	a := makeOpenLDAPConfigSampleStore(t)
+	b := makeOpenLDAPConfigSampleStore(t)
+	diff, err := DiffStores(a, b)
+	if nil != err {
+		t.Fatalf("failed on diff stores: %v", err)
+	}
+	if !diff.Empty() {
+		t.Errorf("expecting empty difference: %v", diff)
+	}
+}
+
+func TestDiffStores_3(t *testing.T) {
+	a := NewLDAPSchemaStore()
+	b := NewLDAPSchemaStore()
+	for _, store := range []*LDAPSchemaStore{a, b} {
+		for _, schemaText := range []string{
+			"( 2.5.4.41 NAME 'name' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+			"( 2.5.4.3 NAME ( 'cn' 'commonName' ) SUP name )",
+			"( 2.5.4.13 NAME 'description' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		} {
+			if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+				t.Fatalf("failed on adding attribute type: %v", err)
+			}
+		}
+		if err := store.AddObjectClassSchemaText("( 2.5.6.0 NAME 'top' ABSTRACT )"); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	if err := a.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleObject' SUP top MUST cn MAY description )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	if err := b.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleObject' SUP 2.5.6.0 MUST CN MAY ( commonName $ 2.5.4.13 ) )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	diff, err := DiffStores(a, b)
+	if nil != err {
+		t.Fatalf("failed on diff stores: %v", err)
+	}
+	if (len(diff.Modified) != 1) || (len(diff.Modified[0].Changes) != 1) {
+		t.Fatalf("expecting only MAY of object class changed: %v", diff.Modified)
+	}
+	if c := diff.Modified[0].Changes[0]; (c.Field != "May") || (len(c.Added) != 1) || (c.Added[0] != "commonName") || (len(c.Removed) != 0) {
+		t.Errorf("expecting MAY gained commonName: %v", c)
+	}
+}
+
+func TestDiffStores_4(t *testing.T) {
+	a := NewLDAPSchemaStore()
+	b := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleA' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32} )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleB' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32} )",
+	} {
+		if err := a.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleA' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{64} )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleB' SYNTAX 1.3.6.1.4.1.1466.115.121.1.26{32} )",
+	} {
+		if err := b.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	diff, err := DiffStores(a, b)
+	if nil != err {
+		t.Fatalf("failed on diff stores: %v", err)
+	}
+	if len(diff.Modified) != 2 {
+		t.Fatalf("expecting 2 modified elements: %v", diff.Modified)
+	}
+	if changes := diff.Modified[0].Changes; (len(changes) != 1) || (changes[0].String() != "SyntaxLength: 32 -> 64") {
+		t.Errorf("expecting only SyntaxLength changed: %v", changes)
+	}
+	if changes := diff.Modified[1].Changes; (len(changes) != 1) || (changes[0].String() != "Syntax: 1.3.6.1.4.1.1466.115.121.1.15{32} -> 1.3.6.1.4.1.1466.115.121.1.26{32}") {
+		t.Errorf("expecting Syntax changed: %v", changes)
+	}
+}
@@ -0,0 +1,198 @@
+package ldapschemaparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// StructuredStoreJSON is the structured JSON form of store with explicit
+// fields for each schema element.
+type StructuredStoreJSON struct {
+	LDAPSyntaxes      []*LDAPSyntaxSchema       `json:"ldap_syntaxes,omitempty"`
+	MatchingRules     []*MatchingRuleSchema     `json:"matching_rules,omitempty"`
+	MatchingRuleUses  []*MatchingRuleUseSchema  `json:"matching_rule_uses,omitempty"`
+	AttributeTypes    []*AttributeTypeSchema    `json:"attribute_types,omitempty"`
+	ObjectClasses     []*ObjectClassSchema      `json:"object_classes,omitempty"`
+	DITContentRules   []*DITContentRuleSchema   `json:"dit_content_rules,omitempty"`
+	DITStructureRules []*DITStructureRuleSchema `json:"dit_structure_rules,omitempty"`
+	NameForms         []*NameFormSchema         `json:"name_forms,omitempty"`
+}
+
+// StructuredJSON collects schema elements in store into structured JSON
+// form. Elements are ordered as the listing methods of store.
+func (store *LDAPSchemaStore) StructuredJSON() (result *StructuredStoreJSON, err error) {
+	result = &StructuredStoreJSON{}
+	if result.LDAPSyntaxes, err = store.LDAPSyntaxes(); nil != err {
+		return nil, err
+	}
+	if result.MatchingRules, err = store.MatchingRules(); nil != err {
+		return nil, err
+	}
+	if result.MatchingRuleUses, err = store.MatchingRuleUses(); nil != err {
+		return nil, err
+	}
+	if result.AttributeTypes, err = store.AttributeTypes(); nil != err {
+		return nil, err
+	}
+	if result.ObjectClasses, err = store.ObjectClasses(); nil != err {
+		return nil, err
+	}
+	if result.DITContentRules, err = store.DITContentRules(); nil != err {
+		return nil, err
+	}
+	if result.DITStructureRules, err = store.DITStructureRules(); nil != err {
+		return nil, err
+	}
+	if result.NameForms, err = store.NameForms(); nil != err {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WriteStructuredJSON write content of store in structured JSON form.
+func (store *LDAPSchemaStore) WriteStructuredJSON(w io.Writer) (err error) {
+	aux, err := store.StructuredJSON()
+	if nil != err {
+		return
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(aux)
+}
+
+// WriteToStructuredJSONFile write content of store into given path in
+// structured JSON form.
+func (store *LDAPSchemaStore) WriteToStructuredJSONFile(name string) (err error) {
+	fp, err := os.Create(name)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	return store.WriteStructuredJSON(fp)
+}
+
+// addSchemaTexts adds schema texts with given add function of store.
+func addSchemaTexts(schemaTexts []string, add func(schemaText string) error) (err error) {
+	for _, schemaText := range schemaTexts {
+		if err = add(schemaText); nil != err {
+			log.Printf("ERROR: failed on adding schema text from JSON [%v]: %v", schemaText, err)
+			return
+		}
+	}
+	return nil
+}
+
+func (store *LDAPSchemaStore) addJSONTexts(aux *storeJSONTexts) (err error) {
+	if err = addSchemaTexts(aux.LDAPSyntax, store.AddLDAPSyntaxSchemaText); nil != err {
+		return
+	}
+	if err = addSchemaTexts(aux.MatchingRule, store.AddMatchingRuleSchemaText); nil != err {
+		return
+	}
+	if err = addSchemaTexts(aux.MatchingRuleUse, store.AddMatchingRuleUseSchemaText); nil != err {
+		return
+	}
+	if err = addSchemaTexts(aux.AttributeType, store.AddAttributeTypeSchemaText); nil != err {
+		return
+	}
+	if err = addSchemaTexts(aux.ObjectClass, store.AddObjectClassSchemaText); nil != err {
+		return
+	}
+	if err = addSchemaTexts(aux.DITContentRule, store.AddDITContentRuleSchemaText); nil != err {
+		return
+	}
+	if err = addSchemaTexts(aux.DITStructureRule, store.AddDITStructureRuleSchemaText); nil != err {
+		return
+	}
+	return addSchemaTexts(aux.NameForm, store.AddNameFormSchemaText)
+}
+
+// addStructuredSchemas adds count schema elements of one kind in structured
+// JSON form. Elements are converted into schema text and parsed again so the
+// same checks of schema text apply. Element without identifier is rejected
+// with missingIdentifierErr.
+func addStructuredSchemas(count int, schemaAt func(idx int) (identifier string, schema fmt.Stringer), missingIdentifierErr error, add func(schemaText string) error) (err error) {
+	schemaTexts := make([]string, 0, count)
+	for idx := 0; idx < count; idx++ {
+		identifier, schema := schemaAt(idx)
+		if "" == identifier {
+			return missingIdentifierErr
+		}
+		schemaTexts = append(schemaTexts, schema.String())
+	}
+	return addSchemaTexts(schemaTexts, add)
+}
+
+// addStructuredJSON adds schema elements in structured JSON form.
+func (store *LDAPSchemaStore) addStructuredJSON(aux *StructuredStoreJSON) (err error) {
+	if err = addStructuredSchemas(len(aux.LDAPSyntaxes), func(idx int) (string, fmt.Stringer) {
+		return aux.LDAPSyntaxes[idx].NumericOID, aux.LDAPSyntaxes[idx]
+	}, ErrMissingNumericOID, store.AddLDAPSyntaxSchemaText); nil != err {
+		return
+	}
+	if err = addStructuredSchemas(len(aux.MatchingRules), func(idx int) (string, fmt.Stringer) {
+		return aux.MatchingRules[idx].NumericOID, aux.MatchingRules[idx]
+	}, ErrMissingNumericOID, store.AddMatchingRuleSchemaText); nil != err {
+		return
+	}
+	if err = addStructuredSchemas(len(aux.MatchingRuleUses), func(idx int) (string, fmt.Stringer) {
+		return aux.MatchingRuleUses[idx].NumericOID, aux.MatchingRuleUses[idx]
+	}, ErrMissingNumericOID, store.AddMatchingRuleUseSchemaText); nil != err {
+		return
+	}
+	if err = addStructuredSchemas(len(aux.AttributeTypes), func(idx int) (string, fmt.Stringer) {
+		return aux.AttributeTypes[idx].NumericOID, aux.AttributeTypes[idx]
+	}, ErrMissingNumericOID, store.AddAttributeTypeSchemaText); nil != err {
+		return
+	}
+	if err = addStructuredSchemas(len(aux.ObjectClasses), func(idx int) (string, fmt.Stringer) {
+		return aux.ObjectClasses[idx].NumericOID, aux.ObjectClasses[idx]
+	}, ErrMissingNumericOID, store.AddObjectClassSchemaText); nil != err {
+		return
+	}
+	if err = addStructuredSchemas(len(aux.DITContentRules), func(idx int) (string, fmt.Stringer) {
+		return aux.DITContentRules[idx].NumericOID, aux.DITContentRules[idx]
+	}, ErrMissingNumericOID, store.AddDITContentRuleSchemaText); nil != err {
+		return
+	}
+	if err = addStructuredSchemas(len(aux.DITStructureRules), func(idx int) (string, fmt.Stringer) {
+		return aux.DITStructureRules[idx].RuleID, aux.DITStructureRules[idx]
+	}, ErrMissingRuleID, store.AddDITStructureRuleSchemaText); nil != err {
+		return
+	}
+	return addStructuredSchemas(len(aux.NameForms), func(idx int) (string, fmt.Stringer) {
+		return aux.NameForms[idx].NumericOID, aux.NameForms[idx]
+	}, ErrMissingNumericOID, store.AddNameFormSchemaText)
+}
+
+// ReadJSON read content into store from JSON written by WriteJSON or
+// WriteStructuredJSON. Both forms may be mixed in one document.
+func (store *LDAPSchemaStore) ReadJSON(r io.Reader) (err error) {
+	var aux struct {
+		storeJSONTexts
+		StructuredStoreJSON
+	}
+	if err = json.NewDecoder(r).Decode(&aux); nil != err {
+		return
+	}
+	if err = store.addJSONTexts(&aux.storeJSONTexts); nil != err {
+		return
+	}
+	return store.addStructuredJSON(&aux.StructuredStoreJSON)
+}
+
+// ReadFromJSONFile read content into store from JSON file at given path.
+func (store *LDAPSchemaStore) ReadFromJSONFile(name string) (err error) {
+	fp, err := os.Open(name)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	if err = store.ReadJSON(fp); nil != err {
+		log.Printf("ERROR: failed on reading JSON from file (file=%v, err=%v)", name, err)
+	}
+	return
+}
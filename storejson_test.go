@@ -0,0 +1,131 @@
+package ldapschemaparser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func storeJSONText(t *testing.T, store *LDAPSchemaStore) string {
+	var b strings.Builder
+	if err := store.WriteJSON(&b); nil != err {
+		t.Fatalf("failed on writing JSON: %v", err)
+	}
+	return b.String()
+}
+
+func TestLDAPSchemaStoreReadJSON_1(t *testing.T) {
+	store := makeGoStructSampleStore(t)
+	addDITStructureSampleRules(t, store)
+	store.AddMatchingRuleUseSchemaText("( 2.5.13.2 NAME 'caseIgnoreMatch' APPLIES ( name $ description ) )")
+	store.AddAttributeTypeSchemaText("( 1.3.6.1.4.1.99999.1.15 NAME ( 'sampleLabel' 'sampleTag' ) DESC 'printed label' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32} SINGLE-VALUE NO-USER-MODIFICATION USAGE dSAOperation X-ORIGIN ( 'sample' 'test' ) )")
+	expect := storeJSONText(t, store)
+	for _, write := range []func(store *LDAPSchemaStore, name string) error{
+		(*LDAPSchemaStore).WriteToJSONFile,
+		(*LDAPSchemaStore).WriteToStructuredJSONFile,
+	} {
+		dirPath, err := ioutil.TempDir("", "storejson")
+		if nil != err {
+			t.Fatalf("cannot create temporary folder: %v", err)
+		}
+		defer os.RemoveAll(dirPath)
+		name := filepath.Join(dirPath, "store.json")
+		if err = write(store, name); nil != err {
+			t.Fatalf("failed on writing JSON file: %v", err)
+		}
+		reloaded := NewLDAPSchemaStore()
+		if err = reloaded.ReadFromJSONFile(name); nil != err {
+			t.Fatalf("failed on reading JSON file: %v", err)
+		}
+		if v := storeJSONText(t, reloaded); v != expect {
+			t.Errorf("expecting reloaded store:\n%v\nbut have:\n%v", expect, v)
+		}
+	}
+}
+
+func TestLDAPSchemaStoreReadJSON_2(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	err := store.ReadJSON(strings.NewReader(`{
+  "ldap_syntax": ["( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )"],
+  "attribute_types": [
+    {"oid": "1.3.6.1.4.1.99999.1.15", "name": ["sampleLabel"], "syntax": "1.3.6.1.4.1.1466.115.121.1.15{32}", "single_value": true,
+     "extensions": {"X-ORIGIN": ["sample"]}}
+  ],
+  "object_classes": [
+    {"oid": "1.3.6.1.4.1.99999.2.5", "name": ["sampleLabeled"], "kind": "AUXILIARY", "must": ["sampleLabel"]}
+  ],
+  "dit_structure_rules": [{"rule_id": "7", "form": "sampleForm", "sup": ["1", "2"]}]
+}`))
+	if nil != err {
+		t.Fatalf("failed on reading JSON: %v", err)
+	}
+	expect := `{"ldap_syntax":["( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )"],` +
+		`"attribute_type":["( 1.3.6.1.4.1.99999.1.15 NAME 'sampleLabel' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{32} SINGLE-VALUE X-ORIGIN 'sample' )"],` +
+		`"object_class":["( 1.3.6.1.4.1.99999.2.5 NAME 'sampleLabeled' AUXILIARY MUST sampleLabel )"],` +
+		`"dit_structure_rule":["( 7 FORM sampleForm SUP ( 1 2 ) )"]}`
+	if v := storeJSONText(t, store); v != expect {
+		t.Errorf("expecting:\n%v\nbut have:\n%v", expect, v)
+	}
+	attributeTypeSchema, err := store.AttributeType("sampleLabel")
+	if nil != err {
+		t.Fatalf("failed on looking up attribute type: %v", err)
+	}
+	if (attributeTypeSchema.SyntaxOID != "1.3.6.1.4.1.1466.115.121.1.15") || (attributeTypeSchema.SyntaxLength != 32) {
+		t.Errorf("unexpected syntax of reloaded attribute type: %#v", attributeTypeSchema)
+	}
+	if err = NewLDAPSchemaStore().ReadJSON(strings.NewReader(`{"object_classes": [{"name": ["sampleNoOID"]}]}`)); ErrMissingNumericOID != err {
+		t.Errorf("expecting missing numeric OID error but have: %v", err)
+	}
+	if err = NewLDAPSchemaStore().ReadJSON(strings.NewReader(`{"dit_structure_rules": [{"name": ["sampleNoRuleID"]}]}`)); ErrMissingRuleID != err {
+		t.Errorf("expecting missing rule ID error but have: %v", err)
+	}
+	if err = NewLDAPSchemaStore().ReadJSON(strings.NewReader(`{"attribute_type": ["( 1.2.3 NAME )"]}`)); nil == err {
+		t.Error("expecting error for invalid schema text")
+	}
+}
+
+func TestLDAPSchemaStoreWriteStructuredJSON_1(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	store.AddAttributeTypeSchemaText("( 2.5.4.3 NAME ( 'cn' 'commonName' ) SUP sampleName X-ORIGIN 'RFC 4519' )")
+	store.AddObjectClassSchemaText("( 2.5.6.0 NAME 'top' ABSTRACT MUST objectClass )")
+	var b strings.Builder
+	if err := store.WriteStructuredJSON(&b); nil != err {
+		t.Fatalf("failed on writing structured JSON: %v", err)
+	}
+	expect := `{
+  "attribute_types": [
+    {
+      "oid": "2.5.4.3",
+      "name": [
+        "cn",
+        "commonName"
+      ],
+      "sup": "sampleName",
+      "usage": "userApplications",
+      "extensions": {
+        "X-ORIGIN": [
+          "RFC 4519"
+        ]
+      }
+    }
+  ],
+  "object_classes": [
+    {
+      "oid": "2.5.6.0",
+      "name": [
+        "top"
+      ],
+      "kind": "ABSTRACT",
+      "must": [
+        "objectClass"
+      ]
+    }
+  ]
+}
+`
+	if v := b.String(); v != expect {
+		t.Errorf("expecting:\n%v\nbut have:\n%v", expect, v)
+	}
+}
@@ -0,0 +1,184 @@
+package ldapschemaparser
+
+// Lookups of typed schema in store. Names are matched case-insensitively.
+// ErrSchemaNotFound is returned when schema does not exist in store.
+
+func (store *LDAPSchemaStore) lookupGenericSchema(kind SchemaKind, identifier string, genericSchema *GenericSchema) (*GenericSchema, error) {
+	if nil == genericSchema {
+		return nil, &ErrSchemaNotFound{
+			Kind:       kind,
+			Identifier: identifier,
+		}
+	}
+	return genericSchema, nil
+}
+
+// LDAPSyntax returns LDAP syntax of given numeric OID.
+func (store *LDAPSchemaStore) LDAPSyntax(numericOID string) (result *LDAPSyntaxSchema, err error) {
+	genericSchema, err := store.lookupGenericSchema(SchemaKindLDAPSyntax, numericOID, store.ldapSyntaxSchemaIndex[numericOID])
+	if nil != err {
+		return
+	}
+	return NewLDAPSyntaxSchemaViaGenericSchema(genericSchema)
+}
+
+// LDAPSyntaxes returns LDAP syntaxes in store ordered by numeric OID.
+func (store *LDAPSchemaStore) LDAPSyntaxes() (result []*LDAPSyntaxSchema, err error) {
+	for _, key := range sortedOIDMapKey(store.ldapSyntaxSchemaIndex) {
+		s, err := NewLDAPSyntaxSchemaViaGenericSchema(store.ldapSyntaxSchemaIndex[key])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return
+}
+
+// MatchingRule returns matching rule of given name or numeric OID.
+func (store *LDAPSchemaStore) MatchingRule(identifier string) (result *MatchingRuleSchema, err error) {
+	genericSchema, err := store.lookupGenericSchema(SchemaKindMatchingRule, identifier, store.findMatchingRuleGenericSchema(identifier))
+	if nil != err {
+		return
+	}
+	return NewMatchingRuleSchemaViaGenericSchema(genericSchema)
+}
+
+// MatchingRules returns matching rules in store ordered by numeric OID.
+func (store *LDAPSchemaStore) MatchingRules() (result []*MatchingRuleSchema, err error) {
+	for _, key := range sortedOIDMapKey(store.matchingRuleSchemaIndex) {
+		s, err := NewMatchingRuleSchemaViaGenericSchema(store.matchingRuleSchemaIndex[key])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return
+}
+
+// MatchingRuleUse returns matching rule use of given name. Name or numeric
+// OID of the matching rule is also accepted.
+func (store *LDAPSchemaStore) MatchingRuleUse(identifier string) (result *MatchingRuleUseSchema, err error) {
+	genericSchema, err := store.lookupGenericSchema(SchemaKindMatchingRuleUse, identifier, store.findMatchingRuleUseGenericSchema(identifier))
+	if nil != err {
+		return
+	}
+	return NewMatchingRuleUseSchemaViaGenericSchema(genericSchema)
+}
+
+// MatchingRuleUses returns matching rule uses in store ordered by numeric OID.
+func (store *LDAPSchemaStore) MatchingRuleUses() (result []*MatchingRuleUseSchema, err error) {
+	for _, key := range sortedOIDMapKey(store.matchingRuleUseSchemaIndex) {
+		s, err := NewMatchingRuleUseSchemaViaGenericSchema(store.matchingRuleUseSchemaIndex[key])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return
+}
+
+// AttributeType returns attribute type of given name or numeric OID.
+func (store *LDAPSchemaStore) AttributeType(identifier string) (result *AttributeTypeSchema, err error) {
+	genericSchema, err := store.lookupGenericSchema(SchemaKindAttributeType, identifier, store.findAttributeTypeGenericSchema(identifier))
+	if nil != err {
+		return
+	}
+	return NewAttributeTypeSchemaViaGenericSchema(genericSchema)
+}
+
+// AttributeTypes returns attribute types in store ordered by numeric OID.
+func (store *LDAPSchemaStore) AttributeTypes() (result []*AttributeTypeSchema, err error) {
+	for _, key := range sortedOIDMapKey(store.attributeTypeSchemaIndex) {
+		s, err := NewAttributeTypeSchemaViaGenericSchema(store.attributeTypeSchemaIndex[key])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return
+}
+
+// ObjectClass returns object class of given name or numeric OID.
+func (store *LDAPSchemaStore) ObjectClass(identifier string) (result *ObjectClassSchema, err error) {
+	genericSchema, err := store.lookupGenericSchema(SchemaKindObjectClass, identifier, store.findObjectClassGenericSchema(identifier))
+	if nil != err {
+		return
+	}
+	return NewObjectClassSchemaViaGenericSchema(genericSchema)
+}
+
+// ObjectClasses returns object classes in store ordered by numeric OID.
+func (store *LDAPSchemaStore) ObjectClasses() (result []*ObjectClassSchema, err error) {
+	for _, key := range sortedOIDMapKey(store.objectClassSchemaIndex) {
+		s, err := NewObjectClassSchemaViaGenericSchema(store.objectClassSchemaIndex[key])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return
+}
+
+// DITContentRule returns DIT content rule of given name. Name or numeric
+// OID of the structural object class is also accepted.
+func (store *LDAPSchemaStore) DITContentRule(identifier string) (result *DITContentRuleSchema, err error) {
+	genericSchema, err := store.lookupGenericSchema(SchemaKindDITContentRule, identifier, store.findDITContentRuleGenericSchema(identifier))
+	if nil != err {
+		return
+	}
+	return NewDITContentRuleSchemaViaGenericSchema(genericSchema)
+}
+
+// DITContentRules returns DIT content rules in store ordered by numeric OID.
+func (store *LDAPSchemaStore) DITContentRules() (result []*DITContentRuleSchema, err error) {
+	for _, key := range sortedOIDMapKey(store.ditContentRuleSchemaIndex) {
+		s, err := NewDITContentRuleSchemaViaGenericSchema(store.ditContentRuleSchemaIndex[key])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return
+}
+
+// DITStructureRule returns DIT structure rule of given rule ID or name.
+func (store *LDAPSchemaStore) DITStructureRule(identifier string) (result *DITStructureRuleSchema, err error) {
+	genericSchema, err := store.lookupGenericSchema(SchemaKindDITStructureRule, identifier, store.findDITStructureRuleGenericSchema(identifier))
+	if nil != err {
+		return
+	}
+	return NewDITStructureRuleSchemaViaGenericSchema(genericSchema)
+}
+
+// DITStructureRules returns DIT structure rules in store ordered by rule ID.
+func (store *LDAPSchemaStore) DITStructureRules() (result []*DITStructureRuleSchema, err error) {
+	for _, key := range sortedRuleIDMapKey(store.ditStructureRuleSchemaIndex) {
+		s, err := NewDITStructureRuleSchemaViaGenericSchema(store.ditStructureRuleSchemaIndex[key])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return
+}
+
+// NameForm returns name form of given name or numeric OID.
+func (store *LDAPSchemaStore) NameForm(identifier string) (result *NameFormSchema, err error) {
+	genericSchema, err := store.lookupGenericSchema(SchemaKindNameForm, identifier, store.findNameFormGenericSchema(identifier))
+	if nil != err {
+		return
+	}
+	return NewNameFormSchemaViaGenericSchema(genericSchema)
+}
+
+// NameForms returns name forms in store ordered by numeric OID.
+func (store *LDAPSchemaStore) NameForms() (result []*NameFormSchema, err error) {
+	for _, key := range sortedOIDMapKey(store.nameFormSchemaIndex) {
+		s, err := NewNameFormSchemaViaGenericSchema(store.nameFormSchemaIndex[key])
+		if nil != err {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return
+}
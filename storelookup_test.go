@@ -0,0 +1,166 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func makeStoreLookupSampleStore(t *testing.T) *LDAPSchemaStore {
+	store := makeDITStructureSampleStore(t)
+	for _, schemaText := range []string{
+		"( 10 NAME 'sampleTenRule' FORM sampleUnitNameForm SUP 2 )",
+		"( 2 NAME 'sampleUnitRule' FORM sampleUnitNameForm )",
+	} {
+		if err := store.AddDITStructureRuleSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding DIT structure rule: %v", err)
+		}
+	}
+	if err := store.AddLDAPSyntaxSchemaText("( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )"); nil != err {
+		t.Fatalf("failed on adding LDAP syntax: %v", err)
+	}
+	if err := store.AddMatchingRuleSchemaText("( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )"); nil != err {
+		t.Fatalf("failed on adding matching rule: %v", err)
+	}
+	if err := store.AddMatchingRuleUseSchemaText("( 2.5.13.2 NAME 'caseIgnoreMatch' APPLIES ( cn $ sn ) )"); nil != err {
+		t.Fatalf("failed on adding matching rule use: %v", err)
+	}
+	if err := store.AddDITContentRuleSchemaText("( 2.5.6.6 NAME 'samplePersonContentRule' AUX sampleMailbox )"); nil != err {
+		t.Fatalf("failed on adding DIT content rule: %v", err)
+	}
+	return store
+}
+
+func TestLDAPSchemaStoreLookup_1(t *testing.T) {
+	store := makeStoreLookupSampleStore(t)
+	if s, err := store.AttributeType("COMMONNAME"); nil != err {
+		t.Errorf("failed on looking up attribute type: %v", err)
+	} else if s.NumericOID != "2.5.4.3" {
+		t.Errorf("unexpected attribute type: %v", s)
+	}
+	if s, err := store.AttributeType("2.5.4.4"); (nil != err) || (s.Name[0] != "sn") {
+		t.Errorf("unexpected attribute type: %v, %v", s, err)
+	}
+	if s, err := store.ObjectClass("Person"); (nil != err) || (s.NumericOID != "2.5.6.6") {
+		t.Errorf("unexpected object class: %v, %v", s, err)
+	}
+	if s, err := store.MatchingRule("CASEIGNOREMATCH"); (nil != err) || (s.NumericOID != "2.5.13.2") {
+		t.Errorf("unexpected matching rule: %v, %v", s, err)
+	}
+	if s, err := store.LDAPSyntax("1.3.6.1.4.1.1466.115.121.1.15"); (nil != err) || (s.Description != "Directory String") {
+		t.Errorf("unexpected LDAP syntax: %v, %v", s, err)
+	}
+	for _, identifier := range []string{"caseignorematch", "2.5.13.2"} {
+		if s, err := store.MatchingRuleUse(identifier); (nil != err) || (len(s.AppliesTo) != 2) {
+			t.Errorf("unexpected matching rule use of %s: %v, %v", identifier, s, err)
+		}
+	}
+	for _, identifier := range []string{"samplePersonContentRule", "PERSON", "2.5.6.6"} {
+		if s, err := store.DITContentRule(identifier); (nil != err) || (s.NumericOID != "2.5.6.6") {
+			t.Errorf("unexpected DIT content rule of %s: %v, %v", identifier, s, err)
+		}
+	}
+	for _, identifier := range []string{"10", "SAMPLETENRULE"} {
+		if s, err := store.DITStructureRule(identifier); (nil != err) || (s.RuleID != "10") {
+			t.Errorf("unexpected DIT structure rule of %s: %v, %v", identifier, s, err)
+		}
+	}
+	for _, identifier := range []string{"sampleunitnameform", "1.3.6.1.4.1.99999.3.2"} {
+		if s, err := store.NameForm(identifier); (nil != err) || (s.ObjectClass != "organizationalUnit") {
+			t.Errorf("unexpected name form of %s: %v, %v", identifier, s, err)
+		}
+	}
+	_, err := store.ObjectClass("sampleMissing")
+	if notFound, ok := err.(*ErrSchemaNotFound); !ok {
+		t.Errorf("expecting not found error but have %v", err)
+	} else if v := notFound.Error(); v != "object-class not found: sampleMissing" {
+		t.Errorf("unexpected error message: %v", v)
+	}
+	if _, err = store.DITStructureRule("3"); nil == err {
+		t.Error("expecting error for missing DIT structure rule")
+	}
+}
+
+func TestLDAPSchemaStoreLookup_2(t *testing.T) {
+	store := makeStoreLookupSampleStore(t)
+	attributeTypes, err := store.AttributeTypes()
+	if nil != err {
+		t.Fatalf("failed on listing attribute types: %v", err)
+	}
+	var oids []string
+	for _, s := range attributeTypes {
+		oids = append(oids, s.NumericOID)
+	}
+	if v := strings.Join(oids, " "); v != "0.9.2342.19200300.100.1.1 0.9.2342.19200300.100.1.3 2.5.4.0 2.5.4.3 2.5.4.4 2.5.4.10 2.5.4.11 2.5.4.13" {
+		t.Errorf("unexpected attribute types: %v", v)
+	}
+	ditStructureRules, err := store.DITStructureRules()
+	if nil != err {
+		t.Fatalf("failed on listing DIT structure rules: %v", err)
+	}
+	if (len(ditStructureRules) != 2) || (ditStructureRules[0].RuleID != "2") || (ditStructureRules[1].RuleID != "10") {
+		t.Errorf("unexpected DIT structure rules: %v", ditStructureRules)
+	}
+	counts := []int{}
+	for _, count := range []func() (int, error){
+		func() (int, error) { s, err := store.LDAPSyntaxes(); return len(s), err },
+		func() (int, error) { s, err := store.MatchingRules(); return len(s), err },
+		func() (int, error) { s, err := store.MatchingRuleUses(); return len(s), err },
+		func() (int, error) { s, err := store.ObjectClasses(); return len(s), err },
+		func() (int, error) { s, err := store.DITContentRules(); return len(s), err },
+		func() (int, error) { s, err := store.NameForms(); return len(s), err },
+	} {
+		c, err := count()
+		if nil != err {
+			t.Fatalf("failed on listing schemas: %v", err)
+		}
+		counts = append(counts, c)
+	}
+	if v := fmt.Sprint(counts); v != "[1 1 1 5 1 3]" {
+		t.Errorf("unexpected schema counts: %v", v)
+	}
+}
+
+func TestLDAPSchemaStoreNameIndex_1(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.3.1 NAME 'sampleForm' OC sampleA MUST cn )",
+		"( 1.3.6.1.4.1.99999.3.2 NAME 'sampleForm' OC sampleB MUST cn )",
+		"( 1.3.6.1.4.1.99999.3.1 NAME ( 'sampleForm' 'sampleFormAlias' ) OC sampleA MUST cn )",
+	} {
+		if err := store.AddNameFormSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding name form: %v", err)
+		}
+	}
+	if genericSchema := store.nameFormNameIndex["sampleform"]; (nil == genericSchema) || (genericSchema.NumericOID != "1.3.6.1.4.1.99999.3.1") {
+		t.Errorf("expecting conflicting name over-written by the last added name form: %v", genericSchema)
+	}
+	if genericSchema := store.nameFormNameIndex["sampleformalias"]; (nil == genericSchema) || (genericSchema.NumericOID != "1.3.6.1.4.1.99999.3.1") {
+		t.Errorf("expecting all names of merged name form indexed: %v", genericSchema)
+	}
+	if err := store.AddDITStructureRuleSchemaText("( 7 NAME 'sampleRule' FORM sampleFormAlias )"); nil != err {
+		t.Fatalf("failed on adding DIT structure rule: %v", err)
+	}
+	if err := store.AddDITContentRuleSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleContentRule' MAY cn )"); nil != err {
+		t.Fatalf("failed on adding DIT content rule: %v", err)
+	}
+	if err := store.AddMatchingRuleUseSchemaText("( 2.5.13.2 NAME 'caseIgnoreMatch' APPLIES cn )"); nil != err {
+		t.Fatalf("failed on adding matching rule use: %v", err)
+	}
+	if nil == store.ditStructureRuleNameIndex["samplerule"] {
+		t.Error("expecting DIT structure rule in name index")
+	}
+	if nil == store.ditContentRuleNameIndex["samplecontentrule"] {
+		t.Error("expecting DIT content rule in name index")
+	}
+	if nil == store.matchingRuleUseNameIndex["caseignorematch"] {
+		t.Error("expecting matching rule use in name index")
+	}
+	loader := NewOpenLDAPSchemaLoader(store)
+	if err := loader.Read(strings.NewReader("ditcontentrule ( 1.3.6.1.4.1.99999.2.2 NAME 'sampleOtherContentRule' MAY cn )\n")); nil != err {
+		t.Fatalf("failed on loading DIT content rule: %v", err)
+	}
+	if s, err := store.DITContentRule("SAMPLEOTHERCONTENTRULE"); (nil != err) || (s.NumericOID != "1.3.6.1.4.1.99999.2.2") {
+		t.Errorf("unexpected DIT content rule loaded from OpenLDAP schema: %v, %v", s, err)
+	}
+}
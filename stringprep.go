@@ -0,0 +1,157 @@
+package ldapschemaparser
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// String preparation of RFC-4518.
+
+// isStringPrepMappedToNothing check characters mapped to nothing in RFC-4518 2.2.
+func isStringPrepMappedToNothing(ch rune) bool {
+	switch {
+	case (ch == 0x00AD) || (ch == 0x1806) || (ch == 0x034F) || (ch == 0x200B) || (ch == 0xFFFC):
+		return true
+	case (ch >= 0x180B) && (ch <= 0x180D):
+		return true
+	case (ch >= 0xFE00) && (ch <= 0xFE0F):
+		return true
+	case (ch <= 0x0008) || ((ch >= 0x000E) && (ch <= 0x001F)) || ((ch >= 0x007F) && (ch <= 0x0084)) || ((ch >= 0x0086) && (ch <= 0x009F)):
+		return true
+	case (ch == 0x070F) || (ch == 0x180E) || ((ch >= 0x200C) && (ch <= 0x200F)) || ((ch >= 0x202A) && (ch <= 0x202E)):
+		return true
+	case ((ch >= 0x2060) && (ch <= 0x2063)) || ((ch >= 0x206A) && (ch <= 0x206F)) || (ch == 0xFEFF):
+		return true
+	case ((ch >= 0xFFF9) && (ch <= 0xFFFB)) || ((ch >= 0x1D173) && (ch <= 0x1D17A)) || (ch == 0xE0001) || ((ch >= 0xE0020) && (ch <= 0xE007F)):
+		return true
+	}
+	return false
+}
+
+// isStringPrepMappedToSpace check characters mapped to SPACE in RFC-4518 2.2.
+func isStringPrepMappedToSpace(ch rune) bool {
+	switch {
+	case ((ch >= 0x0009) && (ch <= 0x000D)) || (ch == 0x0085):
+		return true
+	case (ch == 0x00A0) || (ch == 0x1680) || ((ch >= 0x2000) && (ch <= 0x200A)):
+		return true
+	case (ch == 0x2028) || (ch == 0x2029) || (ch == 0x202F) || (ch == 0x205F) || (ch == 0x3000):
+		return true
+	}
+	return false
+}
+
+// isStringPrepProhibited check characters prohibited in RFC-4518 2.4.
+func isStringPrepProhibited(ch rune) bool {
+	switch {
+	case ch == utf8.RuneError:
+		return true
+	case ((ch >= 0xE000) && (ch <= 0xF8FF)) || ((ch >= 0xF0000) && (ch <= 0xFFFFD)) || ((ch >= 0x100000) && (ch <= 0x10FFFD)):
+		return true
+	case ((ch >= 0xFDD0) && (ch <= 0xFDEF)) || ((ch & 0xFFFE) == 0xFFFE):
+		return true
+	case (ch >= 0xD800) && (ch <= 0xDFFF):
+		return true
+	}
+	return false
+}
+
+// foldCase maps value with case folding for use with NFKC (RFC-3454
+// Table B.2). Full case folding is applied before and after NFKC so
+// compatibility characters normalized into upper case letters are also
+// folded.
+func foldCase(value string) string {
+	return cases.Fold().String(norm.NFKC.String(cases.Fold().String(value)))
+}
+
+// prepareStringCharacters applies transcode, map, normalize (NFKC) and
+// prohibit steps of RFC-4518. Case is folded in map step when caseFold is
+// set.
+func prepareStringCharacters(value string, caseFold bool) (result string, err error) {
+	if !utf8.ValidString(value) {
+		return "", errors.New("invalid UTF-8 sequence")
+	}
+	var b strings.Builder
+	for _, ch := range value {
+		if isStringPrepMappedToNothing(ch) {
+			continue
+		}
+		if isStringPrepMappedToSpace(ch) {
+			ch = ' '
+		}
+		b.WriteRune(ch)
+	}
+	result = b.String()
+	if caseFold {
+		result = foldCase(result)
+	}
+	result = norm.NFKC.String(result)
+	for _, ch := range result {
+		if isStringPrepProhibited(ch) {
+			return "", errors.New("prohibited character " + strings.ToUpper(string(ch)))
+		}
+	}
+	return result, nil
+}
+
+// collapseSpaces replace each run of spaces with one space.
+func collapseSpaces(value string) string {
+	var b strings.Builder
+	previousSpace := false
+	for _, ch := range value {
+		if ch == ' ' {
+			if previousSpace {
+				continue
+			}
+			previousSpace = true
+		} else {
+			previousSpace = false
+		}
+		b.WriteRune(ch)
+	}
+	return b.String()
+}
+
+// prepareString prepares value for equality and ordering matching.
+// Leading and trailing spaces are removed and inner spaces are collapsed
+// as insignificant space handling of RFC-4518 2.6.1.
+func prepareString(value string, caseFold bool) (result string, err error) {
+	if result, err = prepareStringCharacters(value, caseFold); nil != err {
+		return
+	}
+	return strings.Trim(collapseSpaces(result), " "), nil
+}
+
+// prepareSubstring prepares component of substring assertion. Inner spaces
+// are collapsed but spaces at edges are kept as they are significant
+// between components.
+func prepareSubstring(value string, caseFold bool) (result string, err error) {
+	if result, err = prepareStringCharacters(value, caseFold); nil != err {
+		return
+	}
+	return collapseSpaces(result), nil
+}
+
+// removeCharacters removes characters which are insignificant for numeric
+// string and telephone number matching. (RFC-4518 2.6.2 and 2.6.3)
+func removeCharacters(value string, removable func(ch rune) bool) string {
+	var b strings.Builder
+	for _, ch := range value {
+		if !removable(ch) {
+			b.WriteRune(ch)
+		}
+	}
+	return b.String()
+}
+
+func isTelephoneNumberInsignificant(ch rune) bool {
+	switch ch {
+	case ' ', '-', 0x058A, 0x2010, 0x2011, 0x2212, 0xFE63, 0xFF0D:
+		return true
+	}
+	return false
+}
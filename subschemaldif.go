@@ -0,0 +1,150 @@
+package ldapschemaparser
+
+import (
+	"bufio"
+	"io"
+	"os"
+)
+
+const extensionKeywordOrigin = "X-ORIGIN"
+
+// SubschemaLDIFOption configures output of `cn=schema` subschema LDIF as
+// used by 389 Directory Server and other generic directory servers.
+type SubschemaLDIFOption struct {
+	// DN of subschema entry. Default to `cn=schema` when empty.
+	DN string
+
+	IncludeLDAPSyntaxes     bool
+	IncludeMatchingRules    bool
+	IncludeMatchingRuleUses bool
+
+	// DefaultOrigin is added as X-ORIGIN of attribute types and object
+	// classes which do not have one when not empty.
+	DefaultOrigin string
+}
+
+func (option *SubschemaLDIFOption) entryDN() string {
+	if (nil == option) || ("" == option.DN) {
+		return "cn=schema"
+	}
+	return option.DN
+}
+
+func applyDefaultOrigin(extensions map[string][]string, defaultOrigin string) map[string][]string {
+	if "" == defaultOrigin {
+		return extensions
+	}
+	if _, ok := extensions[extensionKeywordOrigin]; ok {
+		return extensions
+	}
+	if nil == extensions {
+		extensions = make(map[string][]string)
+	}
+	extensions[extensionKeywordOrigin] = []string{defaultOrigin}
+	return extensions
+}
+
+func (store *LDAPSchemaStore) collectSubschemaAttributeTypes(defaultOrigin string) (result []string, err error) {
+	attributeTypeSchemas, err := store.makeDependencyOrderedAttributeTypeSchemas()
+	if nil != err {
+		return
+	}
+	for _, attributeTypeSchema := range attributeTypeSchemas {
+		attributeTypeSchema.Extensions = applyDefaultOrigin(attributeTypeSchema.Extensions, defaultOrigin)
+		result = append(result, attributeTypeSchema.String())
+	}
+	return
+}
+
+func (store *LDAPSchemaStore) collectSubschemaObjectClasses(defaultOrigin string) (result []string, err error) {
+	objectClassSchemas, err := store.makeDependencyOrderedObjectClassSchemas()
+	if nil != err {
+		return
+	}
+	for _, objectClassSchema := range objectClassSchemas {
+		objectClassSchema.Extensions = applyDefaultOrigin(objectClassSchema.Extensions, defaultOrigin)
+		result = append(result, objectClassSchema.String())
+	}
+	return
+}
+
+func writeLDIFAttributeValues(w io.Writer, attrName string, values []string) (err error) {
+	for _, value := range values {
+		if err = writeLDIFAttributeValue(w, attrName, value); nil != err {
+			return
+		}
+	}
+	return nil
+}
+
+// WriteSubschemaLDIF writes content of store as a `cn=schema` subschema entry in LDIF form.
+func (store *LDAPSchemaStore) WriteSubschemaLDIF(w io.Writer, option *SubschemaLDIFOption) (err error) {
+	if nil == option {
+		option = &SubschemaLDIFOption{}
+	}
+	var ldapSyntaxes, matchingRules, matchingRuleUses []string
+	if option.IncludeLDAPSyntaxes {
+		if ldapSyntaxes, err = store.collectLDAPSyntaxSchemaTexts(true); nil != err {
+			return
+		}
+	}
+	if option.IncludeMatchingRules {
+		if matchingRules, err = store.collectMatchingRuleSchemaTexts(true); nil != err {
+			return
+		}
+	}
+	if option.IncludeMatchingRuleUses {
+		if matchingRuleUses, err = store.collectMatchingRuleUseSchemaTexts(true); nil != err {
+			return
+		}
+	}
+	attributeTypes, err := store.collectSubschemaAttributeTypes(option.DefaultOrigin)
+	if nil != err {
+		return
+	}
+	objectClasses, err := store.collectSubschemaObjectClasses(option.DefaultOrigin)
+	if nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValue(w, "dn", option.entryDN()); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValues(w, "objectClass", []string{"top", "ldapSubentry", "subschema"}); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValue(w, "cn", "schema"); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValues(w, "ldapSyntaxes", ldapSyntaxes); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValues(w, "matchingRules", matchingRules); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValues(w, "matchingRuleUse", matchingRuleUses); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValues(w, "attributeTypes", attributeTypes); nil != err {
+		return
+	}
+	if err = writeLDIFAttributeValues(w, "objectClasses", objectClasses); nil != err {
+		return
+	}
+	_, err = io.WriteString(w, "\n")
+	return
+}
+
+// WriteToSubschemaLDIFFile write content of store into file at given path
+// as `cn=schema` subschema LDIF.
+func (store *LDAPSchemaStore) WriteToSubschemaLDIFFile(name string, option *SubschemaLDIFOption) (err error) {
+	fp, err := os.Create(name)
+	if nil != err {
+		return
+	}
+	defer fp.Close()
+	w := bufio.NewWriter(fp)
+	if err = store.WriteSubschemaLDIF(w, option); nil != err {
+		return
+	}
+	return w.Flush()
+}
@@ -0,0 +1,90 @@
+package ldapschemaparser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-ldap/ldif"
+)
+
+func TestWriteSubschemaLDIF_1(t *testing.T) {
+	store := makeOpenLDAPConfigSampleStore(t)
+	if err := store.AddLDAPSyntaxSchemaText(sampleLDAPSyntax1); nil != err {
+		t.Fatalf("failed on adding LDAP syntax: %v", err)
+	}
+	if err := store.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.20 NAME 'sampleAux' AUXILIARY MAY sampleBase X-ORIGIN 'sample' )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	var buf bytes.Buffer
+	err := store.WriteSubschemaLDIF(&buf, &SubschemaLDIFOption{
+		IncludeLDAPSyntaxes: true,
+		DefaultOrigin:       "user defined",
+	})
+	if nil != err {
+		t.Fatalf("failed on writing LDIF: %v", err)
+	}
+	var ldifContent ldif.LDIF
+	if err = ldif.Unmarshal(strings.NewReader(buf.String()), &ldifContent); nil != err {
+		t.Fatalf("failed on reading back LDIF: %v\n%v", err, buf.String())
+	}
+	if (len(ldifContent.Entries) != 1) || (nil == ldifContent.Entries[0].Entry) {
+		t.Fatalf("expecting one entry: %v", buf.String())
+	}
+	entry := ldifContent.Entries[0].Entry
+	if entry.DN != "cn=schema" {
+		t.Errorf("unexpected DN: %v", entry.DN)
+	}
+	if v := entry.GetAttributeValues("ldapSyntaxes"); (len(v) != 1) || (v[0] != sampleLDAPSyntax1) {
+		t.Errorf("unexpected ldapSyntaxes: %v", v)
+	}
+	loaded := NewLDAPSchemaStore()
+	for _, schemaText := range entry.GetAttributeValues("attributeTypes") {
+		if err = loaded.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Errorf("failed on loading attribute type %v: %v", schemaText, err)
+		}
+	}
+	for _, schemaText := range entry.GetAttributeValues("objectClasses") {
+		if err = loaded.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Errorf("failed on loading object class %v: %v", schemaText, err)
+		}
+	}
+	if (len(loaded.attributeTypeSchemaIndex) != 2) || (len(loaded.objectClassSchemaIndex) != 3) {
+		t.Errorf("unexpected loaded element count: %d attribute types, %d object classes", len(loaded.attributeTypeSchemaIndex), len(loaded.objectClassSchemaIndex))
+	}
+	objectClassSchema, err := NewObjectClassSchemaViaGenericSchema(loaded.objectClassNameIndex["sampleaux"])
+	if nil != err {
+		t.Fatalf("failed on converting object class: %v", err)
+	}
+	if origin := objectClassSchema.Extensions[extensionKeywordOrigin]; (len(origin) != 1) || (origin[0] != "sample") {
+		t.Errorf("expecting existed X-ORIGIN kept: %v", origin)
+	}
+	attributeTypeSchema, err := NewAttributeTypeSchemaViaGenericSchema(loaded.attributeTypeNameIndex["samplebase"])
+	if nil != err {
+		t.Fatalf("failed on converting attribute type: %v", err)
+	}
+	if origin := attributeTypeSchema.Extensions[extensionKeywordOrigin]; (len(origin) != 1) || (origin[0] != "user defined") {
+		t.Errorf("expecting default X-ORIGIN: %v", origin)
+	}
+}
+
+func TestWriteSubschemaLDIF_2(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.2.1 NAME 'sampleLoopA' SUP sampleLoopB AUXILIARY )",
+		"( 1.3.6.1.4.1.99999.2.2 NAME 'sampleLoopB' SUP sampleLoopA AUXILIARY )",
+	} {
+		if err := store.AddObjectClassSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding object class: %v", err)
+		}
+	}
+	var b bytes.Buffer
+	err := store.WriteSubschemaLDIF(&b, nil)
+	cyclicSuperior, ok := err.(*ErrCyclicSuperior)
+	if !ok {
+		t.Fatalf("expecting cyclic superior error: %v", err)
+	}
+	if v := strings.Join(cyclicSuperior.Chain, " "); v != "1.3.6.1.4.1.99999.2.1 1.3.6.1.4.1.99999.2.2 1.3.6.1.4.1.99999.2.1" {
+		t.Errorf("unexpected cycle: %v", v)
+	}
+}
@@ -0,0 +1,118 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// SyntaxValidator checks if given value conforms to an LDAP syntax.
+// A non-nil error describes why the value is rejected.
+type SyntaxValidator func(value string) error
+
+// SyntaxValidatorRegistry holds syntax validators keyed by numeric OID of syntax.
+type SyntaxValidatorRegistry struct {
+	validators map[string]SyntaxValidator
+}
+
+// NewSyntaxValidatorRegistry create an empty instance of SyntaxValidatorRegistry.
+func NewSyntaxValidatorRegistry() *SyntaxValidatorRegistry {
+	return &SyntaxValidatorRegistry{
+		validators: make(map[string]SyntaxValidator),
+	}
+}
+
+// NewBuiltinSyntaxValidatorRegistry create an instance of
+// SyntaxValidatorRegistry with validators of RFC-4517 and RFC-4523 syntaxes.
+func NewBuiltinSyntaxValidatorRegistry() *SyntaxValidatorRegistry {
+	registry := NewSyntaxValidatorRegistry()
+	for syntaxOID, validator := range builtinSyntaxValidators {
+		registry.Register(syntaxOID, validator)
+	}
+	return registry
+}
+
+// DefaultSyntaxValidatorRegistry is used by stores without their own registry.
+var DefaultSyntaxValidatorRegistry = NewBuiltinSyntaxValidatorRegistry()
+
+// Register set validator for syntax of given numeric OID.
+// Existed validator is replaced. Passing nil validator removes it.
+func (registry *SyntaxValidatorRegistry) Register(syntaxOID string, validator SyntaxValidator) {
+	if nil == validator {
+		delete(registry.validators, syntaxOID)
+		return
+	}
+	registry.validators[syntaxOID] = validator
+}
+
+// Lookup return validator of syntax of given numeric OID, or nil if not found.
+func (registry *SyntaxValidatorRegistry) Lookup(syntaxOID string) SyntaxValidator {
+	return registry.validators[syntaxOID]
+}
+
+// Validate checks value against syntax of given numeric OID.
+// Values of syntaxes without validator are accepted.
+func (registry *SyntaxValidatorRegistry) Validate(syntaxOID, value string) (err error) {
+	validator := registry.validators[syntaxOID]
+	if nil == validator {
+		return nil
+	}
+	if err = validator(value); nil != err {
+		return &ErrInvalidSyntaxValue{
+			SyntaxOID: syntaxOID,
+			Value:     value,
+			Reason:    err.Error(),
+		}
+	}
+	return nil
+}
+
+// ErrInvalidSyntaxValue indicates value does not conform to syntax.
+type ErrInvalidSyntaxValue struct {
+	Attribute string
+	SyntaxOID string
+	Value     string
+	Reason    string
+}
+
+func (invalidValue *ErrInvalidSyntaxValue) Error() string {
+	if "" != invalidValue.Attribute {
+		return fmt.Sprintf("invalid value %q of attribute %s (syntax %s): %s", invalidValue.Value, invalidValue.Attribute, invalidValue.SyntaxOID, invalidValue.Reason)
+	}
+	return fmt.Sprintf("invalid value %q of syntax %s: %s", invalidValue.Value, invalidValue.SyntaxOID, invalidValue.Reason)
+}
+
+// SetSyntaxValidatorRegistry set registry used by ValidateValue.
+// DefaultSyntaxValidatorRegistry is used when registry is nil.
+func (store *LDAPSchemaStore) SetSyntaxValidatorRegistry(registry *SyntaxValidatorRegistry) {
+	store.syntaxValidators = registry
+}
+
+func (store *LDAPSchemaStore) syntaxValidatorRegistry() *SyntaxValidatorRegistry {
+	if nil == store.syntaxValidators {
+		return DefaultSyntaxValidatorRegistry
+	}
+	return store.syntaxValidators
+}
+
+// ValidateValue checks value of attribute type of given name or numeric OID
+// against the syntax of attribute type. Syntax is inherited through SUP
+// chain. The `{len}` bound of syntax limits number of characters of value.
+func (store *LDAPSchemaStore) ValidateValue(attributeTypeName, value string) (err error) {
+	effective, err := store.EffectiveAttributeType(attributeTypeName)
+	if nil != err {
+		return
+	}
+	syntaxOID := effective.SyntaxOID()
+	if syntaxLength := effective.SyntaxLength(); (syntaxLength > 0) && (utf8.RuneCountInString(value) > int(syntaxLength)) {
+		return &ErrInvalidSyntaxValue{
+			Attribute: attributeTypeName,
+			SyntaxOID: syntaxOID,
+			Value:     value,
+			Reason:    fmt.Sprintf("longer than %d characters", syntaxLength),
+		}
+	}
+	if err = store.syntaxValidatorRegistry().Validate(syntaxOID, value); nil != err {
+		err.(*ErrInvalidSyntaxValue).Attribute = attributeTypeName
+	}
+	return
+}
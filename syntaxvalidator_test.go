@@ -0,0 +1,104 @@
+package ldapschemaparser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuiltinSyntaxValidators_1(t *testing.T) {
+	registry := NewBuiltinSyntaxValidatorRegistry()
+	testCases := []struct {
+		syntaxOID string
+		valids    []string
+		invalids  []string
+	}{
+		{SyntaxOIDBoolean, []string{"TRUE", "FALSE"}, []string{"true", "1", ""}},
+		{SyntaxOIDInteger, []string{"0", "-1", "1234567890123456789012"}, []string{"", "-", "-0", "01", "1.5"}},
+		{SyntaxOIDBitString, []string{"''B", "'0101'B"}, []string{"'012'B", "0101", "'01'"}},
+		{SyntaxOIDCountryString, []string{"TW", "us"}, []string{"TWN", "T", "T@"}},
+		{SyntaxOIDIA5String, []string{"", "user@example.net"}, []string{"café"}},
+		{SyntaxOIDPrintableString, []string{"Hello (World) 1+1=2?"}, []string{"", "a@b", "a_b"}},
+		{SyntaxOIDNumericString, []string{"15 079 672 281"}, []string{"", "12a"}},
+		{SyntaxOIDTelephoneNumber, []string{"+1 512 315 0280"}, []string{"", "+1#512"}},
+		{SyntaxOIDFacsimileTelephoneNumber, []string{"+61 3 9896 7801", "+81 3 347 7418$fineResolution"}, []string{"+1$unknownParam"}},
+		{SyntaxOIDTelexNumber, []string{"812345$81$Herrn"}, []string{"812345$81"}},
+		{SyntaxOIDPostalAddress, []string{"1234 Main St.$Anytown, CA 12345$USA", "\\241,000,000 Sweepstakes$PO Box 1000000$Anytown, CA 12345$USA"}, []string{"a$$b", "a\\20b", "a\\2"}},
+		{SyntaxOIDDirectoryString, []string{"中文"}, []string{"", "\xff"}},
+		{SyntaxOIDOID, []string{"1.2.3.4", "cn", "name-2"}, []string{"1", "1.02", "2cn", "c_n"}},
+		{SyntaxOIDUUID, []string{"597ae2f6-16a6-1027-98f4-d28b5365dc14"}, []string{"597ae2f616a6102798f4d28b5365dc14", "597ae2f6-16a6-1027-98f4-d28b5365dcxx"}},
+		{SyntaxOIDGeneralizedTime, []string{"199412161032Z", "199412160532-0500", "19941216103200.5Z", "2020010100Z"}, []string{"19941216103200", "199413161032Z", "1994121610Z1", "199412161032+05000"}},
+		{SyntaxOIDUTCTime, []string{"9412161032Z", "941216103200", "9412161032-0500"}, []string{"94121610Z", "9412161032-05"}},
+		{SyntaxOIDDN, []string{"", "UID=jsmith,DC=example,DC=net", "OU=Sales+CN=J.  Smith,DC=example,DC=net", "CN=James \\\"Jim\\\" Smith\\, III,DC=example,DC=net", "1.3.6.1.4.1.1466.0=#04024869", "CN=Lu\\C4\\8Di\\C4\\87"}, []string{"CN", "CN=a;b", "CN= a", "CN=a ,DC=b", "CN=#zz", "-CN=x", "CN=a\\x"}},
+		{SyntaxOIDNameAndOptionalUID, []string{"1.3.6.1.4.1.1466.0=#04024869,O=Test,C=GB#'0101'B", "CN=x"}, []string{"CN=x,#'01'B"}},
+		{SyntaxOIDDeliveryMethod, []string{"telephone", "telephone $ videotex"}, []string{"carrier-pigeon"}},
+		{SyntaxOIDOtherMailbox, []string{"smtp$user@example.net"}, []string{"user@example.net"}},
+		{SyntaxOIDJPEG, []string{"\xFF\xD8\xFF\xE0"}, []string{"GIF89a"}},
+		{SyntaxOIDCertificate, []string{"\x30\x03\x02\x01\x01"}, []string{"\x02\x01\x01", "\x30\x03\x02\x01\x01\x00"}},
+		{SyntaxOIDEnhancedGuide, []string{"person#(sn$EQ)#oneLevel", "2.5.6.6 # sn$EQ|!(cn$SUBSTR&?true) # wholeSubtree"}, []string{"person#(sn$EQ)", "person#sn$EQUAL#oneLevel", "person#(sn$EQ#base"}},
+		{SyntaxOIDGuide, []string{"sn$EQ", "person#(sn$EQ)|?false"}, []string{"", "sn", "person#sn$EQ#oneLevel", "(sn$EQ"}},
+		{SyntaxOIDTeletexTerminalIdentifier, []string{"abc", "abc$graphic:\\24x$page:"}, []string{"a@b", "abc$unknown:x", "abc$graphic", "abc$misc:\\41"}},
+		{SyntaxOIDSubstringAssertion, []string{"*", "ab*", "*cd", "ab*cd*ef", "a\\2Ab*"}, []string{"", "abc", "a**b", "a\\41*"}},
+		{SyntaxOIDCertificateExactAssertion, []string{`{ serialNumber 123456789, issuer rdnSequence:"CN=Example CA,O=Example Corp,C=US" }`}, []string{`{ serialNumber 1 }`, `{ issuer rdnSequence:"CN=a", serialNumber 1 }`, `{ serialNumber 1, issuer rdnSequence:"CN" }`, `{ serialNumber "1", issuer rdnSequence:"CN=a" }`, `{ serialNumber 1, issuer rdnSequence:"CN=a"`}},
+		{SyntaxOIDCertificateAssertion, []string{"{ }", `{ serialNumber 1, certificateValid utcTime:"9412161032Z", keyUsage { digitalSignature, keyCertSign }, subject rdnSequence:"CN=a" }`}, []string{`{ subject rdnSequence:"CN=a", serialNumber 1 }`, `{ certificateValid utcTime:"94121610Z" }`, `{ unknownField 1 }`}},
+		{SyntaxOIDCertificatePairExactAssertion, []string{`{ issuedToThisCAAssertion { serialNumber 1, issuer rdnSequence:"CN=a" } }`}, []string{`{ issuedToThisCAAssertion { serialNumber 1 } }`}},
+		{SyntaxOIDCertificatePairAssertion, []string{`{ issuedByThisCAAssertion { subject rdnSequence:"CN=a" } }`}, []string{`{ issuedByThisCAAssertion 1 }`}},
+		{SyntaxOIDCertificateListExactAssertion, []string{`{ issuer rdnSequence:"CN=CA", thisUpdate generalizedTime:"199412161032Z" }`}, []string{`{ issuer rdnSequence:"CN=CA" }`, `{ issuer rdnSequence:"CN=CA", thisUpdate localTime:"199412161032Z" }`}},
+		{SyntaxOIDCertificateListAssertion, []string{`{ minCRLNumber 1, maxCRLNumber 20 }`}, []string{`{ maxCRLNumber 20, minCRLNumber 1 }`}},
+		{SyntaxOIDAlgorithmIdentifier, []string{"{ algorithm 1.2.840.113549.1.1.5 }", "{ algorithm sha1WithRSAEncryption, parameters NULL }"}, []string{"{ parameters NULL }", "{ algorithm 1 }", "{ algorithm 1.2.3, parameters }", "algorithm 1.2.3"}},
+		{SyntaxOIDAttributeTypeDescription, []string{"( 2.5.4.41 NAME 'name' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )"}, []string{"( NAME 'name' )", "2.5.4.41"}},
+	}
+	for _, testCase := range testCases {
+		for _, value := range testCase.valids {
+			if err := registry.Validate(testCase.syntaxOID, value); nil != err {
+				t.Errorf("expecting valid value %q of syntax %s: %v", value, testCase.syntaxOID, err)
+			}
+		}
+		for _, value := range testCase.invalids {
+			if err := registry.Validate(testCase.syntaxOID, value); nil == err {
+				t.Errorf("expecting invalid value %q of syntax %s", value, testCase.syntaxOID)
+			}
+		}
+	}
+}
+
+func TestLDAPSchemaStoreValidateValue_1(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	for _, schemaText := range []string{
+		"( 2.5.4.41 NAME 'name' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15{8} )",
+		"( 2.5.4.3 NAME 'cn' SUP name )",
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleCount' SYNTAX 1.3.6.1.4.1.1466.115.121.1.27 )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleCustom' SYNTAX 1.3.6.1.4.1.99999.3.1 )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	if err := store.ValidateValue("cn", "中文名字abcd"); nil != err {
+		t.Errorf("expecting valid value: %v", err)
+	}
+	if err := store.ValidateValue("cn", "123456789"); nil == err {
+		t.Error("expecting error for value exceeds length bound")
+	} else if v, ok := err.(*ErrInvalidSyntaxValue); !ok || v.Attribute != "cn" {
+		t.Errorf("unexpected error: %#v", err)
+	}
+	if err := store.ValidateValue("sampleCount", "12x"); nil == err {
+		t.Error("expecting error for invalid integer")
+	}
+	if err := store.ValidateValue("sampleCustom", "anything"); nil != err {
+		t.Errorf("expecting value of syntax without validator accepted: %v", err)
+	}
+	registry := NewBuiltinSyntaxValidatorRegistry()
+	registry.Register("1.3.6.1.4.1.99999.3.1", func(value string) error {
+		if value != "ok" {
+			return errors.New("expecting ok")
+		}
+		return nil
+	})
+	store.SetSyntaxValidatorRegistry(registry)
+	if err := store.ValidateValue("sampleCustom", "anything"); nil == err {
+		t.Error("expecting error from custom validator")
+	}
+	if err := store.ValidateValue("sampleMissing", "x"); nil == err {
+		t.Error("expecting error for unknown attribute type")
+	}
+}
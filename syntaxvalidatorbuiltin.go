@@ -0,0 +1,818 @@
+package ldapschemaparser
+
+import (
+	"encoding/asn1"
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Numeric OIDs of syntaxes defined in RFC-4517, RFC-4523 and RFC-4530.
+const (
+	SyntaxOIDAttributeTypeDescription    = "1.3.6.1.4.1.1466.115.121.1.3"
+	SyntaxOIDBitString                   = "1.3.6.1.4.1.1466.115.121.1.6"
+	SyntaxOIDBoolean                     = "1.3.6.1.4.1.1466.115.121.1.7"
+	SyntaxOIDCertificate                 = "1.3.6.1.4.1.1466.115.121.1.8"
+	SyntaxOIDCertificateList             = "1.3.6.1.4.1.1466.115.121.1.9"
+	SyntaxOIDCertificatePair             = "1.3.6.1.4.1.1466.115.121.1.10"
+	SyntaxOIDCountryString               = "1.3.6.1.4.1.1466.115.121.1.11"
+	SyntaxOIDDN                          = "1.3.6.1.4.1.1466.115.121.1.12"
+	SyntaxOIDDeliveryMethod              = "1.3.6.1.4.1.1466.115.121.1.14"
+	SyntaxOIDDirectoryString             = "1.3.6.1.4.1.1466.115.121.1.15"
+	SyntaxOIDDITContentRuleDescription   = "1.3.6.1.4.1.1466.115.121.1.16"
+	SyntaxOIDDITStructureRuleDescription = "1.3.6.1.4.1.1466.115.121.1.17"
+	SyntaxOIDEnhancedGuide               = "1.3.6.1.4.1.1466.115.121.1.21"
+	SyntaxOIDFacsimileTelephoneNumber    = "1.3.6.1.4.1.1466.115.121.1.22"
+	SyntaxOIDFax                         = "1.3.6.1.4.1.1466.115.121.1.23"
+	SyntaxOIDGeneralizedTime             = "1.3.6.1.4.1.1466.115.121.1.24"
+	SyntaxOIDGuide                       = "1.3.6.1.4.1.1466.115.121.1.25"
+	SyntaxOIDIA5String                   = "1.3.6.1.4.1.1466.115.121.1.26"
+	SyntaxOIDInteger                     = "1.3.6.1.4.1.1466.115.121.1.27"
+	SyntaxOIDJPEG                        = "1.3.6.1.4.1.1466.115.121.1.28"
+	SyntaxOIDMatchingRuleDescription     = "1.3.6.1.4.1.1466.115.121.1.30"
+	SyntaxOIDMatchingRuleUseDescription  = "1.3.6.1.4.1.1466.115.121.1.31"
+	SyntaxOIDNameAndOptionalUID          = "1.3.6.1.4.1.1466.115.121.1.34"
+	SyntaxOIDNameFormDescription         = "1.3.6.1.4.1.1466.115.121.1.35"
+	SyntaxOIDNumericString               = "1.3.6.1.4.1.1466.115.121.1.36"
+	SyntaxOIDObjectClassDescription      = "1.3.6.1.4.1.1466.115.121.1.37"
+	SyntaxOIDOID                         = "1.3.6.1.4.1.1466.115.121.1.38"
+	SyntaxOIDOtherMailbox                = "1.3.6.1.4.1.1466.115.121.1.39"
+	SyntaxOIDOctetString                 = "1.3.6.1.4.1.1466.115.121.1.40"
+	SyntaxOIDPostalAddress               = "1.3.6.1.4.1.1466.115.121.1.41"
+	SyntaxOIDPrintableString             = "1.3.6.1.4.1.1466.115.121.1.44"
+	SyntaxOIDSupportedAlgorithm          = "1.3.6.1.4.1.1466.115.121.1.49"
+	SyntaxOIDTelephoneNumber             = "1.3.6.1.4.1.1466.115.121.1.50"
+	SyntaxOIDTeletexTerminalIdentifier   = "1.3.6.1.4.1.1466.115.121.1.51"
+	SyntaxOIDTelexNumber                 = "1.3.6.1.4.1.1466.115.121.1.52"
+	SyntaxOIDUTCTime                     = "1.3.6.1.4.1.1466.115.121.1.53"
+	SyntaxOIDLDAPSyntaxDescription       = "1.3.6.1.4.1.1466.115.121.1.54"
+	SyntaxOIDSubstringAssertion          = "1.3.6.1.4.1.1466.115.121.1.58"
+	SyntaxOIDUUID                        = "1.3.6.1.1.16.1"
+)
+
+var builtinSyntaxValidators = map[string]SyntaxValidator{
+	SyntaxOIDAttributeTypeDescription:    validateAttributeTypeDescription,
+	SyntaxOIDBitString:                   validateBitString,
+	SyntaxOIDBoolean:                     validateBoolean,
+	SyntaxOIDCertificate:                 validateDERSequence,
+	SyntaxOIDCertificateList:             validateDERSequence,
+	SyntaxOIDCertificatePair:             validateDERSequence,
+	SyntaxOIDCountryString:               validateCountryString,
+	SyntaxOIDDN:                          validateDistinguishedName,
+	SyntaxOIDDeliveryMethod:              validateDeliveryMethod,
+	SyntaxOIDDirectoryString:             validateDirectoryString,
+	SyntaxOIDDITContentRuleDescription:   validateDITContentRuleDescription,
+	SyntaxOIDDITStructureRuleDescription: validateDITStructureRuleDescription,
+	SyntaxOIDEnhancedGuide:               validateEnhancedGuide,
+	SyntaxOIDFacsimileTelephoneNumber:    validateFacsimileTelephoneNumber,
+	SyntaxOIDFax:                         validateOctetString,
+	SyntaxOIDGeneralizedTime:             validateGeneralizedTime,
+	SyntaxOIDGuide:                       validateGuide,
+	SyntaxOIDIA5String:                   validateIA5String,
+	SyntaxOIDInteger:                     validateInteger,
+	SyntaxOIDJPEG:                        validateJPEG,
+	SyntaxOIDMatchingRuleDescription:     validateMatchingRuleDescription,
+	SyntaxOIDMatchingRuleUseDescription:  validateMatchingRuleUseDescription,
+	SyntaxOIDNameAndOptionalUID:          validateNameAndOptionalUID,
+	SyntaxOIDNameFormDescription:         validateNameFormDescription,
+	SyntaxOIDNumericString:               validateNumericString,
+	SyntaxOIDObjectClassDescription:      validateObjectClassDescription,
+	SyntaxOIDOID:                         validateOID,
+	SyntaxOIDOtherMailbox:                validateOtherMailbox,
+	SyntaxOIDOctetString:                 validateOctetString,
+	SyntaxOIDPostalAddress:               validatePostalAddress,
+	SyntaxOIDPrintableString:             validatePrintableString,
+	SyntaxOIDSupportedAlgorithm:          validateDERSequence,
+	SyntaxOIDTelephoneNumber:             validatePrintableString,
+	SyntaxOIDTeletexTerminalIdentifier:   validateTeletexTerminalIdentifier,
+	SyntaxOIDTelexNumber:                 validateTelexNumber,
+	SyntaxOIDUTCTime:                     validateUTCTime,
+	SyntaxOIDLDAPSyntaxDescription:       validateLDAPSyntaxDescription,
+	SyntaxOIDSubstringAssertion:          validateSubstringAssertion,
+	SyntaxOIDUUID:                        validateUUID,
+
+	SyntaxOIDCertificateExactAssertion:     makeGSERSequenceValidator(certificateExactAssertionRules),
+	SyntaxOIDCertificateAssertion:          makeGSERSequenceValidator(certificateAssertionRules),
+	SyntaxOIDCertificatePairExactAssertion: makeGSERSequenceValidator(certificatePairExactAssertionRules),
+	SyntaxOIDCertificatePairAssertion:      makeGSERSequenceValidator(certificatePairAssertionRules),
+	SyntaxOIDCertificateListExactAssertion: makeGSERSequenceValidator(certificateListExactAssertionRules),
+	SyntaxOIDCertificateListAssertion:      makeGSERSequenceValidator(certificateListAssertionRules),
+	SyntaxOIDAlgorithmIdentifier:           makeGSERSequenceValidator(algorithmIdentifierRules),
+}
+
+var errEmptyValue = errors.New("empty value")
+
+func isDigitByte(ch byte) bool {
+	return (ch >= '0') && (ch <= '9')
+}
+
+func isAlphaByte(ch byte) bool {
+	return ((ch >= 'a') && (ch <= 'z')) || ((ch >= 'A') && (ch <= 'Z'))
+}
+
+func isHexByte(ch byte) bool {
+	return isDigitByte(ch) || ((ch >= 'a') && (ch <= 'f')) || ((ch >= 'A') && (ch <= 'F'))
+}
+
+// isPrintableByte check PrintableCharacter of RFC-4517 3.2.
+func isPrintableByte(ch byte) bool {
+	if isAlphaByte(ch) || isDigitByte(ch) {
+		return true
+	}
+	return strings.IndexByte("'()+,-.=/:? ", ch) >= 0
+}
+
+func validateOctetString(value string) error {
+	return nil
+}
+
+func validateDirectoryString(value string) error {
+	if "" == value {
+		return errEmptyValue
+	}
+	if !utf8.ValidString(value) {
+		return errors.New("invalid UTF-8 sequence")
+	}
+	return nil
+}
+
+func validateIA5String(value string) error {
+	for idx := 0; idx < len(value); idx++ {
+		if value[idx] >= 0x80 {
+			return errors.New("non IA5 character at offset " + strconv.Itoa(idx))
+		}
+	}
+	return nil
+}
+
+func validatePrintableString(value string) error {
+	if "" == value {
+		return errEmptyValue
+	}
+	for idx := 0; idx < len(value); idx++ {
+		if !isPrintableByte(value[idx]) {
+			return errors.New("non printable character at offset " + strconv.Itoa(idx))
+		}
+	}
+	return nil
+}
+
+func validateNumericString(value string) error {
+	if "" == value {
+		return errEmptyValue
+	}
+	for idx := 0; idx < len(value); idx++ {
+		if !isDigitByte(value[idx]) && (value[idx] != ' ') {
+			return errors.New("non numeric character at offset " + strconv.Itoa(idx))
+		}
+	}
+	return nil
+}
+
+func validateCountryString(value string) error {
+	if len(value) != 2 {
+		return errors.New("expecting 2 characters")
+	}
+	return validatePrintableString(value)
+}
+
+func validateBoolean(value string) error {
+	if (value != "TRUE") && (value != "FALSE") {
+		return errors.New("expecting TRUE or FALSE")
+	}
+	return nil
+}
+
+func validateInteger(value string) error {
+	digits := value
+	if strings.HasPrefix(digits, "-") {
+		digits = digits[1:]
+		if "0" == digits {
+			return errors.New("negative zero")
+		}
+	}
+	if "" == digits {
+		return errors.New("missing digits")
+	}
+	if (len(digits) > 1) && (digits[0] == '0') {
+		return errors.New("leading zero")
+	}
+	for idx := 0; idx < len(digits); idx++ {
+		if !isDigitByte(digits[idx]) {
+			return errors.New("non digit character")
+		}
+	}
+	return nil
+}
+
+func validateBitString(value string) error {
+	if (len(value) < 3) || (value[0] != '\'') || !strings.HasSuffix(value, "'B") {
+		return errors.New("expecting '...'B form")
+	}
+	for idx := 1; idx < len(value)-2; idx++ {
+		if (value[idx] != '0') && (value[idx] != '1') {
+			return errors.New("non binary digit")
+		}
+	}
+	return nil
+}
+
+func isNumericOIDValue(value string) bool {
+	if "" == value {
+		return false
+	}
+	parts := strings.Split(value, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts {
+		if "" == part {
+			return false
+		}
+		if (len(part) > 1) && (part[0] == '0') {
+			return false
+		}
+		for idx := 0; idx < len(part); idx++ {
+			if !isDigitByte(part[idx]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isKeyStringValue(value string) bool {
+	if ("" == value) || !isAlphaByte(value[0]) {
+		return false
+	}
+	for idx := 1; idx < len(value); idx++ {
+		ch := value[idx]
+		if !isAlphaByte(ch) && !isDigitByte(ch) && (ch != '-') {
+			return false
+		}
+	}
+	return true
+}
+
+func validateOID(value string) error {
+	if isNumericOIDValue(value) || isKeyStringValue(value) {
+		return nil
+	}
+	return errors.New("expecting numeric OID or descriptor")
+}
+
+func validateUUID(value string) error {
+	if len(value) != 36 {
+		return errors.New("expecting 36 characters")
+	}
+	for idx := 0; idx < len(value); idx++ {
+		switch idx {
+		case 8, 13, 18, 23:
+			if value[idx] != '-' {
+				return errors.New("expecting hyphen at offset " + strconv.Itoa(idx))
+			}
+		default:
+			if !isHexByte(value[idx]) {
+				return errors.New("non hex digit at offset " + strconv.Itoa(idx))
+			}
+		}
+	}
+	return nil
+}
+
+func validateJPEG(value string) error {
+	if !strings.HasPrefix(value, "\xFF\xD8") {
+		return errors.New("missing JPEG start of image marker")
+	}
+	return nil
+}
+
+// validateDERSequence checks value is a single DER encoded SEQUENCE as
+// certificate, certificate list, certificate pair and supported algorithm
+// of RFC-4523 are transferred in binary.
+func validateDERSequence(value string) error {
+	var raw asn1.RawValue
+	rest, err := asn1.Unmarshal([]byte(value), &raw)
+	if nil != err {
+		return err
+	}
+	if len(rest) > 0 {
+		return errors.New("trailing data after DER value")
+	}
+	if (raw.Class != asn1.ClassUniversal) || (raw.Tag != asn1.TagSequence) || !raw.IsCompound {
+		return errors.New("expecting DER encoded SEQUENCE")
+	}
+	return nil
+}
+
+func parseFixedDigits(value string, offset, width, minValue, maxValue int) (next int, err error) {
+	if offset+width > len(value) {
+		return offset, errors.New("value too short")
+	}
+	n := 0
+	for idx := offset; idx < offset+width; idx++ {
+		if !isDigitByte(value[idx]) {
+			return offset, errors.New("non digit character at offset " + strconv.Itoa(idx))
+		}
+		n = n*10 + int(value[idx]-'0')
+	}
+	if (n < minValue) || (n > maxValue) {
+		return offset, errors.New("value out of range at offset " + strconv.Itoa(offset))
+	}
+	return offset + width, nil
+}
+
+// parseTimeDifferential parses `( "+" / "-" ) hour [ minute ]` and
+// requires minute when minuteRequired is set.
+func parseTimeDifferential(value string, offset int, minuteRequired bool) (err error) {
+	if (value[offset] != '+') && (value[offset] != '-') {
+		return errors.New("invalid time zone")
+	}
+	if offset, err = parseFixedDigits(value, offset+1, 2, 0, 23); nil != err {
+		return
+	}
+	if (offset == len(value)) && !minuteRequired {
+		return nil
+	}
+	if offset, err = parseFixedDigits(value, offset, 2, 0, 59); nil != err {
+		return
+	}
+	if offset != len(value) {
+		return errors.New("trailing characters after time zone")
+	}
+	return nil
+}
+
+func validateGeneralizedTime(value string) (err error) {
+	offset := 0
+	if offset, err = parseFixedDigits(value, offset, 4, 0, 9999); nil != err {
+		return
+	}
+	if offset, err = parseFixedDigits(value, offset, 2, 1, 12); nil != err {
+		return
+	}
+	if offset, err = parseFixedDigits(value, offset, 2, 1, 31); nil != err {
+		return
+	}
+	if offset, err = parseFixedDigits(value, offset, 2, 0, 23); nil != err {
+		return
+	}
+	if (offset < len(value)) && isDigitByte(value[offset]) {
+		if offset, err = parseFixedDigits(value, offset, 2, 0, 59); nil != err {
+			return
+		}
+		if (offset < len(value)) && isDigitByte(value[offset]) {
+			if offset, err = parseFixedDigits(value, offset, 2, 0, 60); nil != err {
+				return
+			}
+		}
+	}
+	if (offset < len(value)) && ((value[offset] == '.') || (value[offset] == ',')) {
+		offset++
+		start := offset
+		for (offset < len(value)) && isDigitByte(value[offset]) {
+			offset++
+		}
+		if start == offset {
+			return errors.New("empty fraction")
+		}
+	}
+	if offset >= len(value) {
+		return errors.New("missing time zone")
+	}
+	if value[offset] == 'Z' {
+		if offset+1 != len(value) {
+			return errors.New("trailing characters after time zone")
+		}
+		return nil
+	}
+	return parseTimeDifferential(value, offset, false)
+}
+
+func validateUTCTime(value string) (err error) {
+	offset := 0
+	if offset, err = parseFixedDigits(value, offset, 2, 0, 99); nil != err {
+		return
+	}
+	if offset, err = parseFixedDigits(value, offset, 2, 1, 12); nil != err {
+		return
+	}
+	if offset, err = parseFixedDigits(value, offset, 2, 1, 31); nil != err {
+		return
+	}
+	if offset, err = parseFixedDigits(value, offset, 2, 0, 23); nil != err {
+		return
+	}
+	if offset, err = parseFixedDigits(value, offset, 2, 0, 59); nil != err {
+		return
+	}
+	if (offset < len(value)) && isDigitByte(value[offset]) {
+		if offset, err = parseFixedDigits(value, offset, 2, 0, 59); nil != err {
+			return
+		}
+	}
+	if offset == len(value) {
+		return nil
+	}
+	if value[offset] == 'Z' {
+		if offset+1 != len(value) {
+			return errors.New("trailing characters after time zone")
+		}
+		return nil
+	}
+	return parseTimeDifferential(value, offset, true)
+}
+
+func splitDollarSeparated(value string) []string {
+	parts := strings.Split(value, "$")
+	for idx, part := range parts {
+		parts[idx] = strings.Trim(part, " ")
+	}
+	return parts
+}
+
+var deliveryMethods = map[string]bool{
+	"any":       true,
+	"mhs":       true,
+	"physical":  true,
+	"telex":     true,
+	"teletex":   true,
+	"g3fax":     true,
+	"g4fax":     true,
+	"ia5":       true,
+	"videotex":  true,
+	"telephone": true,
+}
+
+func validateDeliveryMethod(value string) error {
+	for _, pdm := range splitDollarSeparated(value) {
+		if !deliveryMethods[pdm] {
+			return errors.New("unknown delivery method: " + pdm)
+		}
+	}
+	return nil
+}
+
+var faxParameters = map[string]bool{
+	"twoDimensional":  true,
+	"fineResolution":  true,
+	"unlimitedLength": true,
+	"b4Length":        true,
+	"a3Width":         true,
+	"b4Width":         true,
+	"uncompressed":    true,
+}
+
+func validateFacsimileTelephoneNumber(value string) error {
+	parts := strings.Split(value, "$")
+	if err := validatePrintableString(parts[0]); nil != err {
+		return err
+	}
+	for _, faxParameter := range parts[1:] {
+		if !faxParameters[faxParameter] {
+			return errors.New("unknown fax parameter: " + faxParameter)
+		}
+	}
+	return nil
+}
+
+func validateTelexNumber(value string) error {
+	parts := strings.Split(value, "$")
+	if len(parts) != 3 {
+		return errors.New("expecting actual-number $ country-code $ answerback")
+	}
+	for _, part := range parts {
+		if err := validatePrintableString(part); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateOtherMailbox(value string) error {
+	idx := strings.Index(value, "$")
+	if idx < 0 {
+		return errors.New("expecting mailbox-type $ mailbox")
+	}
+	if err := validatePrintableString(value[:idx]); nil != err {
+		return err
+	}
+	return validateIA5String(value[idx+1:])
+}
+
+func validatePostalAddress(value string) error {
+	if !utf8.ValidString(value) {
+		return errors.New("invalid UTF-8 sequence")
+	}
+	for _, line := range strings.Split(value, "$") {
+		if "" == line {
+			return errors.New("empty line")
+		}
+		if err := checkEscapedOctets(line, "24", "5C"); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEscapedOctets checks each backslash in value is followed by one of
+// given hex pairs.
+func checkEscapedOctets(value string, escapes ...string) error {
+	for idx := 0; idx < len(value); idx++ {
+		if value[idx] != '\\' {
+			continue
+		}
+		if idx+2 >= len(value) {
+			return errors.New("incomplete escape")
+		}
+		escaped := strings.ToUpper(value[idx+1 : idx+3])
+		valid := false
+		for _, escape := range escapes {
+			if escaped == escape {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return errors.New("invalid escape: \\" + value[idx+1:idx+3])
+		}
+		idx += 2
+	}
+	return nil
+}
+
+var teletexParameterKeys = map[string]bool{
+	"graphic": true,
+	"control": true,
+	"misc":    true,
+	"page":    true,
+	"private": true,
+}
+
+// validateTeletexTerminalIdentifier checks value of RFC-4517 3.3.32.
+func validateTeletexTerminalIdentifier(value string) error {
+	parts := strings.Split(value, "$")
+	if err := validatePrintableString(parts[0]); nil != err {
+		return err
+	}
+	for _, parameter := range parts[1:] {
+		idx := strings.IndexByte(parameter, ':')
+		if idx < 0 {
+			return errors.New("expecting ttx-key:ttx-value but have: " + parameter)
+		}
+		if !teletexParameterKeys[strings.ToLower(parameter[:idx])] {
+			return errors.New("unknown teletex parameter: " + parameter[:idx])
+		}
+		if err := checkEscapedOctets(parameter[idx+1:], "24", "5C"); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSubstringAssertion checks value of RFC-4517 3.3.30. Asterisks
+// separate initial, any and final substrings.
+func validateSubstringAssertion(value string) error {
+	parts := strings.Split(value, "*")
+	if len(parts) < 2 {
+		return errors.New("expecting at least one asterisk")
+	}
+	for idx, part := range parts {
+		if ("" == part) && (idx > 0) && (idx < len(parts)-1) {
+			return errors.New("empty substring between asterisks")
+		}
+		if err := checkEscapedOctets(part, "2A", "5C"); nil != err {
+			return err
+		}
+	}
+	return nil
+}
+
+// guideParser parses criteria of Guide and Enhanced Guide in RFC-4517
+// 3.3.10 and 3.3.14.
+type guideParser struct {
+	text string
+	pos  int
+}
+
+var guideMatchTypes = map[string]bool{
+	"EQ":     true,
+	"SUBSTR": true,
+	"GE":     true,
+	"LE":     true,
+	"APPROX": true,
+}
+
+func (p *guideParser) errorf(message string) error {
+	return errors.New(message + " at offset " + strconv.Itoa(p.pos))
+}
+
+func (p *guideParser) consume(ch byte) bool {
+	if (p.pos < len(p.text)) && (p.text[p.pos] == ch) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *guideParser) parseCriteria() (err error) {
+	if err = p.parseAndTerm(); nil != err {
+		return
+	}
+	for p.consume('|') {
+		if err = p.parseAndTerm(); nil != err {
+			return
+		}
+	}
+	return nil
+}
+
+func (p *guideParser) parseAndTerm() (err error) {
+	if err = p.parseTerm(); nil != err {
+		return
+	}
+	for p.consume('&') {
+		if err = p.parseTerm(); nil != err {
+			return
+		}
+	}
+	return nil
+}
+
+func (p *guideParser) parseTerm() (err error) {
+	switch {
+	case p.consume('!'):
+		return p.parseTerm()
+	case p.consume('('):
+		if err = p.parseCriteria(); nil != err {
+			return
+		}
+		if !p.consume(')') {
+			return p.errorf("expecting )")
+		}
+		return nil
+	}
+	rest := strings.ToLower(p.text[p.pos:])
+	for _, flag := range []string{"?true", "?false"} {
+		if strings.HasPrefix(rest, flag) {
+			p.pos += len(flag)
+			return nil
+		}
+	}
+	start := p.pos
+	for (p.pos < len(p.text)) && (isAlphaByte(p.text[p.pos]) || isDigitByte(p.text[p.pos]) || (p.text[p.pos] == '.') || (p.text[p.pos] == '-')) {
+		p.pos++
+	}
+	if err = validateOID(p.text[start:p.pos]); nil != err {
+		return p.errorf(err.Error())
+	}
+	if !p.consume('$') {
+		return p.errorf("expecting $")
+	}
+	start = p.pos
+	for (p.pos < len(p.text)) && isAlphaByte(p.text[p.pos]) {
+		p.pos++
+	}
+	if !guideMatchTypes[strings.ToUpper(p.text[start:p.pos])] {
+		return p.errorf("unknown match type")
+	}
+	return nil
+}
+
+func validateGuideCriteria(criteria string) error {
+	p := &guideParser{
+		text: criteria,
+	}
+	if err := p.parseCriteria(); nil != err {
+		return err
+	}
+	if p.pos != len(p.text) {
+		return p.errorf("trailing text")
+	}
+	return nil
+}
+
+// validateGuide checks value of RFC-4517 3.3.14 as [ object-class # ]
+// criteria.
+func validateGuide(value string) error {
+	parts := strings.Split(value, "#")
+	switch len(parts) {
+	case 1:
+		return validateGuideCriteria(value)
+	case 2:
+		if err := validateOID(strings.Trim(parts[0], " ")); nil != err {
+			return err
+		}
+		return validateGuideCriteria(parts[1])
+	}
+	return errors.New("expecting [ object-class # ] criteria")
+}
+
+var enhancedGuideSubsets = map[string]bool{
+	"baseobject":   true,
+	"onelevel":     true,
+	"wholesubtree": true,
+}
+
+// validateEnhancedGuide checks value of RFC-4517 3.3.10 as
+// object-class # criteria # subset.
+func validateEnhancedGuide(value string) error {
+	parts := strings.Split(value, "#")
+	if len(parts) != 3 {
+		return errors.New("expecting object-class # criteria # subset")
+	}
+	if err := validateOID(strings.Trim(parts[0], " ")); nil != err {
+		return err
+	}
+	if err := validateGuideCriteria(strings.Trim(parts[1], " ")); nil != err {
+		return err
+	}
+	if !enhancedGuideSubsets[strings.ToLower(strings.TrimLeft(parts[2], " "))] {
+		return errors.New("unknown subset: " + parts[2])
+	}
+	return nil
+}
+
+// validateDistinguishedName checks value is a string representation of
+// distinguished name in RFC-4514.
+func validateDistinguishedName(value string) error {
+	_, err := ParseDN(value)
+	if parseErr, ok := err.(*ParseError); ok {
+		return errors.New(parseErr.Message + " at offset " + strconv.Itoa(parseErr.Offset))
+	}
+	return err
+}
+
+func validateNameAndOptionalUID(value string) error {
+	if idx := strings.LastIndex(value, "#'"); (idx >= 0) && strings.HasSuffix(value, "'B") {
+		if nil == validateBitString(value[idx+1:]) {
+			value = value[:idx]
+		}
+	}
+	return validateDistinguishedName(value)
+}
+
+func validateSchemaDescription(value string, makeTypedSchema func(generic *GenericSchema) error) error {
+	genericSchema, err := Parse(value)
+	if nil != err {
+		return err
+	}
+	return makeTypedSchema(genericSchema)
+}
+
+func validateAttributeTypeDescription(value string) error {
+	return validateSchemaDescription(value, func(generic *GenericSchema) (err error) {
+		_, err = NewAttributeTypeSchemaViaGenericSchema(generic)
+		return
+	})
+}
+
+func validateObjectClassDescription(value string) error {
+	return validateSchemaDescription(value, func(generic *GenericSchema) (err error) {
+		_, err = NewObjectClassSchemaViaGenericSchema(generic)
+		return
+	})
+}
+
+func validateMatchingRuleDescription(value string) error {
+	return validateSchemaDescription(value, func(generic *GenericSchema) (err error) {
+		_, err = NewMatchingRuleSchemaViaGenericSchema(generic)
+		return
+	})
+}
+
+func validateMatchingRuleUseDescription(value string) error {
+	return validateSchemaDescription(value, func(generic *GenericSchema) (err error) {
+		_, err = NewMatchingRuleUseSchemaViaGenericSchema(generic)
+		return
+	})
+}
+
+func validateLDAPSyntaxDescription(value string) error {
+	return validateSchemaDescription(value, func(generic *GenericSchema) (err error) {
+		_, err = NewLDAPSyntaxSchemaViaGenericSchema(generic)
+		return
+	})
+}
+
+func validateDITContentRuleDescription(value string) error {
+	return validateSchemaDescription(value, func(generic *GenericSchema) (err error) {
+		_, err = NewDITContentRuleSchemaViaGenericSchema(generic)
+		return
+	})
+}
+
+func validateDITStructureRuleDescription(value string) error {
+	return validateSchemaDescription(value, func(generic *GenericSchema) (err error) {
+		_, err = NewDITStructureRuleSchemaViaGenericSchema(generic)
+		return
+	})
+}
+
+func validateNameFormDescription(value string) error {
+	return validateSchemaDescription(value, func(generic *GenericSchema) (err error) {
+		_, err = NewNameFormSchemaViaGenericSchema(generic)
+		return
+	})
+}
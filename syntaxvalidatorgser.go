@@ -0,0 +1,179 @@
+package ldapschemaparser
+
+import (
+	"errors"
+)
+
+// Numeric OIDs of assertion syntaxes defined in RFC-4523. Values of these
+// syntaxes are encoded with GSER.
+const (
+	SyntaxOIDCertificateExactAssertion     = "1.3.6.1.1.15.1"
+	SyntaxOIDCertificateAssertion          = "1.3.6.1.1.15.2"
+	SyntaxOIDCertificatePairExactAssertion = "1.3.6.1.1.15.3"
+	SyntaxOIDCertificatePairAssertion      = "1.3.6.1.1.15.4"
+	SyntaxOIDCertificateListExactAssertion = "1.3.6.1.1.15.5"
+	SyntaxOIDCertificateListAssertion      = "1.3.6.1.1.15.6"
+	SyntaxOIDAlgorithmIdentifier           = "1.3.6.1.1.15.7"
+)
+
+// gserComponentRule describes a component of GSER SEQUENCE value. Value of
+// component is checked with check when given.
+type gserComponentRule struct {
+	identifier string
+	required   bool
+	check      func(value *gserValue) error
+}
+
+// checkGSERSequence checks components of value follow the order of rules
+// and all required components exist.
+func checkGSERSequence(value *gserValue, rules []*gserComponentRule) error {
+	if gserSequence != value.kind {
+		return errors.New("expecting SEQUENCE value")
+	}
+	ruleIdx := 0
+	for _, component := range value.components {
+		for (ruleIdx < len(rules)) && (rules[ruleIdx].identifier != component.identifier) {
+			if rules[ruleIdx].required {
+				return errors.New("missing component " + rules[ruleIdx].identifier)
+			}
+			ruleIdx++
+		}
+		if ruleIdx == len(rules) {
+			return errors.New("unexpected component " + component.identifier)
+		}
+		if check := rules[ruleIdx].check; nil != check {
+			if err := check(component.value); nil != err {
+				return errors.New("invalid component " + component.identifier + ": " + err.Error())
+			}
+		}
+		ruleIdx++
+	}
+	for ; ruleIdx < len(rules); ruleIdx++ {
+		if rules[ruleIdx].required {
+			return errors.New("missing component " + rules[ruleIdx].identifier)
+		}
+	}
+	return nil
+}
+
+func checkGSERInteger(value *gserValue) error {
+	if gserNumber != value.kind {
+		return errors.New("expecting INTEGER value")
+	}
+	return validateInteger(value.text)
+}
+
+func checkGSERObjectIdentifier(value *gserValue) error {
+	if (gserNumericOID == value.kind) || ((gserWord == value.kind) && isKeyStringValue(value.text)) {
+		return nil
+	}
+	return errors.New("expecting OBJECT IDENTIFIER value")
+}
+
+// checkGSERChoiceString checks value is CHOICE of one of given identifiers
+// with string value accepted by validate.
+func checkGSERChoiceString(value *gserValue, validates map[string]SyntaxValidator) error {
+	if gserChoice != value.kind {
+		return errors.New("expecting CHOICE value")
+	}
+	validate := validates[value.text]
+	if nil == validate {
+		return errors.New("unexpected alternative " + value.text)
+	}
+	if gserString != value.chosen.kind {
+		return errors.New("expecting string value of " + value.text)
+	}
+	return validate(value.chosen.text)
+}
+
+func checkGSERName(value *gserValue) error {
+	return checkGSERChoiceString(value, map[string]SyntaxValidator{
+		"rdnSequence": validateDistinguishedName,
+	})
+}
+
+func checkGSERTime(value *gserValue) error {
+	return checkGSERChoiceString(value, map[string]SyntaxValidator{
+		"utcTime":         validateUTCTime,
+		"generalizedTime": validateGeneralizedTime,
+	})
+}
+
+func checkGSERGeneralizedTime(value *gserValue) error {
+	if gserString != value.kind {
+		return errors.New("expecting GeneralizedTime value")
+	}
+	return validateGeneralizedTime(value.text)
+}
+
+var certificateExactAssertionRules = []*gserComponentRule{
+	{"serialNumber", true, checkGSERInteger},
+	{"issuer", true, checkGSERName},
+}
+
+var certificateAssertionRules = []*gserComponentRule{
+	{"serialNumber", false, checkGSERInteger},
+	{"issuer", false, checkGSERName},
+	{"subjectKeyIdentifier", false, nil},
+	{"authorityKeyIdentifier", false, nil},
+	{"certificateValid", false, checkGSERTime},
+	{"privateKeyValid", false, checkGSERGeneralizedTime},
+	{"subjectPublicKeyAlgID", false, checkGSERObjectIdentifier},
+	{"keyUsage", false, nil},
+	{"subjectAltName", false, nil},
+	{"policy", false, nil},
+	{"pathToName", false, checkGSERName},
+	{"subject", false, checkGSERName},
+	{"nameConstraints", false, nil},
+}
+
+func checkGSERCertificateExactAssertion(value *gserValue) error {
+	return checkGSERSequence(value, certificateExactAssertionRules)
+}
+
+func checkGSERCertificateAssertion(value *gserValue) error {
+	return checkGSERSequence(value, certificateAssertionRules)
+}
+
+var certificatePairExactAssertionRules = []*gserComponentRule{
+	{"issuedToThisCAAssertion", false, checkGSERCertificateExactAssertion},
+	{"issuedByThisCAAssertion", false, checkGSERCertificateExactAssertion},
+}
+
+var certificatePairAssertionRules = []*gserComponentRule{
+	{"issuedToThisCAAssertion", false, checkGSERCertificateAssertion},
+	{"issuedByThisCAAssertion", false, checkGSERCertificateAssertion},
+}
+
+var certificateListExactAssertionRules = []*gserComponentRule{
+	{"issuer", true, checkGSERName},
+	{"thisUpdate", true, checkGSERTime},
+	{"distributionPoint", false, nil},
+}
+
+var certificateListAssertionRules = []*gserComponentRule{
+	{"issuer", false, checkGSERName},
+	{"minCRLNumber", false, checkGSERInteger},
+	{"maxCRLNumber", false, checkGSERInteger},
+	{"reasonFlags", false, nil},
+	{"dateAndTime", false, checkGSERTime},
+	{"distributionPoint", false, nil},
+	{"authorityKeyIdentifier", false, nil},
+}
+
+var algorithmIdentifierRules = []*gserComponentRule{
+	{"algorithm", true, checkGSERObjectIdentifier},
+	{"parameters", false, nil},
+}
+
+// makeGSERSequenceValidator makes validator of GSER encoded SEQUENCE value
+// with given component rules.
+func makeGSERSequenceValidator(rules []*gserComponentRule) SyntaxValidator {
+	return func(value string) error {
+		parsed, err := parseGSERValue(value)
+		if nil != err {
+			return err
+		}
+		return checkGSERSequence(parsed, rules)
+	}
+}
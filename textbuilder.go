@@ -79,6 +79,22 @@ func (b *SchemaTextBuilder) AppendOIDSlice(keyword string, values []string) {
 	b.fragments = append(b.fragments, ")")
 }
 
+// AppendRuleIDSlice append rule IDs into result
+// Rule IDs are seperated by spaces. (RFC-4512 4.1.7.1)
+func (b *SchemaTextBuilder) AppendRuleIDSlice(keyword string, values []string) {
+	l := len(values)
+	if 0 == l {
+		return
+	} else if 1 == l {
+		b.AppendBareString(keyword, values[0])
+		return
+	}
+	b.fragments = append(b.fragments, keyword)
+	b.fragments = append(b.fragments, "(")
+	b.fragments = append(b.fragments, values...)
+	b.fragments = append(b.fragments, ")")
+}
+
 // AppendExtensions appends extensions to result
 func (b *SchemaTextBuilder) AppendExtensions(extensions map[string][]string) {
 	if nil == extensions {
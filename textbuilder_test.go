@@ -36,3 +36,14 @@ func TestSchemaTextBuilder_1(t *testing.T) {
 		t.Errorf("unexpect result: %v", result)
 	}
 }
+
+func TestSchemaTextBuilder_2(t *testing.T) {
+	b := SchemaTextBuilder{}
+	b.AppendFragment("3")
+	b.AppendRuleIDSlice("SUPA", []string{"1", "2"})
+	b.AppendRuleIDSlice("SUPB", []string{"1"})
+	b.AppendRuleIDSlice("SUPC", nil)
+	if result := b.String(); result != "( 3 SUPA ( 1 2 ) SUPB 1 )" {
+		t.Errorf("unexpect result: %v", result)
+	}
+}
@@ -0,0 +1,302 @@
+package ldapschemaparser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReferenceProblemType indicates the type of reference problem
+type ReferenceProblemType string
+
+// DanglingReference and CyclicSuperior are types of reference problems.
+const (
+	DanglingReference ReferenceProblemType = "dangling-reference"
+	CyclicSuperior    ReferenceProblemType = "cyclic-superior"
+)
+
+// ReferenceProblem represents a reference problem found in store.
+//
+// For dangling reference, Field is the keyword holding the reference and
+// Target is the missing target. For cyclic superior, Cycle contains the
+// identifiers forming the cycle.
+type ReferenceProblem struct {
+	Type       ReferenceProblemType `json:"type"`
+	Kind       SchemaKind           `json:"kind"`
+	Source     string               `json:"source"`
+	SourceName string               `json:"source_name,omitempty"`
+	Field      string               `json:"field,omitempty"`
+	TargetKind SchemaKind           `json:"target_kind,omitempty"`
+	Target     string               `json:"target,omitempty"`
+	Cycle      []string             `json:"cycle,omitempty"`
+}
+
+func (p *ReferenceProblem) String() string {
+	source := p.Source
+	if "" != p.SourceName {
+		source = p.Source + " (" + p.SourceName + ")"
+	}
+	if p.Type == CyclicSuperior {
+		return fmt.Sprintf("%s %s: cyclic SUP chain: %s", p.Kind, source, strings.Join(p.Cycle, " -> "))
+	}
+	return fmt.Sprintf("%s %s: %s references missing %s %s", p.Kind, source, p.Field, p.TargetKind, p.Target)
+}
+
+// ErrInvalidReferences contains all reference problems found in store.
+type ErrInvalidReferences struct {
+	Problems []*ReferenceProblem
+}
+
+func (invalidReferences *ErrInvalidReferences) Error() string {
+	texts := make([]string, 0, len(invalidReferences.Problems))
+	for _, p := range invalidReferences.Problems {
+		texts = append(texts, p.String())
+	}
+	return fmt.Sprintf("%d invalid schema references: %s", len(invalidReferences.Problems), strings.Join(texts, "; "))
+}
+
+type referenceValidator struct {
+	store    *LDAPSchemaStore
+	problems []*ReferenceProblem
+}
+
+func (v *referenceValidator) check(kind SchemaKind, source string, sourceNames []string, field string, targetKind SchemaKind, targets ...string) {
+	for _, target := range targets {
+		if "" == target {
+			continue
+		}
+		var found bool
+		switch targetKind {
+		case SchemaKindLDAPSyntax:
+			found = nil != v.store.ldapSyntaxSchemaIndex[target]
+		case SchemaKindMatchingRule:
+			found = nil != v.store.findMatchingRuleGenericSchema(target)
+		case SchemaKindAttributeType:
+			found = nil != v.store.findAttributeTypeGenericSchema(target)
+		case SchemaKindObjectClass:
+			found = nil != v.store.findObjectClassGenericSchema(target)
+		case SchemaKindNameForm:
+			found = nil != v.store.findNameFormGenericSchema(target)
+		case SchemaKindDITStructureRule:
+			found = nil != v.store.ditStructureRuleSchemaIndex[target]
+		}
+		if found {
+			continue
+		}
+		sourceName := ""
+		if len(sourceNames) > 0 {
+			sourceName = sourceNames[0]
+		}
+		v.problems = append(v.problems, &ReferenceProblem{
+			Type:       DanglingReference,
+			Kind:       kind,
+			Source:     source,
+			SourceName: sourceName,
+			Field:      field,
+			TargetKind: targetKind,
+			Target:     target,
+		})
+	}
+}
+
+// checkCycles reports cycles of superiors. Cycle is skipped when
+// acceptable is given and returns true for it.
+func (v *referenceValidator) checkCycles(kind SchemaKind, index map[string]*GenericSchema, superiors func(generic *GenericSchema) []string, acceptable func(cycle []string) bool) {
+	keys := sortedMapKey(index)
+	cycles := findDependencyCycles(keys, func(k string) []string {
+		return superiors(index[k])
+	})
+	for _, cycle := range cycles {
+		if (nil != acceptable) && acceptable(cycle) {
+			continue
+		}
+		v.problems = append(v.problems, &ReferenceProblem{
+			Type:       CyclicSuperior,
+			Kind:       kind,
+			Source:     cycle[0],
+			SourceName: index[cycle[0]].getValueOfParameterizedKeyword("NAME"),
+			Cycle:      cycle,
+		})
+	}
+}
+
+func (v *referenceValidator) validateMatchingRules() (err error) {
+	for _, oid := range sortedMapKey(v.store.matchingRuleSchemaIndex) {
+		s, err := NewMatchingRuleSchemaViaGenericSchema(v.store.matchingRuleSchemaIndex[oid])
+		if nil != err {
+			return err
+		}
+		v.check(SchemaKindMatchingRule, s.NumericOID, s.Name, "SYNTAX", SchemaKindLDAPSyntax, s.Syntax)
+	}
+	return nil
+}
+
+func (v *referenceValidator) validateMatchingRuleUses() (err error) {
+	for _, oid := range sortedMapKey(v.store.matchingRuleUseSchemaIndex) {
+		s, err := NewMatchingRuleUseSchemaViaGenericSchema(v.store.matchingRuleUseSchemaIndex[oid])
+		if nil != err {
+			return err
+		}
+		v.check(SchemaKindMatchingRuleUse, s.NumericOID, s.Name, "NUMERICOID", SchemaKindMatchingRule, s.NumericOID)
+		v.check(SchemaKindMatchingRuleUse, s.NumericOID, s.Name, "APPLIES", SchemaKindAttributeType, s.AppliesTo...)
+	}
+	return nil
+}
+
+func (v *referenceValidator) validateAttributeTypes() (err error) {
+	for _, oid := range sortedMapKey(v.store.attributeTypeSchemaIndex) {
+		s, err := NewAttributeTypeSchemaViaGenericSchema(v.store.attributeTypeSchemaIndex[oid])
+		if nil != err {
+			return err
+		}
+		v.check(SchemaKindAttributeType, s.NumericOID, s.Name, "SUP", SchemaKindAttributeType, s.SuperType)
+		v.check(SchemaKindAttributeType, s.NumericOID, s.Name, "EQUALITY", SchemaKindMatchingRule, s.Equality)
+		v.check(SchemaKindAttributeType, s.NumericOID, s.Name, "ORDERING", SchemaKindMatchingRule, s.Ordering)
+		v.check(SchemaKindAttributeType, s.NumericOID, s.Name, "SUBSTR", SchemaKindMatchingRule, s.SubString)
+		v.check(SchemaKindAttributeType, s.NumericOID, s.Name, "SYNTAX", SchemaKindLDAPSyntax, s.SyntaxOID)
+	}
+	v.checkCycles(SchemaKindAttributeType, v.store.attributeTypeSchemaIndex, func(generic *GenericSchema) []string {
+		if supGenericSchema := v.store.findAttributeTypeGenericSchema(generic.getValueOfParameterizedKeyword("SUP")); nil != supGenericSchema {
+			return []string{supGenericSchema.NumericOID}
+		}
+		return nil
+	}, nil)
+	return nil
+}
+
+func (v *referenceValidator) validateObjectClasses() (err error) {
+	for _, oid := range sortedMapKey(v.store.objectClassSchemaIndex) {
+		s, err := NewObjectClassSchemaViaGenericSchema(v.store.objectClassSchemaIndex[oid])
+		if nil != err {
+			return err
+		}
+		v.check(SchemaKindObjectClass, s.NumericOID, s.Name, "SUP", SchemaKindObjectClass, s.SuperClasses...)
+		v.check(SchemaKindObjectClass, s.NumericOID, s.Name, "MUST", SchemaKindAttributeType, s.Must...)
+		v.check(SchemaKindObjectClass, s.NumericOID, s.Name, "MAY", SchemaKindAttributeType, s.May...)
+	}
+	v.checkCycles(SchemaKindObjectClass, v.store.objectClassSchemaIndex, func(generic *GenericSchema) (result []string) {
+		for _, superClassName := range generic.getValuesOfParameterizedKeyword("SUP") {
+			if supGenericSchema := v.store.findObjectClassGenericSchema(superClassName); nil != supGenericSchema {
+				result = append(result, supGenericSchema.NumericOID)
+			}
+		}
+		return
+	}, nil)
+	return nil
+}
+
+func (v *referenceValidator) validateDITContentRules() (err error) {
+	for _, oid := range sortedMapKey(v.store.ditContentRuleSchemaIndex) {
+		s, err := NewDITContentRuleSchemaViaGenericSchema(v.store.ditContentRuleSchemaIndex[oid])
+		if nil != err {
+			return err
+		}
+		v.check(SchemaKindDITContentRule, s.NumericOID, s.Name, "NUMERICOID", SchemaKindObjectClass, s.NumericOID)
+		v.check(SchemaKindDITContentRule, s.NumericOID, s.Name, "AUX", SchemaKindObjectClass, s.Aux...)
+		v.check(SchemaKindDITContentRule, s.NumericOID, s.Name, "MUST", SchemaKindAttributeType, s.Must...)
+		v.check(SchemaKindDITContentRule, s.NumericOID, s.Name, "MAY", SchemaKindAttributeType, s.May...)
+		v.check(SchemaKindDITContentRule, s.NumericOID, s.Name, "NOT", SchemaKindAttributeType, s.Not...)
+	}
+	return nil
+}
+
+func (v *referenceValidator) validateNameForms() (err error) {
+	for _, oid := range sortedMapKey(v.store.nameFormSchemaIndex) {
+		s, err := NewNameFormSchemaViaGenericSchema(v.store.nameFormSchemaIndex[oid])
+		if nil != err {
+			return err
+		}
+		v.check(SchemaKindNameForm, s.NumericOID, s.Name, "OC", SchemaKindObjectClass, s.ObjectClass)
+		v.check(SchemaKindNameForm, s.NumericOID, s.Name, "MUST", SchemaKindAttributeType, s.Must...)
+		v.check(SchemaKindNameForm, s.NumericOID, s.Name, "MAY", SchemaKindAttributeType, s.May...)
+	}
+	return nil
+}
+
+func (v *referenceValidator) validateDITStructureRules() (err error) {
+	for _, ruleID := range sortedMapKey(v.store.ditStructureRuleSchemaIndex) {
+		s, err := NewDITStructureRuleSchemaViaGenericSchema(v.store.ditStructureRuleSchemaIndex[ruleID])
+		if nil != err {
+			return err
+		}
+		v.check(SchemaKindDITStructureRule, s.RuleID, s.Name, "FORM", SchemaKindNameForm, s.NameForm)
+		v.check(SchemaKindDITStructureRule, s.RuleID, s.Name, "SUP", SchemaKindDITStructureRule, s.SuperRules...)
+	}
+	// Recursive rules listing itself in SUP are allowed (RFC 4512 4.1.7.1).
+	superRules := func(generic *GenericSchema) (result []string) {
+		for _, ruleID := range generic.getValuesOfParameterizedKeyword("SUP") {
+			if ruleID != generic.NumericOID {
+				result = append(result, ruleID)
+			}
+		}
+		return
+	}
+	reachRoot := v.ditStructureRulesReachingRoot(superRules)
+	v.checkCycles(SchemaKindDITStructureRule, v.store.ditStructureRuleSchemaIndex, superRules, func(cycle []string) bool {
+		for _, ruleID := range cycle {
+			if reachRoot[ruleID] {
+				return true
+			}
+		}
+		return false
+	})
+	return nil
+}
+
+// ditStructureRulesReachingRoot finds DIT structure rules which can reach a
+// rule without SUP through superior rules.
+func (v *referenceValidator) ditStructureRulesReachingRoot(superRules func(generic *GenericSchema) []string) (result map[string]bool) {
+	index := v.store.ditStructureRuleSchemaIndex
+	result = make(map[string]bool)
+	for ruleID, generic := range index {
+		if 0 == len(generic.getValuesOfParameterizedKeyword("SUP")) {
+			result[ruleID] = true
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for ruleID, generic := range index {
+			if result[ruleID] {
+				continue
+			}
+			for _, superRuleID := range superRules(generic) {
+				if result[superRuleID] {
+					result[ruleID] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return
+}
+
+// Validate checks references between schema elements in store.
+// All dangling references and cyclic SUP chains of attribute types, object
+// classes and DIT structure rules are reported at once with an
+// *ErrInvalidReferences. Cycles of DIT structure rules are reported only
+// when no rule in cycle reaches a rule without SUP. Other errors are returned when schema element
+// cannot be converted into typed schema.
+func (store *LDAPSchemaStore) Validate() (err error) {
+	v := &referenceValidator{
+		store: store,
+	}
+	for _, validate := range []func() error{
+		v.validateMatchingRules,
+		v.validateMatchingRuleUses,
+		v.validateAttributeTypes,
+		v.validateObjectClasses,
+		v.validateDITContentRules,
+		v.validateNameForms,
+		v.validateDITStructureRules,
+	} {
+		if err = validate(); nil != err {
+			return
+		}
+	}
+	if len(v.problems) > 0 {
+		return &ErrInvalidReferences{
+			Problems: v.problems,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package ldapschemaparser
+
+import (
+	"testing"
+)
+
+func TestLDAPSchemaStoreValidate_1(t *testing.T) {
+	store := NewLDAPSchemaStore()
+	if err := store.AddLDAPSyntaxSchemaText("( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )"); nil != err {
+		t.Fatalf("failed on adding LDAP syntax: %v", err)
+	}
+	for _, schemaText := range []string{
+		"( 1.3.6.1.4.1.99999.1.1 NAME 'sampleA' EQUALITY unknownMatch SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )",
+		"( 1.3.6.1.4.1.99999.1.2 NAME 'sampleB' SYNTAX 1.3.6.1.4.1.99999.9.9 )",
+		"( 1.3.6.1.4.1.99999.1.3 NAME 'sampleLoopA' SUP sampleLoopB )",
+		"( 1.3.6.1.4.1.99999.1.4 NAME 'sampleLoopB' SUP sampleLoopA )",
+	} {
+		if err := store.AddAttributeTypeSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding attribute type: %v", err)
+		}
+	}
+	if err := store.AddObjectClassSchemaText("( 1.3.6.1.4.1.99999.2.1 NAME 'sampleObject' SUP top MUST ( sampleA $ sampleMissing ) )"); nil != err {
+		t.Fatalf("failed on adding object class: %v", err)
+	}
+	err := store.Validate()
+	invalidReferences, ok := err.(*ErrInvalidReferences)
+	if !ok {
+		t.Fatalf("expecting *ErrInvalidReferences but have %T: %v", err, err)
+	}
+	expects := []string{
+		"attribute-type 1.3.6.1.4.1.99999.1.1 (sampleA): EQUALITY references missing matching-rule unknownMatch",
+		"attribute-type 1.3.6.1.4.1.99999.1.2 (sampleB): SYNTAX references missing ldap-syntax 1.3.6.1.4.1.99999.9.9",
+		"attribute-type 1.3.6.1.4.1.99999.1.3 (sampleLoopA): cyclic SUP chain: 1.3.6.1.4.1.99999.1.3 -> 1.3.6.1.4.1.99999.1.4",
+		"object-class 1.3.6.1.4.1.99999.2.1 (sampleObject): SUP references missing object-class top",
+		"object-class 1.3.6.1.4.1.99999.2.1 (sampleObject): MUST references missing attribute-type sampleMissing",
+	}
+	if len(invalidReferences.Problems) != len(expects) {
+		t.Fatalf("expecting %d problems but have %d: %v", len(expects), len(invalidReferences.Problems), err)
+	}
+	for idx, expect := range expects {
+		if v := invalidReferences.Problems[idx].String(); v != expect {
+			t.Errorf("expecting problem %d: %v but have %v", idx, expect, v)
+		}
+	}
+}
+
+func TestLDAPSchemaStoreValidate_2(t *testing.T) {
+	store := makeOpenLDAPConfigSampleStore(t)
+	store.AddLDAPSyntaxSchemaText("( 1.3.6.1.4.1.1466.115.121.1.15 DESC 'Directory String' )")
+	store.AddMatchingRuleSchemaText("( 2.5.13.2 NAME 'caseIgnoreMatch' SYNTAX 1.3.6.1.4.1.1466.115.121.1.15 )")
+	store.AddObjectClassSchemaText("( 2.5.6.0 NAME 'top' ABSTRACT )")
+	if err := store.Validate(); nil != err {
+		t.Errorf("expecting valid store: %v", err)
+	}
+}
+
+func TestLDAPSchemaStoreValidate_3(t *testing.T) {
+	store := makeDITStructureSampleStore(t)
+	addDITStructureSampleRules(t, store)
+	for _, schemaText := range []string{
+		"( 4 NAME 'sampleLinkedRuleA' FORM sampleUnitNameForm SUP 5 )",
+		"( 5 NAME 'sampleLinkedRuleB' FORM sampleUnitNameForm SUP ( 4 1 ) )",
+		"( 6 NAME 'sampleLoopRuleA' FORM sampleUnitNameForm SUP 7 )",
+		"( 7 NAME 'sampleLoopRuleB' FORM sampleUnitNameForm SUP ( 6 7 ) )",
+	} {
+		if err := store.AddDITStructureRuleSchemaText(schemaText); nil != err {
+			t.Fatalf("failed on adding DIT structure rule: %v", err)
+		}
+	}
+	err := store.Validate()
+	invalidReferences, ok := err.(*ErrInvalidReferences)
+	if !ok {
+		t.Fatalf("expecting *ErrInvalidReferences but have %T: %v", err, err)
+	}
+	var cycles []string
+	for _, problem := range invalidReferences.Problems {
+		if CyclicSuperior == problem.Type {
+			cycles = append(cycles, problem.String())
+		}
+	}
+	expect := "dit-structure-rule 6 (sampleLoopRuleA): cyclic SUP chain: 6 -> 7"
+	if (len(cycles) != 1) || (cycles[0] != expect) {
+		t.Errorf("expecting only cycle [%s] but have %v", expect, cycles)
+	}
+}